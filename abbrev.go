@@ -0,0 +1,66 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+	"strings"
+)
+
+// lookupAbbreviation returns the flag whose long name, or an alias of it,
+// starts with prefix, see AllowAbbreviations. A prefix starting with the
+// negative prefix also matches the negated form of negatable flags, in which
+// case negated is set.
+// It returns an AmbiguousFlagError if the prefix matches multiple flags, and
+// no flag if it matches none.
+func (fs *FlagSet) lookupAbbreviation(prefix string) (flag *Flag, negated bool, err error) {
+	type match struct {
+		flag    *Flag
+		negated bool
+	}
+	matches := make(map[match]bool)
+
+	normalized := string(fs.normalizeFlagName(prefix))
+	negativePrefix := fs.negativePrefix()
+	negatedPrefix := ""
+	if strings.HasPrefix(prefix, negativePrefix) {
+		negatedPrefix = string(fs.normalizeFlagName(prefix[len(negativePrefix):]))
+	}
+	for _, names := range []map[NormalizedName]*Flag{fs.formal, fs.aliases} {
+		for name, f := range names {
+			if f.ShorthandOnly || f.negates != nil {
+				continue
+			}
+			if strings.HasPrefix(string(name), normalized) {
+				matches[match{flag: f}] = true
+			}
+			if negatedPrefix != "" && isNegatable(f) && strings.HasPrefix(string(name), negatedPrefix) {
+				matches[match{flag: f, negated: true}] = true
+			}
+		}
+	}
+
+	if len(matches) > 1 {
+		candidates := make([]string, 0, len(matches))
+		for m := range matches {
+			if m.negated {
+				candidates = append(candidates, "--"+negativePrefix+m.flag.Name)
+			} else {
+				candidates = append(candidates, "--"+m.flag.Name)
+			}
+		}
+		sort.Strings(candidates)
+		return nil, false, AmbiguousFlagError{Name: prefix, Candidates: candidates}
+	}
+	for m := range matches {
+		return m.flag, m.negated, nil
+	}
+	return nil, false, nil
+}
+
+// isBuiltinName reports whether name is the long name of the built-in help or
+// version flag, which are never abbreviated.
+func (fs *FlagSet) isBuiltinName(name string) bool {
+	return (name == "help" && !fs.DisableBuiltinHelp) || (name == "version" && fs.versionFunc != nil)
+}
@@ -0,0 +1,117 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestAbbreviations(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		disabled    bool
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "unique prefix",
+			input: []string{"--verbose-l=3", "--col"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, 3, fs.MustGetInt("verbose-level"))
+				assertEqual(t, true, fs.MustGetBool("color"))
+			},
+		},
+		{
+			name:  "exact match wins over longer flags",
+			input: []string{"--verbose"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, 0, fs.MustGetInt("verbose-level"))
+			},
+		},
+		{
+			name:  "negated prefix",
+			input: []string{"--no-col"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+				assertEqual(t, true, fs.Changed("color"))
+			},
+		},
+		{
+			name:  "alias prefix",
+			input: []string{"--out", "x"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, "x", fs.MustGetString("output"))
+			},
+		},
+		{
+			name:        "ambiguous prefix",
+			input:       []string{"--verb"},
+			expectedErr: "ambiguous flag: --verb matches --verbose, --verbose-level",
+		},
+		{
+			name:        "shorthand only flags are not matched",
+			input:       []string{"--qui"},
+			expectedErr: "unknown flag: --qui",
+		},
+		{
+			name:        "disabled",
+			disabled:    true,
+			input:       []string{"--col"},
+			expectedErr: "unknown flag: --col (did you mean --color?)",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.AllowAbbreviations = !test.disabled
+			fs.Bool("verbose", false, "usage")
+			fs.Int("verbose-level", 0, "usage")
+			fs.Bool("color", true, "usage", zflag.OptAddNegative())
+			fs.String("output", "", "usage", zflag.OptAlias("out-file"))
+			fs.Bool("quiet", false, "usage", zflag.OptShorthand('q'), zflag.OptShorthandOnly())
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestAbbreviationsAmbiguousError(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithErrorHandling(zflag.ContinueOnError), zflag.WithOutput(ioutil.Discard), zflag.WithAbbreviations())
+	fs.Bool("cache", false, "usage", zflag.OptAddNegative())
+	fs.Bool("no-color", false, "usage")
+
+	err := fs.Parse([]string{"--no-c"})
+	var ambiguous zflag.AmbiguousFlagError
+	if !errors.As(err, &ambiguous) {
+		t.Fatalf("expected an AmbiguousFlagError, got %v", err)
+	}
+	assertEqual(t, "no-c", ambiguous.Name)
+	assertDeepEqual(t, []string{"--no-cache", "--no-color"}, ambiguous.Candidates)
+
+	var userErr zflag.UserInputError
+	if !errors.As(err, &userErr) {
+		t.Fatalf("expected a UserInputError, got %v", err)
+	}
+}
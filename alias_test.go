@@ -0,0 +1,127 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptAlias(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "canonical name", args: []string{"--color=red"}, expected: "red"},
+		{name: "alias", args: []string{"--colour=green"}, expected: "green"},
+		{name: "second alias", args: []string{"--colr", "blue"}, expected: "blue"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			color := f.String("color", "none", "color", zflag.OptAlias("colour"), zflag.OptAlias("colr"))
+			assertNoErr(t, f.Parse(test.args))
+
+			assertEqual(t, test.expected, *color)
+			assertEqual(t, f.Lookup("color"), f.Lookup("colour"))
+			assertEqual(t, true, f.Changed("colour"))
+			assertEqual(t, 1, f.NFlag())
+			assertEqual(t, "color", f.GetFlags()[0].Name)
+		})
+	}
+}
+
+func TestOptAliasNegated(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("color", true, "color", zflag.OptAlias("colour"), zflag.OptAddNegative())
+	assertNoErr(t, f.Parse([]string{"--no-colour"}))
+	assertEqual(t, false, f.MustGetBool("color"))
+	assertNoErr(t, f.Set("colour", "true"))
+	assertEqual(t, true, f.MustGetBool("color"))
+}
+
+func TestOptAliasUsage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.String("color", "", "color", zflag.OptAlias("colour"))
+	f.PrintDefaults()
+	assertEqual(t, "      --color string   color\n", buf.String())
+}
+
+func TestOptAliasRedefined(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		define func(f *zflag.FlagSet)
+	}{
+		{
+			name: "alias of existing flag",
+			define: func(f *zflag.FlagSet) {
+				f.String("colour", "", "colour")
+				f.String("color", "", "color", zflag.OptAlias("colour"))
+			},
+		},
+		{
+			name: "flag named like an alias",
+			define: func(f *zflag.FlagSet) {
+				f.String("color", "", "color", zflag.OptAlias("colour"))
+				f.String("colour", "", "colour")
+			},
+		},
+		{
+			name: "empty alias",
+			define: func(f *zflag.FlagSet) {
+				f.String("color", "", "color", zflag.OptAlias(""))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+
+			defer assertPanic(t)()
+			test.define(f)
+		})
+	}
+}
+
+func TestOptAliasNormalized(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("log-level", "", "log level", zflag.OptAlias("loglevel"))
+	f.SetNormalizeFunc(func(f *zflag.FlagSet, name string) zflag.NormalizedName {
+		return zflag.NormalizedName(strings.ReplaceAll(name, "_", "-"))
+	})
+	assertNoErr(t, f.Parse([]string{"--loglevel=debug"}))
+	assertEqual(t, "debug", f.MustGetString("log_level"))
+
+	f.RemoveFlag("log-level")
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("loglevel"))
+}
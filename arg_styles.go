@@ -0,0 +1,108 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// translateArg translates an argument in one of the alternative styles enabled
+// on the flag set into the equivalent dashed argument, see AllowSlashFlags and
+// SingleDashLongFlags. Other arguments are returned unchanged.
+func (fs *FlagSet) translateArg(s string) string {
+	if dashed, ok := fs.slashArg(s); ok {
+		return dashed
+	}
+	if dashed, ok := fs.singleDashArg(s); ok {
+		return dashed
+	}
+	return s
+}
+
+// singleDashArg translates the argument s, -name or -name=value, into the
+// equivalent long flag, see SingleDashLongFlags. It reports false if single
+// dash long flags are disabled, or s is a single shorthand, with or without a
+// value, or not a flag at all.
+func (fs *FlagSet) singleDashArg(s string) (string, bool) {
+	if !fs.SingleDashLongFlags || len(s) < 3 || s[0] != '-' || s[1] == '-' {
+		return "", false
+	}
+	name := s[1:]
+	if i := strings.Index(name, "="); i >= 0 {
+		name = name[:i]
+	}
+	if utf8.RuneCountInString(name) <= 1 {
+		return "", false
+	}
+	return "-" + s, true
+}
+
+// slashArg translates the Windows-style argument s, /name or /name:value, into
+// the equivalent dashed argument, see AllowSlashFlags. It reports false if
+// slash flags are disabled or s does not name a flag.
+func (fs *FlagSet) slashArg(s string) (string, bool) {
+	if !fs.AllowSlashFlags || len(s) < 2 || s[0] != '/' {
+		return "", false
+	}
+
+	name, value := s[1:], ""
+	i := strings.Index(name, ":")
+	if i >= 0 {
+		name, value = name[:i], name[i+1:]
+	}
+	if name == "?" {
+		name = "help"
+	}
+
+	var dashed string
+	switch {
+	case fs.isSlashShorthand(name):
+		dashed = "-" + name
+	case fs.isSlashLongName(name):
+		dashed = "--" + name
+	default:
+		return "", false
+	}
+	if i >= 0 {
+		dashed += "=" + value
+	}
+	return dashed, true
+}
+
+// isSlashShorthand reports whether name is a single character naming a
+// shorthand flag.
+func (fs *FlagSet) isSlashShorthand(name string) bool {
+	char, size := utf8.DecodeRuneInString(name)
+	if size == 0 || size != len(name) {
+		return false
+	}
+	if _, ok := fs.shorthands[char]; ok {
+		return true
+	}
+	return (char == 'h' && !fs.DisableBuiltinHelp) || (char == 'V' && fs.versionFunc != nil)
+}
+
+// isSlashLongName reports whether name is the long name of a flag, its negated
+// form, or a built-in flag.
+func (fs *FlagSet) isSlashLongName(name string) bool {
+	return fs.lookup(fs.normalizeFlagName(name)) != nil || fs.isBuiltinName(name) || fs.lookupNegated(name) != nil
+}
+
+// isNegativeNumber reports whether s is a negative number to be treated as a
+// positional argument, see NegativeNumbersAsArgs.
+func (fs *FlagSet) isNegativeNumber(s string) bool {
+	if !fs.NegativeNumbersAsArgs || len(s) < 2 || s[0] != '-' {
+		return false
+	}
+	if (s[1] < '0' || s[1] > '9') && s[1] != '.' {
+		return false
+	}
+	if _, ok := fs.shorthands[rune(s[1])]; ok {
+		return false
+	}
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
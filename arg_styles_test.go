@@ -0,0 +1,261 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSlashFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		disabled    bool
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "long flags",
+			input: []string{"/verbose", "/output:out.txt", "/level", "3"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, "out.txt", fs.MustGetString("output"))
+				assertEqual(t, 3, fs.MustGetInt("level"))
+				assertEqual(t, 0, fs.NArg())
+			},
+		},
+		{
+			name:  "shorthands",
+			input: []string{"/v", "/o:C:\\out.txt"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, "C:\\out.txt", fs.MustGetString("output"))
+			},
+		},
+		{
+			name:  "negated",
+			input: []string{"/no-color"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+			},
+		},
+		{
+			name:  "paths stay positional",
+			input: []string{"/usr/bin", "/unknown", "/", "/verbose"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertDeepEqual(t, []string{"/usr/bin", "/unknown", "/"}, fs.Args())
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+			},
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			input:    []string{"/verbose"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertDeepEqual(t, []string{"/verbose"}, fs.Args())
+				assertEqual(t, false, fs.MustGetBool("verbose"))
+			},
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"/level:x"},
+			expectedErr: `invalid argument "x" for "--level" flag: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:        "help",
+			input:       []string{"/?"},
+			expectedErr: zflag.ErrHelp.Error(),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.AllowSlashFlags = !test.disabled
+			fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			fs.String("output", "", "usage", zflag.OptShorthand('o'))
+			fs.Int("level", 0, "usage")
+			fs.Bool("color", true, "usage", zflag.OptAddNegative())
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestSlashFlagsClassify(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	fs := zflag.New("test", zflag.WithOutput(&out), zflag.WithSlashFlags())
+	fs.String("output", "", "usage")
+
+	tokens := fs.Classify([]string{"/output", "file", "/tmp"})
+	assertEqual(t, 3, len(tokens))
+	assertEqual(t, zflag.TokenLongFlag, tokens[0].Kind)
+	assertEqual(t, "/output", tokens[0].Arg)
+	assertEqual(t, zflag.TokenValue, tokens[1].Kind)
+	assertEqual(t, zflag.TokenPositional, tokens[2].Kind)
+}
+
+func TestSingleDashLongFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		disabled    bool
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "long flags",
+			input: []string{"-verbose", "-output=out.txt", "-level", "3", "--color=false"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, "out.txt", fs.MustGetString("output"))
+				assertEqual(t, 3, fs.MustGetInt("level"))
+				assertEqual(t, false, fs.MustGetBool("color"))
+			},
+		},
+		{
+			name:  "shorthands",
+			input: []string{"-v", "-o=out.txt"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, "out.txt", fs.MustGetString("output"))
+			},
+		},
+		{
+			name:  "negated",
+			input: []string{"-no-color"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+			},
+		},
+		{
+			name:        "clusters are not supported",
+			input:       []string{"-vo", "out.txt"},
+			expectedErr: "unknown flag: --vo",
+		},
+		{
+			name:     "disabled",
+			disabled: true,
+			input:    []string{"-vo", "out.txt"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, "out.txt", fs.MustGetString("output"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.DisableSuggestions = true
+			fs.SingleDashLongFlags = !test.disabled
+			fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			fs.String("output", "", "usage", zflag.OptShorthand('o'))
+			fs.Int("level", 0, "usage")
+			fs.Bool("color", true, "usage", zflag.OptAddNegative())
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestNegativeNumbersAsArgs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		disabled    bool
+		singleDash  bool
+		input       []string
+		expectedErr string
+		expected    []string
+	}{
+		{
+			name:     "numbers",
+			input:    []string{"-1", "-2.5", "-.5", "-1e3", "--", "-3"},
+			expected: []string{"-1", "-2.5", "-.5", "-1e3", "-3"},
+		},
+		{
+			name:     "flags are still parsed",
+			input:    []string{"-v", "-10", "-o", "out.txt"},
+			expected: []string{"-10"},
+		},
+		{
+			name:       "single dash long flags",
+			singleDash: true,
+			input:      []string{"-verbose", "-12", "-2.5", "-output=out.txt"},
+			expected:   []string{"-12", "-2.5"},
+		},
+		{
+			name:        "defined shorthand",
+			input:       []string{"-9"},
+			expectedErr: `flag needs an argument: '9' in -9`,
+		},
+		{
+			name:        "not a number",
+			input:       []string{"-1x"},
+			expectedErr: `unknown shorthand flag: '1' in -1x`,
+		},
+		{
+			name:        "disabled",
+			disabled:    true,
+			input:       []string{"-1"},
+			expectedErr: `unknown shorthand flag: '1' in -1`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.DisableSuggestions = true
+			fs.NegativeNumbersAsArgs = !test.disabled
+			fs.SingleDashLongFlags = test.singleDash
+			fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			fs.String("output", "", "usage", zflag.OptShorthand('o'))
+			fs.Int("nine", 0, "usage", zflag.OptShorthand('9'))
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, fs.Args())
+		})
+	}
+}
@@ -0,0 +1,146 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// ToArgs returns the command line arguments that reproduce the values of all
+// changed flags when passed to Parse on a FlagSet with the same definitions.
+// Slice flags are repeated once per element, or passed an empty value if they
+// have no elements, and map flags are repeated once per key.
+func (fs *FlagSet) ToArgs() []string {
+	args := make([]string, 0, len(fs.actual))
+	fs.Visit(func(flag *Flag) {
+		args = append(args, flagToArgs(flag)...)
+	})
+	return args
+}
+
+func flagToArgs(flag *Flag) []string {
+	values := flagValues(flag)
+	if values == nil {
+		return nil
+	}
+
+	prefix := "--" + flag.Name + "="
+	if flag.ShorthandOnly {
+		prefix = fmt.Sprintf("-%c=", flag.Shorthand)
+	}
+
+	args := make([]string, len(values))
+	for i, value := range values {
+		args[i] = prefix + value
+	}
+	return args
+}
+
+// flagValues returns the values that, when passed to Set in order, reproduce
+// the value of the flag. Slice flags have one value per element and map flags
+// one per key. Func flags hold no state that could be reproduced, so nil is
+// returned for them.
+func flagValues(flag *Flag) []string {
+	if _, isFunc := flag.Value.(*funcValue); isFunc {
+		return nil
+	}
+
+	if sv, ok := flag.Value.(SliceValue); ok {
+		values := sv.GetSlice()
+		if len(values) == 0 {
+			// an empty value sets the slice to no elements
+			return []string{""}
+		}
+		if ssv, ok := sv.(*stringSliceValue); ok && ssv.csv {
+			quoted := make([]string, 0, len(values))
+			for _, elem := range values {
+				quoted = append(quoted, writeCSVField(elem))
+			}
+			return quoted
+		}
+		return values
+	}
+
+	if mv, ok := flag.Value.(*stringToStringValue); ok && mv.csv {
+		keys := make([]string, 0, len(*mv.value))
+		for k := range *mv.value {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		values := make([]string, 0, len(keys))
+		for _, k := range keys {
+			values = append(values, writeCSVField(k+"="+(*mv.value)[k]))
+		}
+		return values
+	}
+
+	if getter, ok := flag.Value.(Getter); ok {
+		v := reflect.ValueOf(getter.Get())
+		if v.IsValid() && v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String {
+			keys := make([]string, 0, v.Len())
+			for _, k := range v.MapKeys() {
+				keys = append(keys, k.String())
+			}
+			sort.Strings(keys)
+
+			values := make([]string, 0, len(keys))
+			for _, k := range keys {
+				elem := v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key()))
+				values = append(values, fmt.Sprintf("%s=%v", k, elem.Interface()))
+			}
+			return values
+		}
+	}
+
+	return []string{flag.Value.String()}
+}
+
+// QuoteWindowsArg quotes arg so that it is parsed back unchanged by
+// CommandLineToArgvW, and thereby by Go programs on Windows. Arguments
+// without whitespace or double quotes are returned as is.
+func QuoteWindowsArg(arg string) string {
+	if arg == "" {
+		return `""`
+	}
+	if !strings.ContainsAny(arg, " \t\n\v\"") {
+		return arg
+	}
+
+	var b strings.Builder
+	b.WriteByte('"')
+	backslashes := 0
+	for i := 0; i < len(arg); i++ {
+		switch arg[i] {
+		case '\\':
+			backslashes++
+			continue
+		case '"':
+			// backslashes preceding a quote must be escaped, as well as the quote itself
+			b.WriteString(strings.Repeat(`\`, backslashes*2+1))
+		default:
+			b.WriteString(strings.Repeat(`\`, backslashes))
+		}
+		backslashes = 0
+		b.WriteByte(arg[i])
+	}
+	// backslashes preceding the closing quote must be escaped
+	b.WriteString(strings.Repeat(`\`, backslashes*2))
+	b.WriteByte('"')
+	return b.String()
+}
+
+// JoinWindowsArgs quotes each of args with QuoteWindowsArg and joins them into
+// a single command line, e.g. for the output of ToArgs to be passed to a child
+// process on Windows with syscall.SysProcAttr.CmdLine.
+func JoinWindowsArgs(args []string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = QuoteWindowsArg(arg)
+	}
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,101 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxAtomicShorthandLen is the maximum number of runes of an atomic
+// shorthand, see OptAtomicShorthand.
+const MaxAtomicShorthandLen = 4
+
+// OptAtomicShorthand sets a shorthand of two up to MaxAtomicShorthandLen
+// runes, e.g. -rf, for programs emulating the interface of tools like tar or
+// ps. An argument matching an atomic shorthand, optionally followed by
+// =value, takes precedence over splitting it into single-letter shorthands.
+func OptAtomicShorthand(shorthand string) Opt {
+	return func(f *Flag) error {
+		n := utf8.RuneCountInString(shorthand)
+		if n < 2 || n > MaxAtomicShorthandLen {
+			return fmt.Errorf("atomic shorthand %q for flag %q must be 2 to %d runes long", shorthand, f.Name, MaxAtomicShorthandLen)
+		}
+		if strings.HasPrefix(shorthand, "-") || strings.Contains(shorthand, "=") {
+			return fmt.Errorf("atomic shorthand %q for flag %q must not start with '-' or contain '='", shorthand, f.Name)
+		}
+		f.AtomicShorthand = shorthand
+		return nil
+	}
+}
+
+// addAtomicShorthand registers the atomic shorthand of flag, which must not be
+// used by any other flag.
+func (fs *FlagSet) addAtomicShorthand(flag *Flag) {
+	if flag.AtomicShorthand == "" {
+		return
+	}
+	if used, alreadyThere := fs.atomicShorthands[flag.AtomicShorthand]; alreadyThere {
+		msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", flag.AtomicShorthand, fs.name, used.Name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+	if fs.atomicShorthands == nil {
+		fs.atomicShorthands = make(map[string]*Flag)
+	}
+	fs.atomicShorthands[flag.AtomicShorthand] = flag
+}
+
+// lookupAtomicShorthand returns the flag whose atomic shorthand is the
+// shorthand cluster, up to an '='.
+func (fs *FlagSet) lookupAtomicShorthand(cluster string) *Flag {
+	if i := strings.Index(cluster, "="); i >= 0 {
+		cluster = cluster[:i]
+	}
+	return fs.atomicShorthands[cluster]
+}
+
+// parseAtomicShorthandArg parses the argument s, which matches the atomic
+// shorthand of flag, like a single shorthand: -rf=arg, -rf arg, or -rf for
+// bool and optional values and flags with a NoArgDefault.
+func (fs *FlagSet) parseAtomicShorthandArg(s string, flag *Flag, args []string, fn parseFunc) (outArgs []string, err error) {
+	outArgs = args
+	rest := s[1+len(flag.AtomicShorthand):]
+
+	_, flagIsBool := flag.Value.(BoolFlag)
+	_, isOptional := flag.Value.(OptionalValue)
+	nextArgIsFlagValue := len(args) > 0 && len(args[0]) > 0 && args[0][0] != '-'
+
+	var value string
+	switch {
+	case strings.HasPrefix(rest, "="):
+		// '-rf=arg'
+		value = rest[1:]
+	case flag.NoArgDefault != "":
+		// '-rf' (arg defaults to NoArgDefault)
+		value = flag.NoArgDefault
+	case nextArgIsFlagValue && (!flagIsBool || isBool(args[0])):
+		// '-rf arg'
+		value = args[0]
+		outArgs = args[1:]
+		if err = fs.checkConsumedValue(flag, value); err != nil {
+			return
+		}
+	case flagIsBool, isOptional:
+		// '-rf' (arg was optional)
+		value = ""
+	default:
+		// '-rf' (arg was required)
+		err = fs.failf("flag needs an argument: %s", s)
+		return
+	}
+
+	err = fn(flag, value)
+	if err != nil {
+		err = fs.failf("%w", err)
+		return
+	}
+	return fs.consumeGreedy(flag, false, outArgs, fn)
+}
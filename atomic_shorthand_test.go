@@ -0,0 +1,151 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestAtomicShorthand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "bool",
+			input: []string{"-rf"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("force-recursive"))
+				assertEqual(t, false, fs.MustGetBool("recursive"))
+				assertEqual(t, false, fs.MustGetBool("force"))
+			},
+		},
+		{
+			name:  "bool with value",
+			input: []string{"-rf=false"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("force-recursive"))
+				assertEqual(t, true, fs.Changed("force-recursive"))
+			},
+		},
+		{
+			name:  "value",
+			input: []string{"-xz", "gzip", "-ef=bzip2"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, "gzip", fs.MustGetString("compress"))
+				assertEqual(t, "bzip2", fs.MustGetString("format"))
+			},
+		},
+		{
+			name:  "other clusters are split",
+			input: []string{"-fr", "-rfr"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("recursive"))
+				assertEqual(t, true, fs.MustGetBool("force"))
+				assertEqual(t, false, fs.MustGetBool("force-recursive"))
+			},
+		},
+		{
+			name:        "missing value",
+			input:       []string{"-xz"},
+			expectedErr: "flag needs an argument: -xz",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.Bool("recursive", false, "usage", zflag.OptShorthand('r'))
+			fs.Bool("force", false, "usage", zflag.OptShorthand('f'))
+			fs.Bool("force-recursive", false, "usage", zflag.OptAtomicShorthand("rf"))
+			fs.String("compress", "", "usage", zflag.OptAtomicShorthand("xz"))
+			fs.String("format", "", "usage", zflag.OptAtomicShorthand("ef"))
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestAtomicShorthandUsage(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test")
+	fs.Bool("force", false, "force", zflag.OptShorthand('f'))
+	fs.Bool("force-recursive", false, "force recursively", zflag.OptAtomicShorthand("rf"))
+	fs.String("compress", "", "compression", zflag.OptShorthand('c'), zflag.OptAtomicShorthand("xz"))
+
+	expected := `  -c, -xz, --compress string   compression
+  -f, --force                  force
+  -rf, --force-recursive       force recursively
+`
+	assertEqualf(t, expected, fs.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, fs.FlagUsages())
+
+	tokens := fs.Classify([]string{"-xz", "gzip", "-rf"})
+	assertEqual(t, 3, len(tokens))
+	assertEqual(t, zflag.TokenValue, tokens[1].Kind)
+	assertEqual(t, "force-recursive", tokens[2].Flags[0].Name)
+
+	var out bytes.Buffer
+	assertNoErr(t, fs.WriteHelpJSON(&out))
+	proxy, err := zflag.ReadHelpJSON(&out)
+	assertNoErr(t, err)
+	assertNoErr(t, proxy.Parse([]string{"-xz", "gzip"}))
+	assertDeepEqual(t, []string{"--compress=gzip"}, proxy.ToArgs())
+}
+
+func TestAtomicShorthandInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		shorthand string
+	}{
+		{name: "single rune", shorthand: "r"},
+		{name: "too long", shorthand: "abcde"},
+		{name: "leading dash", shorthand: "-r"},
+		{name: "equals", shorthand: "r="},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+
+			defer assertPanic(t)()
+			fs.Bool("flag", false, "usage", zflag.OptAtomicShorthand(test.shorthand))
+		})
+	}
+}
+
+func TestAtomicShorthandRedefined(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.Bool("first", false, "usage", zflag.OptAtomicShorthand("rf"))
+
+	defer assertPanic(t)()
+	fs.Bool("second", false, "usage", zflag.OptAtomicShorthand("rf"))
+}
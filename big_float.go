@@ -0,0 +1,95 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// -- big.Float Value
+type bigFloatValue big.Float
+
+var _ Value = (*bigFloatValue)(nil)
+var _ Getter = (*bigFloatValue)(nil)
+var _ Typed = (*bigFloatValue)(nil)
+
+func newBigFloatValue(val *big.Float, p *big.Float) *bigFloatValue {
+	if val != nil {
+		p.Set(val)
+	}
+	return (*bigFloatValue)(p)
+}
+
+// Set parses val with the precision of the flag value, or 64 bits if the
+// precision is 0.
+func (b *bigFloatValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	f := (*big.Float)(b)
+	v, ok := new(big.Float).SetPrec(f.Prec()).SetString(val)
+	if !ok {
+		return fmt.Errorf("failed to parse float: %q", val)
+	}
+	f.Set(v)
+	return nil
+}
+
+func (b *bigFloatValue) Get() interface{} {
+	return (*big.Float)(b)
+}
+
+func (b *bigFloatValue) Type() string {
+	return "bigFloat"
+}
+
+func (b *bigFloatValue) String() string { return (*big.Float)(b).Text('g', -1) }
+
+// GetBigFloat return the *big.Float value of a flag with the given name
+func (fs *FlagSet) GetBigFloat(name string) (*big.Float, error) {
+	val, err := fs.getFlagValue(name, "bigFloat")
+	if err != nil {
+		return nil, err
+	}
+	return val.(*big.Float), nil
+}
+
+// MustGetBigFloat is like GetBigFloat, but panics on error.
+func (fs *FlagSet) MustGetBigFloat(name string) *big.Float {
+	val, err := fs.GetBigFloat(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// BigFloatVar defines a big.Float flag with specified name, default value, and usage string.
+// The argument p points to a big.Float variable in which to store the value of the flag.
+// Values are parsed with the precision of p, which is taken from the default
+// value if p has none yet, or 64 bits if neither has a precision.
+func (fs *FlagSet) BigFloatVar(p *big.Float, name string, value *big.Float, usage string, opts ...Opt) {
+	fs.Var(newBigFloatValue(value, p), name, usage, opts...)
+}
+
+// BigFloatVar defines a big.Float flag with specified name, default value, and usage string.
+// The argument p points to a big.Float variable in which to store the value of the flag.
+// Values are parsed with the precision of p, which is taken from the default
+// value if p has none yet, or 64 bits if neither has a precision.
+func BigFloatVar(p *big.Float, name string, value *big.Float, usage string, opts ...Opt) {
+	CommandLine.BigFloatVar(p, name, value, usage, opts...)
+}
+
+// BigFloat defines a big.Float flag with specified name, default value, and usage string.
+// The return value is the address of a big.Float variable that stores the value of the flag.
+func (fs *FlagSet) BigFloat(name string, value *big.Float, usage string, opts ...Opt) *big.Float {
+	p := new(big.Float)
+	fs.BigFloatVar(p, name, value, usage, opts...)
+	return p
+}
+
+// BigFloat defines a big.Float flag with specified name, default value, and usage string.
+// The return value is the address of a big.Float variable that stores the value of the flag.
+func BigFloat(name string, value *big.Float, usage string, opts ...Opt) *big.Float {
+	return CommandLine.BigFloat(name, value, usage, opts...)
+}
@@ -0,0 +1,104 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestBigFloat(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault *big.Float
+		input       []string
+		expectedErr string
+		expected    string
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: "0",
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--ratio" flag: failed to parse float: ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"abc"},
+			expectedErr: `invalid argument "abc" for "--ratio" flag: failed to parse float: "abc"`,
+		},
+		{
+			name:     "decimal",
+			input:    []string{"1.5"},
+			expected: "1.5",
+		},
+		{
+			name:     "exponent larger than float64",
+			input:    []string{"1e400"},
+			expected: "1e+400",
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: big.NewFloat(2.25),
+			expected:    "2.25",
+		},
+		{
+			name:        "precision of default value",
+			input:       []string{"0.1"},
+			flagDefault: new(big.Float).SetPrec(8),
+			expected:    "0.1",
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    -3    "},
+			expected: "-3",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v big.Float
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.BigFloatVar(&v, "ratio", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--ratio", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v.Text('g', -1))
+
+			got, err := f.GetBigFloat("ratio")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got.Text('g', -1))
+			assertEqual(t, test.expected, f.MustGetBigFloat("ratio").Text('g', -1))
+		})
+	}
+}
+
+func TestBigFloatPrecision(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	v := f.BigFloat("ratio", new(big.Float).SetPrec(200), "usage")
+	assertNoErr(t, f.Parse([]string{"--ratio=0.1"}))
+	assertEqual(t, uint(200), v.Prec())
+
+	v = f.BigFloat("other", nil, "usage")
+	assertNoErr(t, f.Parse([]string{"--other=0.1"}))
+	assertEqual(t, uint(64), v.Prec())
+}
@@ -0,0 +1,90 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// -- big.Int Value
+type bigIntValue big.Int
+
+var _ Value = (*bigIntValue)(nil)
+var _ Getter = (*bigIntValue)(nil)
+var _ Typed = (*bigIntValue)(nil)
+
+func newBigIntValue(val *big.Int, p *big.Int) *bigIntValue {
+	if val != nil {
+		p.Set(val)
+	}
+	return (*bigIntValue)(p)
+}
+
+func (b *bigIntValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	v, ok := new(big.Int).SetString(val, 0)
+	if !ok {
+		return fmt.Errorf("failed to parse integer: %q", val)
+	}
+	(*big.Int)(b).Set(v)
+	return nil
+}
+
+func (b *bigIntValue) Get() interface{} {
+	return (*big.Int)(b)
+}
+
+func (b *bigIntValue) Type() string {
+	return "bigInt"
+}
+
+func (b *bigIntValue) String() string { return (*big.Int)(b).String() }
+
+// GetBigInt return the *big.Int value of a flag with the given name
+func (fs *FlagSet) GetBigInt(name string) (*big.Int, error) {
+	val, err := fs.getFlagValue(name, "bigInt")
+	if err != nil {
+		return nil, err
+	}
+	return val.(*big.Int), nil
+}
+
+// MustGetBigInt is like GetBigInt, but panics on error.
+func (fs *FlagSet) MustGetBigInt(name string) *big.Int {
+	val, err := fs.GetBigInt(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// BigIntVar defines a big.Int flag with specified name, default value, and usage string.
+// The argument p points to a big.Int variable in which to store the value of the flag.
+// A nil default value leaves p unchanged.
+func (fs *FlagSet) BigIntVar(p *big.Int, name string, value *big.Int, usage string, opts ...Opt) {
+	fs.Var(newBigIntValue(value, p), name, usage, opts...)
+}
+
+// BigIntVar defines a big.Int flag with specified name, default value, and usage string.
+// The argument p points to a big.Int variable in which to store the value of the flag.
+// A nil default value leaves p unchanged.
+func BigIntVar(p *big.Int, name string, value *big.Int, usage string, opts ...Opt) {
+	CommandLine.BigIntVar(p, name, value, usage, opts...)
+}
+
+// BigInt defines a big.Int flag with specified name, default value, and usage string.
+// The return value is the address of a big.Int variable that stores the value of the flag.
+func (fs *FlagSet) BigInt(name string, value *big.Int, usage string, opts ...Opt) *big.Int {
+	p := new(big.Int)
+	fs.BigIntVar(p, name, value, usage, opts...)
+	return p
+}
+
+// BigInt defines a big.Int flag with specified name, default value, and usage string.
+// The return value is the address of a big.Int variable that stores the value of the flag.
+func BigInt(name string, value *big.Int, usage string, opts ...Opt) *big.Int {
+	return CommandLine.BigInt(name, value, usage, opts...)
+}
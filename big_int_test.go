@@ -0,0 +1,116 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func mustBigInt(t *testing.T, s string) *big.Int {
+	t.Helper()
+	v, ok := new(big.Int).SetString(s, 0)
+	if !ok {
+		t.Fatalf("invalid big.Int %q", s)
+	}
+	return v
+}
+
+func TestBigInt(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault string
+		input       []string
+		expectedErr string
+		expected    string
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: "0",
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--amount" flag: failed to parse integer: ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"1.5"},
+			expectedErr: `invalid argument "1.5" for "--amount" flag: failed to parse integer: "1.5"`,
+		},
+		{
+			name:     "larger than int64",
+			input:    []string{"123456789012345678901234567890"},
+			expected: "123456789012345678901234567890",
+		},
+		{
+			name:     "negative",
+			input:    []string{"-42"},
+			expected: "-42",
+		},
+		{
+			name:     "hex",
+			input:    []string{"0xff"},
+			expected: "255",
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: "99999999999999999999",
+			expected:    "99999999999999999999",
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    7    "},
+			expected: "7",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var def *big.Int
+			if test.flagDefault != "" {
+				def = mustBigInt(t, test.flagDefault)
+			}
+
+			var v big.Int
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.BigIntVar(&v, "amount", def, "usage")
+			err := f.Parse(repeatFlag("--amount", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v.String())
+
+			got, err := f.GetBigInt("amount")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got.String())
+			assertEqual(t, test.expected, f.MustGetBigInt("amount").String())
+		})
+	}
+}
+
+func TestBigIntDefaultNotShared(t *testing.T) {
+	t.Parallel()
+
+	def := big.NewInt(10)
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	v := f.BigInt("amount", def, "usage")
+	assertNoErr(t, f.Parse([]string{"--amount=20"}))
+	assertEqual(t, "20", v.String())
+	assertEqual(t, "10", def.String())
+	assertEqual(t, "10", f.Lookup("amount").DefValue)
+}
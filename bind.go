@@ -0,0 +1,82 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+)
+
+// BindSetter connects the named flag to setter, which is called with the new
+// value every time the flag is set, e.g. to forward parsed values to an
+// existing settings object. The value passed is the result of Get if the flag
+// value implements Getter, or its String representation otherwise. An error
+// returned by setter is treated as a flag value parsing error.
+func (fs *FlagSet) BindSetter(name string, setter func(v interface{}) error) error {
+	flag := fs.Lookup(name)
+	if flag == nil {
+		return NewUnknownFlagError(name)
+	}
+
+	flag.setHooks = append(flag.setHooks, func() error {
+		return setter(typedValue(flag.Value))
+	})
+	return nil
+}
+
+// BindSetter connects the named command-line flag to setter.
+// See FlagSet.BindSetter for more information.
+func BindSetter(name string, setter func(v interface{}) error) error {
+	return CommandLine.BindSetter(name, setter)
+}
+
+// OptOnChange calls fn after every successful Set of the flag, with the
+// values before and after the Set, e.g. to reconfigure a logger when the log
+// level changes. The values are the result of Get if the flag value
+// implements Getter, or its String representation otherwise. Maps and big
+// numbers are copied, so the old value is not affected by the Set. It may be
+// repeated to add several functions.
+func OptOnChange(fn func(old, new interface{})) Opt {
+	return func(f *Flag) error {
+		if fn == nil {
+			return fmt.Errorf("change func for flag %q must be set", f.Name)
+		}
+		f.onChange = append(f.onChange, fn)
+		return nil
+	}
+}
+
+// typedValue returns the result of Get if v implements Getter, or its String
+// representation otherwise.
+func typedValue(v Value) interface{} {
+	if getter, ok := v.(Getter); ok {
+		return getter.Get()
+	}
+	return v.String()
+}
+
+// snapshotValue is like typedValue, but copies maps and big numbers, which
+// are modified in place by Set.
+func snapshotValue(v Value) interface{} {
+	value := typedValue(v)
+	switch n := value.(type) {
+	case *big.Int:
+		return new(big.Int).Set(n)
+	case *big.Float:
+		return new(big.Float).Copy(n)
+	}
+
+	m := reflect.ValueOf(value)
+	if m.Kind() != reflect.Map || m.IsNil() {
+		return value
+	}
+
+	c := reflect.MakeMapWithSize(m.Type(), m.Len())
+	iter := m.MapRange()
+	for iter.Next() {
+		c.SetMapIndex(iter.Key(), iter.Value())
+	}
+	return c.Interface()
+}
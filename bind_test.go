@@ -0,0 +1,132 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"math/big"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type legacySettings struct {
+	values map[string]interface{}
+}
+
+func (s *legacySettings) Set(key string) func(v interface{}) error {
+	return func(v interface{}) error {
+		if v == 13 {
+			return errors.New("unlucky number")
+		}
+		s.values[key] = v
+		return nil
+	}
+}
+
+func TestBindSetter(t *testing.T) {
+	t.Parallel()
+
+	settings := &legacySettings{values: map[string]interface{}{}}
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("port", 80, "usage")
+	f.StringSlice("tags", nil, "usage")
+	f.Var(new(flagVar), "custom", "usage")
+	assertNoErr(t, f.BindSetter("port", settings.Set("port")))
+	assertNoErr(t, f.BindSetter("tags", settings.Set("tags")))
+	assertNoErr(t, f.BindSetter("custom", settings.Set("custom")))
+	assertErrMsg(t, "unknown flag: --unknown", f.BindSetter("unknown", settings.Set("unknown")))
+
+	assertNoErr(t, f.Parse([]string{"--port=8080", "--tags=a", "--tags=b", "--custom=x"}))
+	assertDeepEqual(t, map[string]interface{}{
+		"port":   8080,
+		"tags":   []string{"a", "b"},
+		"custom": "[x]",
+	}, settings.values)
+
+	err := f.Parse([]string{"--port=13"})
+	assertErrMsg(t, `invalid argument "13" for "--port" flag: unlucky number`, err)
+}
+
+func TestSetHookErrorLeavesFlagUnchanged(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("port", 80, "usage")
+	f.StringSlice("tags", []string{"a"}, "usage")
+	reject := func(interface{}) error { return errors.New("rejected") }
+	assertNoErr(t, f.BindSetter("port", reject))
+	assertNoErr(t, f.BindSetter("tags", reject))
+
+	assertErrMsg(t, `invalid argument "8080" for "--port" flag: rejected`, f.Set("port", "8080"))
+	assertEqual(t, 80, f.MustGetInt("port"))
+	assertEqual(t, false, f.Changed("port"))
+	assertEqual(t, "", f.Lookup("port").Source)
+
+	assertErr(t, f.Set("tags", "b"))
+	assertDeepEqual(t, []string{"a"}, f.MustGetStringSlice("tags"))
+	assertEqual(t, false, f.Changed("tags"))
+	assertEqual(t, 0, f.NFlag())
+}
+
+func TestOptOnChange(t *testing.T) {
+	t.Parallel()
+
+	type change struct {
+		old, new interface{}
+	}
+	var levels, labels []change
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("log-level", "info", "usage", zflag.OptOnChange(func(old, new interface{}) {
+		levels = append(levels, change{old, new})
+	}))
+	f.StringToString("labels", nil, "usage", zflag.OptOnChange(func(old, new interface{}) {
+		labels = append(labels, change{old, new})
+	}))
+
+	assertNoErr(t, f.Parse([]string{"--log-level=debug", "--labels=a=1", "--labels=b=2"}))
+	assertNoErr(t, f.Set("log-level", "warn"))
+	assertErr(t, f.Set("labels", "invalid"))
+
+	assertDeepEqual(t, []change{{"info", "debug"}, {"debug", "warn"}}, levels)
+	assertDeepEqual(t, []change{
+		{map[string]string(nil), map[string]string{"a": "1"}},
+		{map[string]string{"a": "1"}, map[string]string{"a": "1", "b": "2"}},
+	}, labels)
+}
+
+func TestOptOnChangeBig(t *testing.T) {
+	t.Parallel()
+
+	var ints, floats [][2]string
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BigInt("count", big.NewInt(1), "usage", zflag.OptOnChange(func(old, new interface{}) {
+		ints = append(ints, [2]string{old.(*big.Int).String(), new.(*big.Int).String()})
+	}))
+	f.BigFloat("ratio", big.NewFloat(0.5), "usage", zflag.OptOnChange(func(old, new interface{}) {
+		floats = append(floats, [2]string{old.(*big.Float).String(), new.(*big.Float).String()})
+	}))
+
+	assertNoErr(t, f.Parse([]string{"--count=5", "--ratio=1.5"}))
+	assertNoErr(t, f.Set("count", "7"))
+
+	assertDeepEqual(t, [][2]string{{"1", "5"}, {"5", "7"}}, ints)
+	assertDeepEqual(t, [][2]string{{"0.5", "1.5"}}, floats)
+}
+
+func TestOptOnChangeNil(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.String("log-level", "info", "usage", zflag.OptOnChange(nil))
+}
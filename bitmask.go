@@ -0,0 +1,147 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// -- bitmask Value
+type bitmaskValue struct {
+	value   *uint64
+	bits    map[string]uint64
+	names   []string
+	changed bool
+}
+
+var _ Value = (*bitmaskValue)(nil)
+var _ Getter = (*bitmaskValue)(nil)
+var _ Typed = (*bitmaskValue)(nil)
+var _ ChoicesValue = (*bitmaskValue)(nil)
+
+func newBitmaskValue(val uint64, p *uint64, bits map[string]uint64) *bitmaskValue {
+	if len(bits) == 0 {
+		panic("bitmask flags require at least one named bit")
+	}
+
+	names := make([]string, 0, len(bits))
+	for name := range bits {
+		names = append(names, name)
+	}
+	// single bits sort before the combinations containing them
+	sort.Slice(names, func(i, j int) bool {
+		if bits[names[i]] != bits[names[j]] {
+			return bits[names[i]] < bits[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	*p = val
+	return &bitmaskValue{value: p, bits: bits, names: names}
+}
+
+// Set ORs the bits of the comma separated names into the mask. The first call
+// replaces the default value.
+func (b *bitmaskValue) Set(val string) error {
+	var mask uint64
+	for _, name := range strings.Split(val, ",") {
+		name = strings.TrimSpace(name)
+		bit, ok := b.bits[name]
+		if !ok {
+			return fmt.Errorf("unknown bit %q, expected one of %s", name, strings.Join(b.names, ", "))
+		}
+		mask |= bit
+	}
+
+	if !b.changed {
+		*b.value = 0
+	}
+	*b.value |= mask
+	b.changed = true
+	return nil
+}
+
+func (b *bitmaskValue) Get() interface{} {
+	return *b.value
+}
+
+func (b *bitmaskValue) Type() string {
+	return "bitmask"
+}
+
+// String returns the names of the set bits, followed by the remaining bits
+// not covered by any name in hexadecimal.
+func (b *bitmaskValue) String() string {
+	remaining := *b.value
+	var names []string
+	for _, name := range b.names {
+		bit := b.bits[name]
+		if bit != 0 && *b.value&bit == bit && remaining&bit != 0 {
+			names = append(names, name)
+			remaining &^= bit
+		}
+	}
+	if remaining != 0 {
+		names = append(names, fmt.Sprintf("0x%x", remaining))
+	}
+	return strings.Join(names, ",")
+}
+
+func (b *bitmaskValue) Choices() []string {
+	return b.names
+}
+
+func (b *bitmaskValue) Delimiter() string {
+	return ","
+}
+
+// GetBitmask return the uint64 mask of a bitmask flag with the given name
+func (fs *FlagSet) GetBitmask(name string) (uint64, error) {
+	val, err := fs.getFlagValue(name, "bitmask")
+	if err != nil {
+		return 0, err
+	}
+	return val.(uint64), nil
+}
+
+// MustGetBitmask is like GetBitmask, but panics on error.
+func (fs *FlagSet) MustGetBitmask(name string) uint64 {
+	val, err := fs.GetBitmask(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// BitmaskVar defines a bitmask flag with specified name, default value, and usage string.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+// The flag accepts comma separated names of bits, which are looked up in bits and OR-ed
+// together, e.g. --features a,b --features c. The available names are listed in usage.
+func (fs *FlagSet) BitmaskVar(p *uint64, name string, value uint64, bits map[string]uint64, usage string, opts ...Opt) {
+	fs.Var(newBitmaskValue(value, p, bits), name, usage, opts...)
+}
+
+// BitmaskVar defines a bitmask flag with specified name, default value, and usage string.
+// The argument p points to a uint64 variable in which to store the value of the flag.
+// The flag accepts comma separated names of bits, which are looked up in bits and OR-ed
+// together, e.g. --features a,b --features c. The available names are listed in usage.
+func BitmaskVar(p *uint64, name string, value uint64, bits map[string]uint64, usage string, opts ...Opt) {
+	CommandLine.BitmaskVar(p, name, value, bits, usage, opts...)
+}
+
+// Bitmask defines a bitmask flag with specified name, default value, and usage string.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func (fs *FlagSet) Bitmask(name string, value uint64, bits map[string]uint64, usage string, opts ...Opt) *uint64 {
+	var p uint64
+	fs.BitmaskVar(&p, name, value, bits, usage, opts...)
+	return &p
+}
+
+// Bitmask defines a bitmask flag with specified name, default value, and usage string.
+// The return value is the address of a uint64 variable that stores the value of the flag.
+func Bitmask(name string, value uint64, bits map[string]uint64, usage string, opts ...Opt) *uint64 {
+	return CommandLine.Bitmask(name, value, bits, usage, opts...)
+}
@@ -0,0 +1,118 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+var featureBits = map[string]uint64{
+	"read":  1,
+	"write": 2,
+	"exec":  4,
+	"rw":    3,
+}
+
+func TestBitmask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault uint64
+		input       []string
+		expectedErr string
+		expected    uint64
+		expectedStr string
+	}{
+		{
+			name:        "no value passed",
+			input:       []string{},
+			flagDefault: 1,
+			expected:    1,
+			expectedStr: "read",
+		},
+		{
+			name:        "comma separated",
+			input:       []string{"read, exec"},
+			expected:    5,
+			expectedStr: "read,exec",
+		},
+		{
+			name:        "repeated flags are combined",
+			input:       []string{"write", "exec"},
+			flagDefault: 1,
+			expected:    6,
+			expectedStr: "write,exec",
+		},
+		{
+			name:        "combined bits",
+			input:       []string{"rw"},
+			expected:    3,
+			expectedStr: "read,write",
+		},
+		{
+			name:        "unknown bit",
+			input:       []string{"read,delete"},
+			expectedErr: `invalid argument "read,delete" for "--features" flag: unknown bit "delete", expected one of read, write, rw, exec`,
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--features" flag: unknown bit "", expected one of read, write, rw, exec`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var features uint64
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.BitmaskVar(&features, "features", test.flagDefault, featureBits, "usage")
+			err := f.Parse(repeatFlag("--features", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, features)
+			assertEqual(t, test.expectedStr, f.Lookup("features").Value.String())
+
+			got, err := f.GetBitmask("features")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetBitmask("features"))
+		})
+	}
+}
+
+func TestBitmaskUnnamedBits(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Bitmask("features", 0x19, featureBits, "usage")
+	assertEqual(t, "read,0x18", f.Lookup("features").DefValue)
+}
+
+func TestBitmaskUsage(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Bitmask("features", 3, featureBits, "enabled features")
+	expected := "      --features read|write|rw|exec   enabled features (default read,write)\n"
+	assertEqual(t, expected, f.FlagUsages())
+}
+
+func TestBitmaskNoBits(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.Bitmask("features", 0, nil, "usage")
+}
@@ -19,6 +19,7 @@ var _ Value = (*boolSliceValue)(nil)
 var _ Getter = (*boolSliceValue)(nil)
 var _ SliceValue = (*boolSliceValue)(nil)
 var _ Typed = (*boolSliceValue)(nil)
+var _ NegatableValue = (*boolSliceValue)(nil)
 
 func newBoolSliceValue(val []bool, p *[]bool) *boolSliceValue {
 	bsv := new(boolSliceValue)
@@ -33,7 +34,7 @@ func (s *boolSliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	b, err := strconv.ParseBool(val)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 
 	if !s.changed {
@@ -45,6 +46,16 @@ func (s *boolSliceValue) Set(val string) error {
 	return nil
 }
 
+// Negate inverts the boolean argument of --no-<name>, so the negated form
+// appends the opposite value.
+func (s *boolSliceValue) Negate(val string) (string, error) {
+	b, err := s.fromString(strings.TrimSpace(val))
+	if err != nil {
+		return "", NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+	return s.toString(!b), nil
+}
+
 func (s *boolSliceValue) Get() interface{} {
 	return *s.value
 }
@@ -74,7 +85,7 @@ func (s *boolSliceValue) toString(val bool) string {
 func (s *boolSliceValue) Append(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -86,7 +97,7 @@ func (s *boolSliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
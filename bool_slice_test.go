@@ -31,22 +31,24 @@ func TestBoolSlice(t *testing.T) {
 			expectedGetSlice:  []string{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []bool{},
-			expectedErr: `invalid argument "" for "--bs" flag: strconv.ParseBool: parsing "": invalid syntax`,
+			name:              "empty value passed",
+			input:             []string{""},
+			flagDefault:       []bool{},
+			expectedValues:    []bool{},
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
 		},
 		{
 			name:        "invalid bool",
 			input:       []string{"blabla"},
 			flagDefault: []bool{},
-			expectedErr: `invalid argument "blabla" for "--bs" flag: strconv.ParseBool: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--bs" flag: element 1: strconv.ParseBool: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"true,false"},
 			flagDefault: []bool{},
-			expectedErr: `invalid argument "true,false" for "--bs" flag: strconv.ParseBool: parsing "true,false": invalid syntax`,
+			expectedErr: `invalid argument "true,false" for "--bs" flag: element 1: strconv.ParseBool: parsing "true,false": invalid syntax`,
 		},
 		{
 			name:              "multiple values passed",
@@ -202,3 +204,28 @@ func TestBoolSliceErrors(t *testing.T) {
 	defer assertPanic(t)()
 	_ = f.MustGetBoolSlice("s")
 }
+
+func TestBoolSliceNegated(t *testing.T) {
+	t.Parallel()
+
+	var bs []bool
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BoolSliceVar(&bs, "bs", nil, "usage", zflag.OptAddNegative())
+	assertNoErr(t, f.Parse([]string{"--bs", "true", "--no-bs", "true", "--no-bs=false"}))
+	assertDeepEqual(t, []bool{true, false, true}, bs)
+
+	err := f.Parse([]string{"--no-bs=maybe"})
+	assertErrMsg(t, `invalid argument "maybe" for "--bs" flag: element 4: strconv.ParseBool: parsing "maybe": invalid syntax`, err)
+
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BoolSliceVar(&bs, "bs", nil, "usage", zflag.OptAddNegative(), zflag.OptNegativeShorthand('N'), zflag.OptGreedy())
+	err = f.Parse([]string{"-N", "true", "false", "maybe"})
+	assertErrMsg(t, `invalid argument "maybe" for "--bs" flag: element 3: strconv.ParseBool: parsing "maybe": invalid syntax`, err)
+
+	f = zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.BoolSliceVar(&bs, "bs", nil, "usage")
+	assertErrMsg(t, "unknown flag: --no-bs", f.Parse([]string{"--no-bs", "true"}))
+}
@@ -0,0 +1,167 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// byteUnits lists the accepted units of a byte size, the multiples of 1024 are
+// listed before those of 1000 so that String prefers the IEC units.
+var byteUnits = []struct {
+	name string
+	size int64
+}{
+	{"EiB", 1 << 60},
+	{"PiB", 1 << 50},
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"EB", 1e18},
+	{"PB", 1e15},
+	{"TB", 1e12},
+	{"GB", 1e9},
+	{"MB", 1e6},
+	{"kB", 1e3},
+	{"B", 1},
+}
+
+// -- bytes Value
+type bytesValue int64
+
+var _ Value = (*bytesValue)(nil)
+var _ Getter = (*bytesValue)(nil)
+var _ Typed = (*bytesValue)(nil)
+
+func newBytesValue(val int64, p *int64) *bytesValue {
+	*p = val
+	return (*bytesValue)(p)
+}
+
+func (b *bytesValue) Set(val string) error {
+	v, err := parseByteSize(val)
+	if err != nil {
+		return err
+	}
+	*b = bytesValue(v)
+	return nil
+}
+
+func (b *bytesValue) Get() interface{} {
+	return int64(*b)
+}
+
+func (b *bytesValue) Type() string {
+	return "bytes"
+}
+
+// String returns the size in the largest unit that represents it exactly,
+// e.g. 1536 becomes "1536B" and 1572864 becomes "1536KiB".
+func (b *bytesValue) String() string {
+	v := int64(*b)
+	if v == 0 {
+		return "0"
+	}
+	for _, unit := range byteUnits {
+		if v%unit.size == 0 {
+			return strconv.FormatInt(v/unit.size, 10) + unit.name
+		}
+	}
+	return strconv.FormatInt(v, 10) + "B"
+}
+
+// parseByteSize parses a size such as "512", "10KiB" or "1.5GB" into bytes.
+// Units are case-insensitive, and both SI (kB, MB, ...) and IEC (KiB, MiB, ...)
+// units are supported.
+func parseByteSize(val string) (int64, error) {
+	s := strings.TrimSpace(val)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	num, unit := s, ""
+	if i >= 0 {
+		num, unit = s[:i], strings.TrimSpace(s[i:])
+	}
+
+	size := int64(1)
+	if unit != "" {
+		found := false
+		for _, u := range byteUnits {
+			if strings.EqualFold(unit, u.name) {
+				size, found = u.size, true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("unknown unit %q in byte size %q", unit, val)
+		}
+	}
+
+	if n, err := strconv.ParseInt(num, 10, 64); err == nil {
+		if n > math.MaxInt64/size {
+			return 0, fmt.Errorf("byte size %q is out of range", val)
+		}
+		return n * size, nil
+	}
+
+	f, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid byte size %q", val)
+	}
+	f *= float64(size)
+	if f >= math.MaxInt64 {
+		return 0, fmt.Errorf("byte size %q is out of range", val)
+	}
+	return int64(f), nil
+}
+
+// GetBytes return the byte size of a flag with the given name
+func (fs *FlagSet) GetBytes(name string) (int64, error) {
+	val, err := fs.getFlagValue(name, "bytes")
+	if err != nil {
+		return 0, err
+	}
+	return val.(int64), nil
+}
+
+// MustGetBytes is like GetBytes, but panics on error.
+func (fs *FlagSet) MustGetBytes(name string) int64 {
+	val, err := fs.GetBytes(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// BytesVar defines a byte size flag with specified name, default value, and usage string.
+// The argument p points to an int64 variable in which to store the number of bytes.
+// Values may use SI or IEC units, e.g. "512", "10KiB" or "1.5GB".
+func (fs *FlagSet) BytesVar(p *int64, name string, value int64, usage string, opts ...Opt) {
+	fs.Var(newBytesValue(value, p), name, usage, opts...)
+}
+
+// BytesVar defines a byte size flag with specified name, default value, and usage string.
+// The argument p points to an int64 variable in which to store the number of bytes.
+// Values may use SI or IEC units, e.g. "512", "10KiB" or "1.5GB".
+func BytesVar(p *int64, name string, value int64, usage string, opts ...Opt) {
+	CommandLine.BytesVar(p, name, value, usage, opts...)
+}
+
+// Bytes defines a byte size flag with specified name, default value, and usage string.
+// The return value is the address of an int64 variable that stores the number of bytes.
+func (fs *FlagSet) Bytes(name string, value int64, usage string, opts ...Opt) *int64 {
+	var p int64
+	fs.BytesVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Bytes defines a byte size flag with specified name, default value, and usage string.
+// The return value is the address of an int64 variable that stores the number of bytes.
+func Bytes(name string, value int64, usage string, opts ...Opt) *int64 {
+	return CommandLine.Bytes(name, value, usage, opts...)
+}
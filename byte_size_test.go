@@ -0,0 +1,153 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestBytes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault int64
+		input       []string
+		expectedErr string
+		expected    int64
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: 0,
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--size" flag: invalid byte size ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"blabla"},
+			expectedErr: `invalid argument "blabla" for "--size" flag: unknown unit "blabla" in byte size "blabla"`,
+		},
+		{
+			name:        "unknown unit",
+			input:       []string{"10XB"},
+			expectedErr: `invalid argument "10XB" for "--size" flag: unknown unit "XB" in byte size "10XB"`,
+		},
+		{
+			name:        "out of range",
+			input:       []string{"16EiB"},
+			expectedErr: `invalid argument "16EiB" for "--size" flag: byte size "16EiB" is out of range`,
+		},
+		{
+			name:     "plain bytes",
+			input:    []string{"512"},
+			expected: 512,
+		},
+		{
+			name:     "bytes unit",
+			input:    []string{"512B"},
+			expected: 512,
+		},
+		{
+			name:     "iec unit",
+			input:    []string{"10KiB"},
+			expected: 10 * 1024,
+		},
+		{
+			name:     "si unit",
+			input:    []string{"10kB"},
+			expected: 10 * 1000,
+		},
+		{
+			name:     "fractional",
+			input:    []string{"1.5GB"},
+			expected: 1500 * 1000 * 1000,
+		},
+		{
+			name:     "case insensitive with space",
+			input:    []string{"2 mib"},
+			expected: 2 * 1024 * 1024,
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: 4096,
+			expected:    4096,
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    1TB    "},
+			expected: 1000 * 1000 * 1000 * 1000,
+		},
+		{
+			name:     "multiple values",
+			input:    []string{"1KiB", "2KiB"},
+			expected: 2 * 1024,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v int64
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.BytesVar(&v, "size", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--size", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			getV, err := f.GetBytes("size")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, getV)
+		})
+	}
+}
+
+func TestBytesString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		value    int64
+		expected string
+	}{
+		{0, "0"},
+		{512, "512B"},
+		{1024, "1KiB"},
+		{1536, "1536B"},
+		{1000, "1kB"},
+		{1 << 30, "1GiB"},
+		{1500 * 1000 * 1000, "1500MB"},
+	}
+
+	for _, test := range tests {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.Bytes("size", test.value, "usage")
+		assertEqual(t, test.expected, f.Lookup("size").DefValue)
+	}
+}
+
+func TestBytesUsage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Bytes("max-body", 10<<20, "maximum body size")
+	f.PrintDefaults()
+	assertEqual(t, "      --max-body bytes   maximum body size (default 10MiB)\n", buf.String())
+}
@@ -0,0 +1,232 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// TokenKind is the kind of a command line argument, as determined by Classify.
+type TokenKind int
+
+const (
+	// TokenPositional is a positional (non-flag) argument.
+	TokenPositional TokenKind = iota
+	// TokenLongFlag is a long flag, e.g. --name or --name=value.
+	TokenLongFlag
+	// TokenShorthandCluster is one or more shorthand flags, e.g. -v, -abc or -n=value.
+	TokenShorthandCluster
+	// TokenValue is the value of the flag in the preceding argument.
+	TokenValue
+	// TokenTerminator is the -- terminating the flags.
+	TokenTerminator
+)
+
+func (k TokenKind) String() string {
+	switch k {
+	case TokenPositional:
+		return "positional"
+	case TokenLongFlag:
+		return "long flag"
+	case TokenShorthandCluster:
+		return "shorthand cluster"
+	case TokenValue:
+		return "value"
+	case TokenTerminator:
+		return "terminator"
+	}
+	return "unknown"
+}
+
+// TokenInfo describes a single command line argument.
+type TokenInfo struct {
+	Arg  string
+	Kind TokenKind
+	// Flags contains the flags referenced by the argument, in order. For a
+	// TokenValue it contains the flag the value belongs to.
+	Flags []*Flag
+	// Unknown is set if the argument references a flag that is not defined.
+	Unknown bool
+}
+
+// Classify labels each element of args the way Parse would interpret it,
+// without setting any flags. This is useful for editors and wrappers that
+// want to highlight or partially validate a command line. The built-in help
+// and version flags are not reported as unknown, but have no Flags.
+func (fs *FlagSet) Classify(args []string) []TokenInfo {
+	tokens := make([]TokenInfo, 0, len(args))
+	for i := 0; i < len(args); i++ {
+		s := args[i]
+		if !fs.isNegativeNumber(s) {
+			s = fs.translateArg(s)
+		}
+		if len(s) == 0 || s[0] != '-' || len(s) == 1 || fs.isNegativeNumber(s) {
+			tokens = append(tokens, TokenInfo{Arg: args[i], Kind: TokenPositional})
+			if !fs.interspersed {
+				return appendPositional(tokens, args[i+1:])
+			}
+			continue
+		}
+
+		if s == "--" {
+			tokens = append(tokens, TokenInfo{Arg: args[i], Kind: TokenTerminator})
+			return appendPositional(tokens, args[i+1:])
+		}
+
+		var token TokenInfo
+		var takesNext bool
+		if s[1] == '-' {
+			token, takesNext = fs.classifyLongArg(s)
+		} else {
+			token, takesNext = fs.classifyShortArg(s)
+		}
+		token.Arg = args[i]
+		tokens = append(tokens, token)
+
+		if takesNext && i+1 < len(args) && nextArgIsValue(args[i+1], token.Flags[len(token.Flags)-1]) {
+			i++
+			tokens = append(tokens, TokenInfo{Arg: args[i], Kind: TokenValue, Flags: token.Flags[len(token.Flags)-1:]})
+		}
+		if len(token.Flags) > 0 && token.Flags[len(token.Flags)-1].Greedy {
+			for i+1 < len(args) && isGreedyValue(args[i+1]) {
+				i++
+				tokens = append(tokens, TokenInfo{Arg: args[i], Kind: TokenValue, Flags: token.Flags[len(token.Flags)-1:]})
+			}
+		}
+	}
+	return tokens
+}
+
+func appendPositional(tokens []TokenInfo, args []string) []TokenInfo {
+	for _, arg := range args {
+		tokens = append(tokens, TokenInfo{Arg: arg, Kind: TokenPositional})
+	}
+	return tokens
+}
+
+// nextArgIsValue reports whether Parse would consume arg as the value of flag.
+func nextArgIsValue(arg string, flag *Flag) bool {
+	if len(arg) == 0 || arg[0] == '-' {
+		return false
+	}
+	_, flagIsBool := flag.Value.(BoolFlag)
+	return !flagIsBool || isBool(arg)
+}
+
+// classifyLongArg classifies a long flag, reporting whether the flag takes
+// its value from the next argument.
+func (fs *FlagSet) classifyLongArg(s string) (TokenInfo, bool) {
+	token := TokenInfo{Arg: s, Kind: TokenLongFlag}
+	split := strings.SplitN(s[2:], "=", 2)
+	name := split[0]
+
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	var bFlag *Flag
+	switch {
+	case flag != nil && flag.negates != nil:
+		bFlag = flag.negates
+	case flag == nil:
+		bFlag = fs.lookupNegated(name)
+	}
+	if bFlag != nil {
+		token.Flags = []*Flag{bFlag}
+		_, isBoolFlag := bFlag.Value.(BoolFlag)
+		return token, len(split) == 1 && !isBoolFlag
+	}
+	exists := flag != nil
+
+	if !exists && fs.AllowAbbreviations && !fs.isBuiltinName(name) {
+		var negated bool
+		flag, negated, _ = fs.lookupAbbreviation(name)
+		if exists = flag != nil; exists && negated {
+			token.Flags = []*Flag{flag}
+			_, isBoolFlag := flag.Value.(BoolFlag)
+			return token, len(split) == 1 && !isBoolFlag
+		}
+	}
+
+	if !exists {
+		isHelp := name == "help" && !fs.DisableBuiltinHelp
+		isVersion := name == "version" && fs.versionFunc != nil
+		token.Unknown = !isHelp && !isVersion
+		return token, false
+	}
+	if flag.ShorthandOnly {
+		token.Unknown = true
+		return token, false
+	}
+
+	token.Flags = []*Flag{flag}
+	_, flagIsBool := flag.Value.(BoolFlag)
+	_, isOptional := flag.Value.(OptionalValue)
+	return token, len(split) == 1 && !flagIsBool && !isOptional && flag.NoArgDefault == ""
+}
+
+// classifyShortArg classifies a shorthand cluster, reporting whether the last
+// flag takes its value from the next argument.
+func (fs *FlagSet) classifyShortArg(s string) (TokenInfo, bool) {
+	token := TokenInfo{Arg: s, Kind: TokenShorthandCluster}
+	shorthands := s[1:]
+	if flag := fs.lookupAtomicShorthand(shorthands); flag != nil {
+		token.Flags = []*Flag{flag}
+		_, flagIsBool := flag.Value.(BoolFlag)
+		_, isOptional := flag.Value.(OptionalValue)
+		return token, !strings.Contains(shorthands, "=") && !isOptional && flag.NoArgDefault == "" && !flagIsBool
+	}
+	for len(shorthands) > 0 {
+		char, size := utf8.DecodeRuneInString(shorthands)
+		rest := shorthands[size:]
+
+		flag, exists := fs.shorthands[char]
+		negated := false
+		if !exists {
+			flag, exists = fs.negShorthands[char]
+			negated = exists
+		}
+		if !exists {
+			flag = fs.lookup(fs.normalizeFlagName(string(char)))
+			if flag == nil || (flag.Shorthand > 0 && flag.Shorthand != char) {
+				isHelp := char == 'h' && !fs.DisableBuiltinHelp
+				isVersion := char == 'V' && fs.versionFunc != nil
+				if !isHelp && !isVersion {
+					token.Unknown = true
+				}
+				shorthands = rest
+				continue
+			}
+		}
+		token.Flags = append(token.Flags, flag)
+
+		_, flagIsBool := flag.Value.(BoolFlag)
+		if negated && flagIsBool {
+			// '-N' (negated bool), which never takes a value
+			shorthands = rest
+			continue
+		}
+		if len(rest) > 1 && rest[0] == '=' {
+			// '-f=arg'
+			return token, false
+		}
+		if flag.NoArgDefault != "" {
+			// '-f' (arg defaults to NoArgDefault)
+			shorthands = rest
+			continue
+		}
+		if len(rest) > 0 {
+			next, _ := utf8.DecodeRuneInString(rest)
+			_, nextFlagExists := fs.shorthands[next]
+			_, nextNegFlagExists := fs.negShorthands[next]
+			if !nextFlagExists && !nextNegFlagExists && (!flagIsBool || isBool(rest)) {
+				// '-farg'
+				return token, false
+			}
+		} else {
+			// '-f arg', which is also attempted for bool and optional values
+			return token, true
+		}
+		shorthands = rest
+	}
+	return token, false
+}
@@ -0,0 +1,152 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+// formatTokens renders tokens as "arg:kind[flags]", with a trailing "?" for
+// unknown flags, to keep the test table readable.
+func formatTokens(tokens []zflag.TokenInfo) []string {
+	out := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		names := make([]string, 0, len(token.Flags))
+		for _, flag := range token.Flags {
+			names = append(names, flag.Name)
+		}
+		s := fmt.Sprintf("%s:%s[%s]", token.Arg, token.Kind, strings.Join(names, ","))
+		if token.Unknown {
+			s += "?"
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+func TestClassify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{
+			name:     "long flags",
+			args:     []string{"--name", "value", "--name=value", "--verbose", "arg"},
+			expected: []string{"--name:long flag[name]", "value:value[name]", "--name=value:long flag[name]", "--verbose:long flag[verbose]", "arg:positional[]"},
+		},
+		{
+			name:     "negative bool",
+			args:     []string{"--no-verbose", "arg"},
+			expected: []string{"--no-verbose:long flag[verbose]", "arg:positional[]"},
+		},
+		{
+			name:     "optional value",
+			args:     []string{"--opt", "arg", "-o", "3"},
+			expected: []string{"--opt:long flag[opt]", "arg:positional[]", "-o:shorthand cluster[opt]", "3:value[opt]"},
+		},
+		{
+			name:     "shorthand clusters",
+			args:     []string{"-vn", "value", "-nfoo", "-vn=value", "-v", "true"},
+			expected: []string{"-vn:shorthand cluster[verbose,name]", "value:value[name]", "-nfoo:shorthand cluster[name]", "-vn=value:shorthand cluster[verbose,name]", "-v:shorthand cluster[verbose]", "true:value[verbose]"},
+		},
+		{
+			name:     "bool shorthand does not take positional",
+			args:     []string{"-v", "arg"},
+			expected: []string{"-v:shorthand cluster[verbose]", "arg:positional[]"},
+		},
+		{
+			name:     "negated bool shorthand does not take a value",
+			args:     []string{"-N", "true", "-vN", "arg"},
+			expected: []string{"-N:shorthand cluster[verbose]", "true:positional[]", "-vN:shorthand cluster[verbose,verbose]", "arg:positional[]"},
+		},
+		{
+			name:     "negated shorthand is not a value",
+			args:     []string{"-nN", "-v"},
+			expected: []string{"-nN:shorthand cluster[name,verbose]", "-v:shorthand cluster[verbose]"},
+		},
+		{
+			name:     "missing value",
+			args:     []string{"--name", "--verbose"},
+			expected: []string{"--name:long flag[name]", "--verbose:long flag[verbose]"},
+		},
+		{
+			name:     "terminator",
+			args:     []string{"arg", "--", "--name", "-v"},
+			expected: []string{"arg:positional[]", "--:terminator[]", "--name:positional[]", "-v:positional[]"},
+		},
+		{
+			name:     "unknown flags",
+			args:     []string{"--unknown", "-vx", "--short"},
+			expected: []string{"--unknown:long flag[]?", "-vx:shorthand cluster[verbose]?", "--short:long flag[]?"},
+		},
+		{
+			name:     "no arg default",
+			args:     []string{"--profile", "arg", "-pv", "-p", "arg"},
+			expected: []string{"--profile:long flag[profile]", "arg:positional[]", "-pv:shorthand cluster[profile,verbose]", "-p:shorthand cluster[profile]", "arg:positional[]"},
+		},
+		{
+			name:     "builtin help",
+			args:     []string{"--help", "-h", "-"},
+			expected: []string{"--help:long flag[]", "-h:shorthand cluster[]", "-:positional[]"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.Bool("verbose", false, "verbose", zflag.OptShorthand('v'), zflag.OptAddNegative(), zflag.OptNegativeShorthand('N'))
+			f.String("name", "", "name", zflag.OptShorthand('n'))
+			f.String("short", "", "short", zflag.OptShorthand('s'), zflag.OptShorthandOnly())
+			f.Count("opt", "optional", zflag.OptShorthand('o'))
+			f.String("profile", "", "profile", zflag.OptShorthand('p'), zflag.OptNoArgDefault("cpu"))
+
+			assertDeepEqual(t, test.expected, formatTokens(f.Classify(test.args)))
+		})
+	}
+}
+
+func TestClassifyNotInterspersed(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetInterspersed(false)
+	f.Bool("verbose", false, "verbose")
+
+	tokens := f.Classify([]string{"--verbose", "arg", "--verbose"})
+	assertDeepEqual(t, []string{"--verbose:long flag[verbose]", "arg:positional[]", "--verbose:positional[]"}, formatTokens(tokens))
+}
+
+func TestClassifyNegativeNumbersSingleDash(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.NegativeNumbersAsArgs = true
+	f.SingleDashLongFlags = true
+	f.Bool("verbose", false, "verbose")
+
+	tokens := f.Classify([]string{"-verbose", "-12", "-2.5"})
+	assertDeepEqual(t, []string{"-verbose:long flag[verbose]", "-12:positional[]", "-2.5:positional[]"}, formatTokens(tokens))
+}
+
+func TestClassifySlashFlags(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.AllowSlashFlags = true
+	f.Bool("verbose", false, "verbose", zflag.OptShorthand('v'))
+	f.String("name", "", "name")
+
+	tokens := f.Classify([]string{"/v", "/name:x", "/foo", "arg"})
+	assertDeepEqual(t, []string{"/v:shorthand cluster[verbose]", "/name:x:long flag[name]", "/foo:positional[]", "arg:positional[]"}, formatTokens(tokens))
+}
@@ -0,0 +1,175 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Report describes the differences between two versions of a FlagSet, as
+// returned by CompareUsage. Flags are listed in lexicographical order.
+type Report struct {
+	Added   []*Flag
+	Removed []*Flag
+	Changed []FlagChange
+}
+
+// FlagChange describes how the definition of a flag changed between two
+// versions of a FlagSet.
+type FlagChange struct {
+	Name string
+	Old  *Flag
+	New  *Flag
+	// Changes describes each changed property, e.g. `default "80" -> "8080"`.
+	Changes []string
+	// Breaking is set if the change can break existing command lines, e.g.
+	// as the type changed, the shorthand was removed or the flag became
+	// required.
+	Breaking bool
+}
+
+// CompareUsage compares the flags defined by two versions of a FlagSet,
+// reporting added and removed flags, and changes of the type, shorthand,
+// default, usage, group and deprecation of flags defined by both, e.g. to
+// generate release notes or check the compatibility of a release.
+func CompareUsage(old, new *FlagSet) Report {
+	var report Report
+	oldFlags := flagsByName(old)
+	newFlags := flagsByName(new)
+
+	for _, name := range sortedFlagNames(newFlags) {
+		if _, ok := oldFlags[name]; !ok {
+			report.Added = append(report.Added, newFlags[name])
+		}
+	}
+
+	for _, name := range sortedFlagNames(oldFlags) {
+		oldFlag := oldFlags[name]
+		newFlag, ok := newFlags[name]
+		if !ok {
+			report.Removed = append(report.Removed, oldFlag)
+			continue
+		}
+		if change := compareFlags(oldFlag, newFlag); len(change.Changes) > 0 {
+			report.Changed = append(report.Changed, change)
+		}
+	}
+
+	return report
+}
+
+func flagsByName(fs *FlagSet) map[string]*Flag {
+	flags := make(map[string]*Flag)
+	if fs == nil {
+		return flags
+	}
+	fs.VisitAll(func(flag *Flag) {
+		flags[flag.Name] = flag
+	})
+	return flags
+}
+
+func sortedFlagNames(flags map[string]*Flag) []string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func compareFlags(oldFlag, newFlag *Flag) FlagChange {
+	change := FlagChange{Name: newFlag.Name, Old: oldFlag, New: newFlag}
+	changed := func(property string, old, new interface{}, breaking bool) {
+		change.Changes = append(change.Changes, fmt.Sprintf("%s %v -> %v", property, old, new))
+		change.Breaking = change.Breaking || breaking
+	}
+
+	if oldType, newType := flagTypeName(oldFlag), flagTypeName(newFlag); oldType != newType {
+		changed("type", oldType, newType, true)
+	}
+	if oldFlag.Shorthand != newFlag.Shorthand {
+		changed("shorthand", describeShorthand(oldFlag.Shorthand), describeShorthand(newFlag.Shorthand), oldFlag.Shorthand != 0)
+	}
+	if oldFlag.DefValue != newFlag.DefValue && !oldFlag.Secret && !newFlag.Secret {
+		changed("default", fmt.Sprintf("%q", oldFlag.DefValue), fmt.Sprintf("%q", newFlag.DefValue), false)
+	}
+	if oldFlag.Usage != newFlag.Usage {
+		changed("usage", fmt.Sprintf("%q", oldFlag.Usage), fmt.Sprintf("%q", newFlag.Usage), false)
+	}
+	if oldFlag.Group != newFlag.Group {
+		changed("group", fmt.Sprintf("%q", oldFlag.Group), fmt.Sprintf("%q", newFlag.Group), false)
+	}
+	if oldFlag.Required != newFlag.Required {
+		changed("required", oldFlag.Required, newFlag.Required, newFlag.Required)
+	}
+	if oldFlag.Hidden != newFlag.Hidden {
+		changed("hidden", oldFlag.Hidden, newFlag.Hidden, false)
+	}
+	if oldFlag.Deprecated != newFlag.Deprecated {
+		changed("deprecated", fmt.Sprintf("%q", oldFlag.Deprecated), fmt.Sprintf("%q", newFlag.Deprecated), false)
+	}
+	if oldFlag.ShorthandDeprecated != newFlag.ShorthandDeprecated {
+		changed("shorthand deprecated", fmt.Sprintf("%q", oldFlag.ShorthandDeprecated), fmt.Sprintf("%q", newFlag.ShorthandDeprecated), false)
+	}
+
+	return change
+}
+
+func describeShorthand(shorthand rune) string {
+	if shorthand == 0 {
+		return "none"
+	}
+	return "-" + string(shorthand)
+}
+
+// Empty reports whether the FlagSets compared are equivalent.
+func (r Report) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Breaking reports whether a change can break existing command lines, which
+// is the case if a flag was removed or a change is breaking.
+func (r Report) Breaking() bool {
+	if len(r.Removed) > 0 {
+		return true
+	}
+	for _, change := range r.Changed {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// String formats the report as a list of added, removed and changed flags,
+// suitable for release notes. Breaking changes are marked as such.
+func (r Report) String() string {
+	buf := new(strings.Builder)
+	if len(r.Added) > 0 {
+		fmt.Fprintln(buf, "Added:")
+		for _, flag := range r.Added {
+			fmt.Fprintf(buf, "  --%s\n", flag.Name)
+		}
+	}
+	if len(r.Removed) > 0 {
+		fmt.Fprintln(buf, "Removed:")
+		for _, flag := range r.Removed {
+			fmt.Fprintf(buf, "  --%s (breaking)\n", flag.Name)
+		}
+	}
+	if len(r.Changed) > 0 {
+		fmt.Fprintln(buf, "Changed:")
+		for _, change := range r.Changed {
+			breaking := ""
+			if change.Breaking {
+				breaking = " (breaking)"
+			}
+			fmt.Fprintf(buf, "  --%s: %s%s\n", change.Name, strings.Join(change.Changes, ", "), breaking)
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,70 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestCompareUsage(t *testing.T) {
+	t.Parallel()
+
+	old := zflag.NewFlagSet("v1", zflag.ContinueOnError)
+	old.String("host", "localhost", "host to connect to", zflag.OptShorthand('H'))
+	old.Int("port", 80, "port")
+	old.Int("timeout", 30, "timeout in seconds")
+	old.Bool("legacy", false, "legacy mode")
+	old.String("token", "secret", "api token", zflag.OptSecret())
+
+	new := zflag.NewFlagSet("v2", zflag.ContinueOnError)
+	new.String("host", "localhost", "host to connect to", zflag.OptShorthand('H'))
+	new.Int("port", 8080, "port", zflag.OptDeprecated("use --listen"))
+	new.Duration("timeout", 0, "timeout", zflag.OptRequired())
+	new.String("listen", ":8080", "address to listen on")
+	new.String("token", "other", "api token", zflag.OptSecret(), zflag.OptShorthand('t'))
+
+	report := zflag.CompareUsage(old, new)
+	assertEqual(t, false, report.Empty())
+	assertEqual(t, true, report.Breaking())
+	assertEqual(t, 1, len(report.Added))
+	assertEqual(t, new.Lookup("listen"), report.Added[0])
+	assertEqual(t, old.Lookup("legacy"), report.Removed[0])
+	assertEqual(t, 3, len(report.Changed))
+	assertEqual(t, old.Lookup("port"), report.Changed[0].Old)
+	assertEqual(t, new.Lookup("port"), report.Changed[0].New)
+	assertEqual(t, false, report.Changed[0].Breaking)
+
+	expected := `Added:
+  --listen
+Removed:
+  --legacy (breaking)
+Changed:
+  --port: default "80" -> "8080", hidden false -> true, deprecated "" -> "use --listen"
+  --timeout: type int -> duration, default "30" -> "0s", usage "timeout in seconds" -> "timeout", required false -> true (breaking)
+  --token: shorthand none -> -t
+`
+	assertEqualf(t, expected, report.String(), "expected:\n%s\ngot:\n%s", expected, report.String())
+}
+
+func TestCompareUsageEqual(t *testing.T) {
+	t.Parallel()
+
+	define := func(name string) *zflag.FlagSet {
+		fs := zflag.NewFlagSet(name, zflag.ContinueOnError)
+		fs.String("host", "localhost", "host", zflag.OptShorthand('H'))
+		fs.Int("port", 80, "port")
+		return fs
+	}
+
+	report := zflag.CompareUsage(define("v1"), define("v2"))
+	assertEqual(t, true, report.Empty())
+	assertEqual(t, false, report.Breaking())
+	assertEqual(t, "", report.String())
+
+	report = zflag.CompareUsage(nil, define("v2"))
+	assertEqual(t, 2, len(report.Added))
+	assertEqual(t, false, report.Breaking())
+}
@@ -0,0 +1,54 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"os"
+)
+
+// completionCommands are the hidden commands used by zulucmd/zulu to request
+// shell completions.
+var completionCommands = []string{"__complete", "__completeNoDesc"}
+
+// SetCompletionMode toggles completion mode, in which parsing does not print
+// deprecation warnings, other warnings, usage or error messages, so that the
+// output of a program computing shell completions stays parseable. Errors are
+// still returned. The CommandLine enters completion mode automatically when
+// the program is invoked with a completion request, see IsCompletionRequest.
+func (fs *FlagSet) SetCompletionMode(enabled bool) {
+	fs.completionMode = enabled
+}
+
+// SetCompletionMode toggles completion mode of the command-line flags.
+// See FlagSet.SetCompletionMode for more information.
+func SetCompletionMode(enabled bool) {
+	CommandLine.SetCompletionMode(enabled)
+}
+
+// CompletionMode returns whether the FlagSet is in completion mode.
+func (fs *FlagSet) CompletionMode() bool {
+	return fs.completionMode
+}
+
+// IsCompletionRequest reports whether args, which should not include the
+// program name, request shell completions, i.e. start with the hidden
+// __complete or __completeNoDesc command.
+func IsCompletionRequest(args []string) bool {
+	return len(args) > 0 && containsString(completionCommands, args[0])
+}
+
+// warnf prints a warning to the output, unless in completion mode.
+func (fs *FlagSet) warnf(format string, a ...interface{}) {
+	if fs.completionMode {
+		return
+	}
+	fmt.Fprintf(fs.Output(), format, a...)
+}
+
+func init() {
+	if IsCompletionRequest(os.Args[1:]) {
+		CommandLine.SetCompletionMode(true)
+	}
+}
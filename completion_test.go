@@ -0,0 +1,86 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestCompletionMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          []string
+		expectedErr    string
+		expectedOutput string
+	}{
+		{
+			name:           "deprecated flag",
+			input:          []string{"--old", "value"},
+			expectedOutput: "Flag --old has been deprecated, use --new instead\n",
+		},
+		{
+			name:           "deprecated shorthand",
+			input:          []string{"-n", "value"},
+			expectedOutput: "Flag shorthand -n has been deprecated, use --new instead\n",
+		},
+		{
+			name:           "consumed value",
+			input:          []string{"--new", "old"},
+			expectedOutput: "Warning: flag --new consumed \"old\" as its value, which is also the name of flag --old; use --new=old if this is intended\n",
+		},
+		{
+			name:        "unknown flag",
+			input:       []string{"--unknown"},
+			expectedErr: "unknown flag: --unknown",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			for _, completion := range []bool{false, true} {
+				var buf bytes.Buffer
+				f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+				f.SetOutput(&buf)
+				f.SetCompletionMode(completion)
+				f.ConsumedValueCheck = zflag.ConsumedValueWarn
+				f.String("new", "", "new", zflag.OptShorthand('n'), zflag.OptShorthandDeprecated("use --new instead"))
+				f.String("old", "", "old", zflag.OptDeprecated("use --new instead"))
+				assertEqual(t, completion, f.CompletionMode())
+
+				err := f.Parse(test.input)
+				if test.expectedErr != "" {
+					assertErrMsg(t, test.expectedErr, err)
+				} else {
+					assertNoErr(t, err)
+				}
+
+				switch {
+				case completion:
+					assertEqual(t, "", buf.String())
+				case test.expectedErr != "":
+					assertEqual(t, true, buf.Len() > 0)
+				default:
+					assertEqual(t, test.expectedOutput, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestIsCompletionRequest(t *testing.T) {
+	t.Parallel()
+
+	assertEqual(t, true, zflag.IsCompletionRequest([]string{"__complete", "serve", ""}))
+	assertEqual(t, true, zflag.IsCompletionRequest([]string{"__completeNoDesc"}))
+	assertEqual(t, false, zflag.IsCompletionRequest([]string{"serve", "__complete"}))
+	assertEqual(t, false, zflag.IsCompletionRequest(nil))
+}
@@ -35,10 +35,9 @@ func (s *complex128SliceValue) Get() interface{} {
 }
 
 func (s *complex128SliceValue) Set(val string) error {
-	val = strings.TrimSpace(val)
-	out, err := strconv.ParseComplex(val, 128)
+	out, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 
 	if !s.changed {
@@ -63,7 +62,7 @@ func (s *complex128SliceValue) String() string {
 }
 
 func (s *complex128SliceValue) fromString(val string) (complex128, error) {
-	return strconv.ParseComplex(val, 128)
+	return strconv.ParseComplex(strings.TrimSpace(val), 128)
 }
 
 func (s *complex128SliceValue) toString(val complex128) string {
@@ -73,7 +72,7 @@ func (s *complex128SliceValue) toString(val complex128) string {
 func (s *complex128SliceValue) Append(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -85,7 +84,7 @@ func (s *complex128SliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
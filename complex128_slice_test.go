@@ -31,22 +31,24 @@ func TestC128Slice(t *testing.T) {
 			expectedGetSlice:  []string{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []complex128{},
-			expectedErr: `invalid argument "" for "--c128s" flag: strconv.ParseComplex: parsing "": invalid syntax`,
+			name:              "empty value passed",
+			input:             []string{""},
+			flagDefault:       []complex128{},
+			expectedValues:    []complex128{},
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
 		},
 		{
 			name:        "invalid c128s",
 			input:       []string{"blabla"},
 			flagDefault: []complex128{},
-			expectedErr: `invalid argument "blabla" for "--c128s" flag: strconv.ParseComplex: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--c128s" flag: element 1: strconv.ParseComplex: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1.0,2.0"},
 			flagDefault: []complex128{},
-			expectedErr: `invalid argument "1.0,2.0" for "--c128s" flag: strconv.ParseComplex: parsing "1.0,2.0": invalid syntax`,
+			expectedErr: `invalid argument "1.0,2.0" for "--c128s" flag: element 1: strconv.ParseComplex: parsing "1.0,2.0": invalid syntax`,
 		},
 		{
 			name:              "multiple values passed",
@@ -132,6 +134,19 @@ func TestC128Slice(t *testing.T) {
 			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i) (3.000000+0.000000i) (0.000000+2.000000i) (1.000000+0.000000i) (0.000000+2.000000i) (2.500000+3.100000i)]",
 			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)", "(3.000000+0.000000i)", "(0.000000+2.000000i)", "(1.000000+0.000000i)", "(0.000000+2.000000i)", "(2.500000+3.100000i)"},
 		},
+		{
+			name:  "trims appended and replaced values",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					assertNoErr(t, val.Replace([]string{" 0+2i "}))
+					assertNoErr(t, val.Append("  2.0"))
+				}
+			},
+			expectedValues:    []complex128{complex(0, 2), complex(2, 0)},
+			expectedStrValues: "[(0.000000+2.000000i) (2.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(0.000000+2.000000i)", "(2.000000+0.000000i)"},
+		},
 		{
 			name:              "trims input",
 			input:             []string{" 1.0 ", "   2.0", "3.0   ", "  0+2i", "1"},
@@ -0,0 +1,82 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strconv"
+	"strings"
+)
+
+// -- complex64 Value
+type complex64Value complex64
+
+var _ Value = (*complex64Value)(nil)
+var _ Getter = (*complex64Value)(nil)
+var _ Typed = (*complex64Value)(nil)
+
+func newComplex64Value(val complex64, p *complex64) *complex64Value {
+	*p = val
+	return (*complex64Value)(p)
+}
+
+func (f *complex64Value) Get() interface{} {
+	return complex64(*f)
+}
+
+func (f *complex64Value) Set(val string) error {
+	val = strings.TrimSpace(val)
+	v, err := strconv.ParseComplex(val, 64)
+	*f = complex64Value(v)
+	return err
+}
+
+func (f *complex64Value) Type() string {
+	return "complex64"
+}
+
+func (f *complex64Value) String() string { return strconv.FormatComplex(complex128(*f), 'g', -1, 64) }
+
+// GetComplex64 return the complex64 value of a flag with the given name
+func (fs *FlagSet) GetComplex64(name string) (complex64, error) {
+	val, err := fs.getFlagValue(name, "complex64")
+	if err != nil {
+		return 0, err
+	}
+	return val.(complex64), nil
+}
+
+// MustGetComplex64 is like GetComplex64, but panics on error.
+func (fs *FlagSet) MustGetComplex64(name string) complex64 {
+	val, err := fs.GetComplex64(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Complex64Var defines a complex64 flag with specified name, default value, and usage string.
+// The argument p points to a complex64 variable in which to store the value of the flag.
+func (fs *FlagSet) Complex64Var(p *complex64, name string, value complex64, usage string, opts ...Opt) {
+	fs.Var(newComplex64Value(value, p), name, usage, opts...)
+}
+
+// Complex64Var defines a complex64 flag with specified name, default value, and usage string.
+// The argument p points to a complex64 variable in which to store the value of the flag.
+func Complex64Var(p *complex64, name string, value complex64, usage string, opts ...Opt) {
+	CommandLine.Complex64Var(p, name, value, usage, opts...)
+}
+
+// Complex64 defines a complex64 flag with specified name, default value, and usage string.
+// The return value is the address of a complex64 variable that stores the value of the flag.
+func (fs *FlagSet) Complex64(name string, value complex64, usage string, opts ...Opt) *complex64 {
+	var p complex64
+	fs.Complex64Var(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Complex64 defines a complex64 flag with specified name, default value, and usage string.
+// The return value is the address of a complex64 variable that stores the value of the flag.
+func Complex64(name string, value complex64, usage string, opts ...Opt) *complex64 {
+	return CommandLine.Complex64(name, value, usage, opts...)
+}
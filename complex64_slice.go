@@ -0,0 +1,145 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.15
+// +build go1.15
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -- complex64Slice Value
+type complex64SliceValue struct {
+	value   *[]complex64
+	changed bool
+}
+
+var _ Value = (*complex64SliceValue)(nil)
+var _ Getter = (*complex64SliceValue)(nil)
+var _ SliceValue = (*complex64SliceValue)(nil)
+var _ Typed = (*complex64SliceValue)(nil)
+
+func newComplex64SliceValue(val []complex64, p *[]complex64) *complex64SliceValue {
+	isv := new(complex64SliceValue)
+	isv.value = p
+	*isv.value = val
+	return isv
+}
+
+func (s *complex64SliceValue) Get() interface{} {
+	return *s.value
+}
+
+func (s *complex64SliceValue) Set(val string) error {
+	out, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []complex64{}
+	}
+	*s.value = append(*s.value, out)
+	s.changed = true
+
+	return nil
+}
+
+func (s *complex64SliceValue) Type() string {
+	return "complex64Slice"
+}
+
+func (s *complex64SliceValue) String() string {
+	if s.value == nil || *s.value == nil {
+		return "[]"
+	}
+
+	return fmt.Sprintf("%f", *s.value)
+}
+
+func (s *complex64SliceValue) fromString(val string) (complex64, error) {
+	v, err := strconv.ParseComplex(strings.TrimSpace(val), 64)
+	return complex64(v), err
+}
+
+func (s *complex64SliceValue) toString(val complex64) string {
+	return fmt.Sprintf("%f", val)
+}
+
+func (s *complex64SliceValue) Append(val string) error {
+	i, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, i)
+	return nil
+}
+
+func (s *complex64SliceValue) Replace(val []string) error {
+	out := make([]complex64, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = s.fromString(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *complex64SliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = s.toString(d)
+	}
+	return out
+}
+
+// GetComplex64Slice return the []complex64 value of a flag with the given name
+func (fs *FlagSet) GetComplex64Slice(name string) ([]complex64, error) {
+	val, err := fs.getFlagValue(name, "complex64Slice")
+	if err != nil {
+		return []complex64{}, err
+	}
+	return val.([]complex64), nil
+}
+
+// MustGetComplex64Slice is like GetComplex64Slice, but panics on error.
+func (fs *FlagSet) MustGetComplex64Slice(name string) []complex64 {
+	val, err := fs.GetComplex64Slice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// Complex64SliceVar defines a complex64Slice flag with specified name, default value, and usage string.
+// The argument p points to a []complex64 variable in which to store the value of the flag.
+func (fs *FlagSet) Complex64SliceVar(p *[]complex64, name string, value []complex64, usage string, opts ...Opt) {
+	fs.Var(newComplex64SliceValue(value, p), name, usage, opts...)
+}
+
+// Complex64SliceVar defines a complex64[] flag with specified name, default value, and usage string.
+// The argument p points to a complex64[] variable in which to store the value of the flag.
+func Complex64SliceVar(p *[]complex64, name string, value []complex64, usage string, opts ...Opt) {
+	CommandLine.Complex64SliceVar(p, name, value, usage, opts...)
+}
+
+// Complex64Slice defines a []complex64 flag with specified name, default value, and usage string.
+// The return value is the address of a []complex64 variable that stores the value of the flag.
+func (fs *FlagSet) Complex64Slice(name string, value []complex64, usage string, opts ...Opt) *[]complex64 {
+	var p []complex64
+	fs.Complex64SliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Complex64Slice defines a []complex64 flag with specified name, default value, and usage string.
+// The return value is the address of a []complex64 variable that stores the value of the flag.
+func Complex64Slice(name string, value []complex64, usage string, opts ...Opt) *[]complex64 {
+	return CommandLine.Complex64Slice(name, value, usage, opts...)
+}
@@ -0,0 +1,217 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestC64Slice(t *testing.T) {
+	tests := []struct {
+		name              string
+		flagDefault       []complex64
+		input             []string
+		expectedErr       string
+		expectedValues    []complex64
+		visitor           func(f *zflag.Flag)
+		expectedStrValues string
+		expectedGetSlice  []string
+	}{
+		{
+			name:              "no value passed",
+			input:             []string{},
+			flagDefault:       []complex64{},
+			expectedErr:       "",
+			expectedValues:    []complex64{},
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
+		},
+		{
+			name:              "empty value passed",
+			input:             []string{""},
+			flagDefault:       []complex64{},
+			expectedValues:    []complex64{},
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
+		},
+		{
+			name:        "invalid c64s",
+			input:       []string{"blabla"},
+			flagDefault: []complex64{},
+			expectedErr: `invalid argument "blabla" for "--c64s" flag: element 1: strconv.ParseComplex: parsing "blabla": invalid syntax`,
+		},
+		{
+			name:        "no csv",
+			input:       []string{"1.0,2.0"},
+			flagDefault: []complex64{},
+			expectedErr: `invalid argument "1.0,2.0" for "--c64s" flag: element 1: strconv.ParseComplex: parsing "1.0,2.0": invalid syntax`,
+		},
+		{
+			name:              "multiple values passed",
+			input:             []string{"1.0", "2.0"},
+			flagDefault:       []complex64{},
+			expectedValues:    []complex64{1.0, 2.0},
+			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)"},
+		},
+		{
+			name:              "with default values",
+			input:             []string{"1.0", "2.0"},
+			flagDefault:       []complex64{2.0, 1.0},
+			expectedValues:    []complex64{1.0, 2.0},
+			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)"},
+		},
+		{
+			name:  "replace values",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					_ = val.Replace([]string{"0+2i"})
+				}
+			},
+			expectedValues:    []complex64{complex(0, 2)},
+			expectedStrValues: "[(0.000000+2.000000i)]",
+			expectedGetSlice:  []string{"(0.000000+2.000000i)"},
+		},
+		{
+			name:  "replace values error",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					err := val.Replace([]string{"notc64"})
+					assertErr(t, err)
+				}
+			},
+			expectedValues:    []complex64{complex(1, 0)},
+			expectedStrValues: "[(1.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)"},
+		},
+		{
+			name:  "add values",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					_ = val.Append("2.0")
+				}
+			},
+			expectedValues:    []complex64{complex(1, 0), complex(2, 0)},
+			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)"},
+		},
+		{
+			name:  "add values error",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					err := val.Append("asd")
+					if err == nil {
+						t.Errorf("Expected an error when appending, got %s", err)
+					}
+				}
+			},
+			flagDefault:       nil,
+			expectedValues:    []complex64{complex(1, 0)},
+			expectedStrValues: "[(1.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)"},
+		},
+		{
+			name:              "nil default",
+			input:             []string{},
+			flagDefault:       nil,
+			expectedValues:    nil,
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
+		},
+		{
+			name:              "valid c64s",
+			input:             []string{"1.0", "2.0", "3.0", "0+2i", "1", "2i", "2.5+3.1i"},
+			expectedValues:    []complex64{1.0, 2.0, 3.0, complex(0, 2), complex(1, 0), complex(0, 2), complex(2.5, 3.1)},
+			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i) (3.000000+0.000000i) (0.000000+2.000000i) (1.000000+0.000000i) (0.000000+2.000000i) (2.500000+3.100000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)", "(3.000000+0.000000i)", "(0.000000+2.000000i)", "(1.000000+0.000000i)", "(0.000000+2.000000i)", "(2.500000+3.100000i)"},
+		},
+		{
+			name:  "trims appended and replaced values",
+			input: []string{"1.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					assertNoErr(t, val.Replace([]string{" 0+2i "}))
+					assertNoErr(t, val.Append("  2.0"))
+				}
+			},
+			expectedValues:    []complex64{complex(0, 2), complex(2, 0)},
+			expectedStrValues: "[(0.000000+2.000000i) (2.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(0.000000+2.000000i)", "(2.000000+0.000000i)"},
+		},
+		{
+			name:              "trims input",
+			input:             []string{" 1.0 ", "   2.0", "3.0   ", "  0+2i", "1"},
+			expectedValues:    []complex64{1.0, 2.0, 3.0, complex(0, 2), complex(1, 0)},
+			expectedStrValues: "[(1.000000+0.000000i) (2.000000+0.000000i) (3.000000+0.000000i) (0.000000+2.000000i) (1.000000+0.000000i)]",
+			expectedGetSlice:  []string{"(1.000000+0.000000i)", "(2.000000+0.000000i)", "(3.000000+0.000000i)", "(0.000000+2.000000i)", "(1.000000+0.000000i)"},
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var c64s []complex64
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.Complex64SliceVar(&c64s, "c64s", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--c64s", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+
+			assertDeepEqual(t, test.expectedValues, c64s)
+
+			getC64s, err := f.GetComplex64Slice("c64s")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, getC64s)
+
+			getC64sGet, err := f.Get("c64s")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, getC64sGet)
+
+			flag := f.Lookup("c64s")
+			assertEqual(t, test.expectedStrValues, flag.Value.String())
+
+			sliced := flag.Value.(zflag.SliceValue)
+			assertDeepEqual(t, test.expectedGetSlice, sliced.GetSlice())
+
+			defer assertNoPanic(t)()
+			mustComplex64Slice := f.MustGetComplex64Slice("c64s")
+			assertDeepEqual(t, test.expectedValues, mustComplex64Slice)
+		})
+	}
+}
+
+func TestComplex64SliceErrors(t *testing.T) {
+	var s string
+	var c64s []complex64
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&s, "s", "", "usage")
+	f.Complex64SliceVar(&c64s, "c64s", []complex64{}, "usage")
+	err := f.Parse([]string{})
+	assertNoErr(t, err)
+
+	_, err = f.GetComplex64Slice("s")
+	assertErr(t, err)
+
+	defer assertPanic(t)()
+	_ = f.MustGetComplex64Slice("s")
+}
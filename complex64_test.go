@@ -0,0 +1,119 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestComplex64(t *testing.T) {
+	tests := []struct {
+		name          string
+		flagDefault   complex64
+		input         []string
+		expectedErr   string
+		expectedValue complex64
+		extraOpts     []zflag.Opt
+	}{
+		{
+			name:          "no value passed",
+			input:         []string{},
+			flagDefault:   complex(1, 0),
+			expectedErr:   "",
+			expectedValue: complex(1, 0),
+		},
+		{
+			name:        "empty value passed",
+			input:       repeatFlag("--c64", ""),
+			flagDefault: complex(1, 0),
+			expectedErr: `invalid argument "" for "--c64" flag: strconv.ParseComplex: parsing "": invalid syntax`,
+		},
+		{
+			name:        "invalid complex64",
+			input:       repeatFlag("--c64", "blabla"),
+			flagDefault: complex(1, 0),
+			expectedErr: `invalid argument "blabla" for "--c64" flag: strconv.ParseComplex: parsing "blabla": invalid syntax`,
+		},
+		{
+			name:        "no csv",
+			input:       repeatFlag("--c64", "1.0,1.0"),
+			flagDefault: complex(0, 0),
+			expectedErr: `invalid argument "1.0,1.0" for "--c64" flag: strconv.ParseComplex: parsing "1.0,1.0": invalid syntax`,
+		},
+		{
+			name:          "accepts separate value without no-",
+			input:         []string{"--c64", "1.0"},
+			flagDefault:   complex(0, 0),
+			expectedValue: complex(1, 0),
+		},
+		{
+			name:          "repeated value",
+			input:         repeatFlag("--c64", "1.0", "3.0"),
+			flagDefault:   complex(0, 0),
+			expectedValue: complex(3, 0),
+		},
+		{
+			name:          "with default values",
+			input:         []string{},
+			flagDefault:   complex(4, 0),
+			expectedValue: complex(4, 0),
+		},
+		{
+			name:          "trims input",
+			input:         repeatFlag("--c64", " 1.0 "),
+			expectedValue: complex(1, 0),
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var c64 complex64
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.Complex64Var(&c64, "c64", test.flagDefault, "usage", test.extraOpts...)
+			err := f.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, c64)
+
+			getBS, err := f.GetComplex64("c64")
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, getBS)
+
+			getBSGet, err := f.Get("c64")
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, getBSGet)
+
+			defer assertNoPanic(t)()
+			mustComplex64 := f.MustGetComplex64("c64")
+			assertEqual(t, test.expectedValue, mustComplex64)
+		})
+	}
+}
+
+func TestComplex64Errors(t *testing.T) {
+	var s string
+	var c64 complex64
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&s, "s", "", "usage")
+	f.Complex64Var(&c64, "c64", complex(1, 0), "usage")
+	err := f.Parse([]string{})
+	assertNoErr(t, err)
+
+	_, err = f.GetComplex64("s")
+	assertErr(t, err)
+
+	defer assertPanic(t)()
+	_ = f.MustGetComplex64("s")
+}
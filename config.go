@@ -0,0 +1,243 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigFormat is the file format written by WriteConfig.
+type ConfigFormat int
+
+const (
+	// ConfigFormatTOML writes the config as TOML.
+	ConfigFormatTOML ConfigFormat = iota
+	// ConfigFormatYAML writes the config as YAML.
+	ConfigFormatYAML
+)
+
+// WriteConfig writes a config file to w in the given format, pre-populated
+// with the current values of the flags in the FlagSet. Flags that were changed
+// are written as active entries with their default noted in a comment, while
+// flags left at their default are written commented out. Flags are keyed by
+// their ConfigKey if set, or their name otherwise. Hidden flags are skipped.
+func (fs *FlagSet) WriteConfig(w io.Writer, format ConfigFormat) error {
+	if format != ConfigFormatTOML && format != ConfigFormatYAML {
+		return fmt.Errorf("unknown config format: %d", format)
+	}
+
+	buf := new(bytes.Buffer)
+	first := true
+	fs.VisitAll(func(flag *Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		if !first {
+			buf.WriteString("\n")
+		}
+		first = false
+
+		_, usage := UnquoteUsage(flag)
+		for _, line := range strings.Split(usage, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				fmt.Fprintf(buf, "# %s\n", line)
+			}
+		}
+
+		name := flag.Name
+		if flag.ConfigKey != "" {
+			name = flag.ConfigKey
+		}
+		key := configKey(name, format)
+		if !flag.Changed {
+			fmt.Fprintf(buf, "# %s%s%s\n", key, configSeparator(format), configValue(flag, format))
+			return
+		}
+
+		defValue := flag.DefValue
+		if flag.Secret {
+			defValue = secretMask
+		}
+		fmt.Fprintf(buf, "# default: %s\n", defValue)
+		fmt.Fprintf(buf, "%s%s%s\n", key, configSeparator(format), configValue(flag, format))
+	})
+
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func configSeparator(format ConfigFormat) string {
+	if format == ConfigFormatYAML {
+		return ": "
+	}
+	return " = "
+}
+
+func configKey(name string, format ConfigFormat) string {
+	for _, r := range name {
+		isBare := r == '-' || r == '_' ||
+			(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+		if !isBare {
+			return quoteConfigString(name)
+		}
+	}
+	if name == "" || (format == ConfigFormatYAML && name[0] == '-') {
+		return quoteConfigString(name)
+	}
+	return name
+}
+
+// configValue encodes the current value of the flag. Values whose Get returns
+// a basic Go type are written as native config types, everything else falls
+// back to the quoted output of String.
+func configValue(flag *Flag, format ConfigFormat) string {
+	if flag.Secret {
+		return quoteConfigString(secretMask)
+	}
+
+	sv, isSlice := flag.Value.(SliceValue)
+	if getter, ok := flag.Value.(Getter); ok {
+		v := reflect.ValueOf(getter.Get())
+		// byte slices that are not slice flags have their own textual
+		// encodings (hex, base64)
+		isBytes := v.IsValid() && v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+		if s, ok := encodeConfigValue(v, format); ok && (!isBytes || isSlice) {
+			return s
+		}
+	}
+
+	if isSlice {
+		items := sv.GetSlice()
+		encoded := make([]string, 0, len(items))
+		for _, item := range items {
+			encoded = append(encoded, quoteConfigString(item))
+		}
+		return "[" + strings.Join(encoded, ", ") + "]"
+	}
+
+	return quoteConfigString(flag.Value.String())
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+func encodeConfigValue(v reflect.Value, format ConfigFormat) (string, bool) {
+	if !v.IsValid() {
+		return "", false
+	}
+	if v.Type().Implements(stringerType) {
+		// types such as time.Duration and net.IP are best represented
+		// by their textual form rather than their underlying kind
+		return "", false
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return encodeConfigFloat(v.Float(), v.Type().Bits(), format), true
+	case reflect.String:
+		return quoteConfigString(v.String()), true
+	case reflect.Slice:
+		encoded := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			s, ok := encodeConfigValue(v.Index(i), format)
+			if !ok {
+				return "", false
+			}
+			encoded = append(encoded, s)
+		}
+		return "[" + strings.Join(encoded, ", ") + "]", true
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return "", false
+		}
+		keys := make([]string, 0, v.Len())
+		for _, k := range v.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		sort.Strings(keys)
+
+		encoded := make([]string, 0, len(keys))
+		for _, k := range keys {
+			s, ok := encodeConfigValue(v.MapIndex(reflect.ValueOf(k).Convert(v.Type().Key())), format)
+			if !ok {
+				return "", false
+			}
+			encoded = append(encoded, quoteConfigString(k)+configSeparator(format)+s)
+		}
+		if len(encoded) == 0 {
+			return "{}", true
+		}
+		return "{ " + strings.Join(encoded, ", ") + " }", true
+	}
+
+	return "", false
+}
+
+func encodeConfigFloat(f float64, bitSize int, format ConfigFormat) string {
+	switch {
+	case math.IsNaN(f):
+		if format == ConfigFormatYAML {
+			return ".nan"
+		}
+		return "nan"
+	case math.IsInf(f, 0):
+		s := "inf"
+		if format == ConfigFormatYAML {
+			s = ".inf"
+		}
+		if f < 0 {
+			return "-" + s
+		}
+		return s
+	}
+
+	s := strconv.FormatFloat(f, 'g', -1, bitSize)
+	if !strings.ContainsAny(s, ".e") {
+		s += ".0"
+	}
+	return s
+}
+
+// quoteConfigString returns s as a double-quoted string using only the
+// escapes common to both TOML basic strings and YAML double-quoted scalars.
+func quoteConfigString(s string) string {
+	var b strings.Builder
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&b, `\u%04X`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
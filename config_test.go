@@ -0,0 +1,131 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func newConfigFlagSet(t *testing.T) *zflag.FlagSet {
+	t.Helper()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SortFlags = false
+	f.String("name", "anon", "the `name` to use")
+	f.Int("port", 80, "port to listen on")
+	f.Float32("ratio", 0.5, "ratio")
+	f.Bool("verbose", false, "verbose output")
+	f.Duration("timeout", time.Second, "timeout")
+	f.StringSlice("tags", nil, "tags")
+	f.StringToInt("limits", nil, "limits")
+	f.String("hidden", "", "hidden", zflag.OptHidden())
+
+	err := f.Parse([]string{"--name=zulu \"cmd\"", "--port=8080", "--tags=a", "--tags=b", "--limits=cpu=2", "--limits=mem=4"})
+	assertNoErr(t, err)
+
+	return f
+}
+
+func TestWriteConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		format   zflag.ConfigFormat
+		expected string
+	}{
+		{
+			name:   "toml",
+			format: zflag.ConfigFormatTOML,
+			expected: `# the name to use
+# default: anon
+name = "zulu \"cmd\""
+
+# port to listen on
+# default: 80
+port = 8080
+
+# ratio
+# ratio = 0.5
+
+# verbose output
+# verbose = false
+
+# timeout
+# timeout = "1s"
+
+# tags
+# default: []
+tags = ["a", "b"]
+
+# limits
+# default: []
+limits = { "cpu" = 2, "mem" = 4 }
+`,
+		},
+		{
+			name:   "yaml",
+			format: zflag.ConfigFormatYAML,
+			expected: `# the name to use
+# default: anon
+name: "zulu \"cmd\""
+
+# port to listen on
+# default: 80
+port: 8080
+
+# ratio
+# ratio: 0.5
+
+# verbose output
+# verbose: false
+
+# timeout
+# timeout: "1s"
+
+# tags
+# default: []
+tags: ["a", "b"]
+
+# limits
+# default: []
+limits: { "cpu": 2, "mem": 4 }
+`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			f := newConfigFlagSet(t)
+			assertNoErr(t, f.WriteConfig(&buf, test.format))
+			assertEqualf(t, test.expected, buf.String(), "expected:\n%s\ngot:\n%s", test.expected, buf.String())
+		})
+	}
+}
+
+func TestWriteConfigUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	assertErrMsg(t, "unknown config format: 42", f.WriteConfig(&buf, zflag.ConfigFormat(42)))
+}
+
+func TestWriteConfigKey(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Int("port", 80, "port", zflag.OptConfigKey("server.port"))
+	assertNoErr(t, f.WriteConfig(&buf, zflag.ConfigFormatTOML))
+	assertEqual(t, "# port\n# \"server.port\" = 80\n", buf.String())
+}
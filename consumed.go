@@ -0,0 +1,71 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// ConsumedValueCheck defines how to handle a flag consuming a value that
+// matches the name of another flag, e.g. --name force when a --force flag is
+// defined, which usually means the value of --name was forgotten.
+type ConsumedValueCheck int
+
+const (
+	// ConsumedValueIgnore accepts the value without any check.
+	ConsumedValueIgnore ConsumedValueCheck = iota
+	// ConsumedValueWarn prints a warning to the output of the FlagSet.
+	ConsumedValueWarn
+	// ConsumedValueError fails parsing.
+	ConsumedValueError
+)
+
+// checkConsumedValue checks a value the flag took from the following argument
+// or from the rest of a shorthand cluster.
+func (fs *FlagSet) checkConsumedValue(flag *Flag, value string) error {
+	if fs.ConsumedValueCheck == ConsumedValueIgnore {
+		return nil
+	}
+	if _, isBoolFlag := flag.Value.(BoolFlag); isBoolFlag {
+		return nil
+	}
+
+	other := fs.flagNamedBy(value)
+	if other == nil || other == flag {
+		return nil
+	}
+
+	msg := fmt.Sprintf("flag %s consumed %q as its value, which is also the name of flag --%s; use %s=%s if this is intended",
+		getFlagWithDashes(flag.Name), value, other.Name, getFlagWithDashes(flag.Name), value)
+	if flag.Secret {
+		// naming the other flag would reveal the value as well
+		msg = fmt.Sprintf("flag %s consumed the name of another flag as its value; use %s=%s if this is intended",
+			getFlagWithDashes(flag.Name), getFlagWithDashes(flag.Name), secretMask)
+	}
+	if fs.ConsumedValueCheck == ConsumedValueError {
+		return fs.failf("%s", msg)
+	}
+
+	fs.warnf("Warning: %s\n", msg)
+	return nil
+}
+
+// flagNamedBy returns the flag that value refers to, with or without dashes.
+func (fs *FlagSet) flagNamedBy(value string) *Flag {
+	name := strings.TrimLeft(value, "-")
+	if name == "" {
+		return nil
+	}
+
+	if utf8.RuneCountInString(name) == 1 && len(value) > len(name) {
+		char, _ := utf8.DecodeRuneInString(name)
+		if flag, ok := fs.shorthands[char]; ok {
+			return flag
+		}
+	}
+
+	return fs.lookup(fs.normalizeFlagName(name))
+}
@@ -0,0 +1,92 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestConsumedValueCheck(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		check       zflag.ConsumedValueCheck
+		args        []string
+		expectedErr string
+		warning     string
+	}{
+		{
+			name:  "ignored by default",
+			check: zflag.ConsumedValueIgnore,
+			args:  []string{"--name", "force"},
+		},
+		{
+			name:    "warn long",
+			check:   zflag.ConsumedValueWarn,
+			args:    []string{"--name", "force"},
+			warning: "Warning: flag --name consumed \"force\" as its value, which is also the name of flag --force; use --name=force if this is intended\n",
+		},
+		{
+			name:    "warn shorthand cluster",
+			check:   zflag.ConsumedValueWarn,
+			args:    []string{"-n-f"},
+			warning: "Warning: flag --name consumed \"-f\" as its value, which is also the name of flag --force; use --name=-f if this is intended\n",
+		},
+		{
+			name:        "error shorthand",
+			check:       zflag.ConsumedValueError,
+			args:        []string{"-n", "force"},
+			expectedErr: "flag --name consumed \"force\" as its value, which is also the name of flag --force; use --name=force if this is intended",
+		},
+		{
+			name:    "warn secret",
+			check:   zflag.ConsumedValueWarn,
+			args:    []string{"--token", "force"},
+			warning: "Warning: flag --token consumed the name of another flag as its value; use --token=******** if this is intended\n",
+		},
+		{
+			name:        "error secret",
+			check:       zflag.ConsumedValueError,
+			args:        []string{"--token", "force"},
+			expectedErr: "flag --token consumed the name of another flag as its value; use --token=******** if this is intended",
+		},
+		{
+			name:  "explicit value",
+			check: zflag.ConsumedValueError,
+			args:  []string{"--name=force", "-n=force"},
+		},
+		{
+			name:  "regular value",
+			check: zflag.ConsumedValueError,
+			args:  []string{"--name", "gopher", "-n", "f"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(&buf)
+			f.ConsumedValueCheck = test.check
+			f.String("name", "", "name", zflag.OptShorthand('n'))
+			f.Bool("force", false, "force", zflag.OptShorthand('f'))
+			f.String("token", "", "token", zflag.OptSecret())
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.warning, buf.String())
+		})
+	}
+}
@@ -4,11 +4,17 @@
 package zflag
 
 import (
+	"fmt"
 	"strconv"
 )
 
 // -- count Value
-type countValue int
+type countValue struct {
+	value *int
+	step  int
+	max   int
+	limit bool
+}
 
 var _ Value = (*countValue)(nil)
 var _ Getter = (*countValue)(nil)
@@ -17,30 +23,85 @@ var _ OptionalValue = (*countValue)(nil)
 
 func newCountValue(val int, p *int) *countValue {
 	*p = val
-	return (*countValue)(p)
+	return &countValue{value: p, step: 1}
+}
+
+func countValueOf(f *Flag) (*countValue, error) {
+	if v, ok := f.Value.(*countValue); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("flag %s is not a count flag", f.Name)
+}
+
+// OptMaxCount caps the value of count flags at max. Repeating the flag more
+// often keeps the value at max, while explicitly setting a larger value fails.
+func OptMaxCount(max int) Opt {
+	return func(f *Flag) error {
+		v, err := countValueOf(f)
+		if err != nil {
+			return err
+		}
+		if max < 0 {
+			return fmt.Errorf("maximum count of flag %s must not be negative", f.Name)
+		}
+		v.max = max
+		v.limit = true
+		return nil
+	}
+}
+
+// OptCountStep sets the amount count flags change by each time they are
+// found on the command line, which is 1 by default. With a negative step the
+// flag counts down, but never below zero, e.g. a -q flag decreasing the
+// verbosity counted by a -v flag bound to the same variable.
+func OptCountStep(step int) Opt {
+	return func(f *Flag) error {
+		v, err := countValueOf(f)
+		if err != nil {
+			return err
+		}
+		if step == 0 {
+			return fmt.Errorf("count step of flag %s must not be zero", f.Name)
+		}
+		v.step = step
+		return nil
+	}
 }
 
 func (i *countValue) Set(val string) error {
 	if val == "" {
-		*i++
+		next := *i.value + i.step
+		if i.limit && next > i.max {
+			next = i.max
+		}
+		if i.step < 0 && next < 0 {
+			next = 0
+		}
+		*i.value = next
 		return nil
 	}
 
 	v, err := strconv.ParseInt(val, 0, 0)
-	*i = countValue(v)
+	if err != nil {
+		return err
+	}
+	if i.limit && int(v) > i.max {
+		return fmt.Errorf("count %d exceeds the maximum of %d", v, i.max)
+	}
+	*i.value = int(v)
 
-	return err
+	return nil
 }
 
 func (i *countValue) Get() interface{} {
-	return int(*i)
+	return *i.value
 }
 
 func (i *countValue) Type() string {
 	return "count"
 }
 
-func (i *countValue) String() string { return strconv.Itoa(int(*i)) }
+func (i *countValue) String() string { return strconv.Itoa(*i.value) }
 
 func (i *countValue) IsOptional() bool { return true }
 
@@ -64,7 +125,8 @@ func (fs *FlagSet) MustGetCount(name string) int {
 
 // CountVar defines a count flag with specified name, and usage string.
 // The argument p points to an int variable in which to store the value of the flag.
-// A count flag will add 1 to its value every time it is found on the command line
+// A count flag will add 1 to its value every time it is found on the command line,
+// see OptCountStep and OptMaxCount to change this.
 func (fs *FlagSet) CountVar(p *int, name string, usage string, opts ...Opt) {
 	fs.Var(newCountValue(0, p), name, usage, opts...)
 }
@@ -115,3 +115,88 @@ func TestCountErrors(t *testing.T) {
 	defer assertPanic(t)()
 	_ = f.MustGetCount("s")
 }
+
+func TestCountMaxAndStep(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         []string
+		expectedErr   string
+		expectedValue int
+	}{
+		{
+			name:          "capped at maximum",
+			input:         []string{"-vvvvv"},
+			expectedValue: 3,
+		},
+		{
+			name:          "quiet decreases",
+			input:         []string{"-vvv", "-q"},
+			expectedValue: 2,
+		},
+		{
+			name:          "quiet after cap",
+			input:         []string{"-vvvvv", "-q"},
+			expectedValue: 2,
+		},
+		{
+			name:          "not below zero",
+			input:         []string{"-v", "-qqq"},
+			expectedValue: 0,
+		},
+		{
+			name:          "explicit value within maximum",
+			input:         []string{"--verbose=2"},
+			expectedValue: 2,
+		},
+		{
+			name:        "explicit value above maximum",
+			input:       []string{"--verbose=4"},
+			expectedErr: `invalid argument "4" for "-v, --verbose" flag: count 4 exceeds the maximum of 3`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var verbosity int
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.CountVar(&verbosity, "verbose", "usage", zflag.OptShorthand('v'), zflag.OptMaxCount(3))
+			f.CountVar(&verbosity, "quiet", "usage", zflag.OptShorthand('q'), zflag.OptCountStep(-1))
+			err := f.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedValue, verbosity)
+		})
+	}
+}
+
+func TestCountOptionsInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		def  func(f *zflag.FlagSet)
+	}{
+		{name: "max on non count flag", def: func(f *zflag.FlagSet) { f.Int("int", 0, "usage", zflag.OptMaxCount(1)) }},
+		{name: "negative max", def: func(f *zflag.FlagSet) { f.Count("count", "usage", zflag.OptMaxCount(-1)) }},
+		{name: "step on non count flag", def: func(f *zflag.FlagSet) { f.Int("int", 0, "usage", zflag.OptCountStep(1)) }},
+		{name: "zero step", def: func(f *zflag.FlagSet) { f.Count("count", "usage", zflag.OptCountStep(0)) }},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			defer assertPanic(t)()
+			test.def(f)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// OptDefaultFunc computes the default value of the flag when the FlagSet is
+// parsed rather than when the flag is defined, so the default can depend on
+// the environment or on other flags. fn is called at the start of parsing to
+// render the default in usage output, and again once all arguments and
+// environment variables were applied, to set the value of the flag if it
+// was not set otherwise. In both cases the result is stored in DefValue.
+func OptDefaultFunc(fn func() string) Opt {
+	return func(f *Flag) error {
+		if fn == nil {
+			return fmt.Errorf("default func for flag %q must be set", f.Name)
+		}
+		f.defaultFunc = fn
+		return nil
+	}
+}
+
+// refreshDefaultFuncs updates the DefValue of flags with a default func.
+func (fs *FlagSet) refreshDefaultFuncs() {
+	for _, flag := range fs.orderedFormal {
+		if flag.defaultFunc != nil {
+			flag.DefValue = flag.defaultFunc()
+			fs.InvalidateUsageCache()
+		}
+	}
+}
+
+// applyDefaultFuncs sets the flags that were not set and have a default func
+// to the computed default.
+func (fs *FlagSet) applyDefaultFuncs() error {
+	var err error
+	fs.VisitAll(func(flag *Flag) {
+		if err != nil || flag.defaultFunc == nil || flag.Changed {
+			return
+		}
+		flag.DefValue = flag.defaultFunc()
+		fs.InvalidateUsageCache()
+		if setErr := flag.Value.Set(flag.DefValue); setErr != nil {
+			err = fs.failf("%w", DefinitionError{Err: NewInvalidArgumentError(setErr, flag, flag.DefValue)})
+		}
+	})
+	return err
+}
@@ -0,0 +1,87 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptDefaultFunc(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name             string
+		input            []string
+		expectedEndpoint string
+		expectedChanged  bool
+	}{
+		{
+			name:             "default of other flag",
+			input:            []string{},
+			expectedEndpoint: "https://eu.example.com",
+		},
+		{
+			name:             "depends on other flag",
+			input:            []string{"--region=us"},
+			expectedEndpoint: "https://us.example.com",
+		},
+		{
+			name:             "set explicitly",
+			input:            []string{"--region=us", "--endpoint=http://localhost"},
+			expectedEndpoint: "http://localhost",
+			expectedChanged:  true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			region := f.String("region", "eu", "usage")
+			endpoint := f.String("endpoint", "", "usage", zflag.OptDefaultFunc(func() string {
+				return "https://" + *region + ".example.com"
+			}))
+			assertEqual(t, "", f.Lookup("endpoint").DefValue)
+
+			assertNoErr(t, f.Parse(test.input))
+			assertEqual(t, test.expectedEndpoint, *endpoint)
+			assertEqual(t, test.expectedChanged, f.Changed("endpoint"))
+			if !test.expectedChanged {
+				assertEqual(t, test.expectedEndpoint, f.Lookup("endpoint").DefValue)
+			}
+		})
+	}
+}
+
+func TestOptDefaultFuncUsage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Int("workers", 0, "number of workers", zflag.OptDefaultFunc(func() string { return "4" }))
+
+	err := f.Parse([]string{"--help"})
+	assertEqual(t, zflag.ErrHelp, err)
+	assertEqual(t, "Usage of test:\n      --workers int   number of workers (default 4)\n", buf.String())
+}
+
+func TestOptDefaultFuncInvalid(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("workers", 0, "usage", zflag.OptDefaultFunc(func() string { return "many" }))
+	assertErrMsg(t, `invalid argument "many" for "--workers" flag: strconv.ParseInt: parsing "many": invalid syntax`, f.Parse(nil))
+
+	defer assertPanic(t)()
+	f.Int("nil", 0, "usage", zflag.OptDefaultFunc(nil))
+}
@@ -0,0 +1,28 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// DeferDefine registers fn to define flags right before the FlagSet is parsed,
+// for flags whose existence depends on something only known at runtime, such
+// as the available plugins. The callbacks run once, in the order in which they
+// were registered, at the start of the first call to Parse or ParseAll after
+// their registration.
+func (fs *FlagSet) DeferDefine(fn func(fs *FlagSet)) {
+	fs.deferredDefines = append(fs.deferredDefines, fn)
+}
+
+// DeferDefine registers fn to define command-line flags right before they
+// are parsed. See FlagSet.DeferDefine for more information.
+func DeferDefine(fn func(fs *FlagSet)) {
+	CommandLine.DeferDefine(fn)
+}
+
+func (fs *FlagSet) runDeferredDefines() {
+	// callbacks may register further callbacks, which run in the same pass
+	for len(fs.deferredDefines) > 0 {
+		fn := fs.deferredDefines[0]
+		fs.deferredDefines = fs.deferredDefines[1:]
+		fn(fs)
+	}
+}
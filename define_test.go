@@ -0,0 +1,52 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestDeferDefine(t *testing.T) {
+	t.Parallel()
+
+	plugins := []string{"alpha", "beta"}
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	var calls int
+	f.DeferDefine(func(fs *zflag.FlagSet) {
+		calls++
+		for _, plugin := range plugins {
+			fs.Bool("enable-"+plugin, false, "enable the "+plugin+" plugin")
+		}
+		fs.DeferDefine(func(fs *zflag.FlagSet) {
+			fs.String("nested", "", "usage")
+		})
+	})
+	assertEqual(t, (*zflag.Flag)(nil), f.Lookup("enable-alpha"))
+
+	plugins = append(plugins, "gamma")
+	assertNoErr(t, f.Parse([]string{"--enable-gamma", "--nested=value"}))
+	assertEqual(t, true, f.MustGetBool("enable-gamma"))
+	assertEqual(t, false, f.MustGetBool("enable-alpha"))
+	assertEqual(t, "value", f.MustGetString("nested"))
+
+	assertNoErr(t, f.Parse([]string{"--enable-beta"}))
+	assertEqual(t, 1, calls)
+}
+
+func TestDeferDefineNoArgs(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.DeferDefine(func(fs *zflag.FlagSet) {
+		fs.Int("late", 5, "usage")
+	})
+	assertNoErr(t, f.Parse(nil))
+	assertEqual(t, 5, f.MustGetInt("late"))
+}
@@ -0,0 +1,167 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func dirValueOf(f *Flag) (*dirValue, error) {
+	if v, ok := f.Value.(*dirValue); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("flag %s is not a directory flag", f.Name)
+}
+
+// OptMkdirAll creates the directory of directory flags, including any
+// missing parents, instead of failing if it does not exist
+func OptMkdirAll() Opt {
+	return func(f *Flag) error {
+		v, err := dirValueOf(f)
+		if err != nil {
+			return err
+		}
+		v.mkdirAll = true
+		return nil
+	}
+}
+
+// OptWritable requires the directory of directory flags to be writable
+func OptWritable() Opt {
+	return func(f *Flag) error {
+		v, err := dirValueOf(f)
+		if err != nil {
+			return err
+		}
+		v.writable = true
+		return nil
+	}
+}
+
+// -- dir Value
+type dirValue struct {
+	value    *string
+	mkdirAll bool
+	writable bool
+}
+
+var _ Value = (*dirValue)(nil)
+var _ Getter = (*dirValue)(nil)
+var _ Typed = (*dirValue)(nil)
+
+func newDirValue(val string, p *string) *dirValue {
+	*p = val
+	return &dirValue{value: p}
+}
+
+func (d *dirValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return errors.New("directory path must not be empty")
+	}
+
+	path, err := filepath.Abs(val)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && d.mkdirAll:
+		if err := os.MkdirAll(path, 0o755); err != nil {
+			return err
+		}
+	case errors.Is(err, os.ErrNotExist):
+		return fmt.Errorf("directory %q does not exist", val)
+	case err != nil:
+		return err
+	case !info.IsDir():
+		return fmt.Errorf("%q is not a directory", val)
+	}
+
+	if d.writable {
+		f, err := os.CreateTemp(path, ".zflag-")
+		if err != nil {
+			return fmt.Errorf("directory %q is not writable", val)
+		}
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}
+
+	*d.value = path
+	return nil
+}
+
+// applyDefault validates and normalizes the default directory if the flag
+// was not set, as it depends on the options of the flag.
+func (d *dirValue) applyDefault() error {
+	if *d.value == "" {
+		return nil
+	}
+	return d.Set(*d.value)
+}
+
+func (d *dirValue) Get() interface{} {
+	return *d.value
+}
+
+func (d *dirValue) Type() string {
+	return "dir"
+}
+
+func (d *dirValue) String() string { return *d.value }
+
+// GetDir return the absolute directory path of a flag with the given name
+func (fs *FlagSet) GetDir(name string) (string, error) {
+	val, err := fs.getFlagValue(name, "dir")
+	if err != nil {
+		return "", err
+	}
+	return val.(string), nil
+}
+
+// MustGetDir is like GetDir, but panics on error.
+func (fs *FlagSet) MustGetDir(name string) string {
+	val, err := fs.GetDir(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// DirVar defines a directory flag with specified name, default value, and usage string.
+// The argument p points to a string variable in which to store the absolute
+// path of the directory, which must exist unless OptMkdirAll is used. The
+// default directory, if not empty, is validated when the flags are parsed and
+// the flag was not set.
+func (fs *FlagSet) DirVar(p *string, name string, value string, usage string, opts ...Opt) {
+	fs.Var(newDirValue(value, p), name, usage, opts...)
+}
+
+// DirVar defines a directory flag with specified name, default value, and usage string.
+// The argument p points to a string variable in which to store the absolute
+// path of the directory. See FlagSet.DirVar for more information.
+func DirVar(p *string, name string, value string, usage string, opts ...Opt) {
+	CommandLine.DirVar(p, name, value, usage, opts...)
+}
+
+// Dir defines a directory flag with specified name, default value, and usage string.
+// The return value is the address of a string variable that stores the absolute
+// path of the directory. See FlagSet.DirVar for more information.
+func (fs *FlagSet) Dir(name string, value string, usage string, opts ...Opt) *string {
+	var p string
+	fs.DirVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Dir defines a directory flag with specified name, default value, and usage string.
+// The return value is the address of a string variable that stores the absolute
+// path of the directory. See FlagSet.DirVar for more information.
+func Dir(name string, value string, usage string, opts ...Opt) *string {
+	return CommandLine.Dir(name, value, usage, opts...)
+}
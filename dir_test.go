@@ -0,0 +1,124 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "file.txt")
+	assertNoErr(t, ioutil.WriteFile(file, []byte("contents"), 0o600))
+	missing := filepath.Join(dir, "missing")
+	nested := filepath.Join(dir, "a", "b")
+
+	cwd, err := os.Getwd()
+	assertNoErr(t, err)
+
+	tests := []struct {
+		name        string
+		flagDefault string
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    string
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: "",
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--dir" flag: directory path must not be empty`,
+		},
+		{
+			name:     "existing directory",
+			input:    []string{dir},
+			expected: dir,
+		},
+		{
+			name:     "relative directory",
+			input:    []string{"."},
+			expected: cwd,
+		},
+		{
+			name:        "missing directory",
+			input:       []string{missing},
+			expectedErr: fmt.Sprintf(`invalid argument %q for "--dir" flag: directory %q does not exist`, missing, missing),
+		},
+		{
+			name:        "not a directory",
+			input:       []string{file},
+			expectedErr: fmt.Sprintf(`invalid argument %q for "--dir" flag: %q is not a directory`, file, file),
+		},
+		{
+			name:     "mkdir all",
+			opts:     []zflag.Opt{zflag.OptMkdirAll()},
+			input:    []string{nested},
+			expected: nested,
+		},
+		{
+			name:     "writable",
+			opts:     []zflag.Opt{zflag.OptWritable()},
+			input:    []string{dir},
+			expected: dir,
+		},
+		{
+			name:        "default directory",
+			flagDefault: ".",
+			input:       []string{},
+			expected:    cwd,
+		},
+		{
+			name:        "missing default directory",
+			flagDefault: missing,
+			input:       []string{},
+			expectedErr: fmt.Sprintf(`invalid argument %q for "--dir" flag: directory %q does not exist`, missing, missing),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v string
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.DirVar(&v, "dir", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--dir", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			got, err := f.GetDir("dir")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetDir("dir"))
+		})
+	}
+}
+
+func TestOptMkdirAllInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.String("dir", "", "usage", zflag.OptMkdirAll())
+}
@@ -32,28 +32,30 @@ func TestDurationSlice(t *testing.T) {
 			expectedGetSlice:  []string{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []time.Duration{},
-			expectedErr: `invalid argument "" for "--ds" flag: time: invalid duration ""`,
+			name:              "empty value passed",
+			input:             []string{""},
+			flagDefault:       []time.Duration{},
+			expectedValues:    []time.Duration{},
+			expectedStrValues: "[]",
+			expectedGetSlice:  []string{},
 		},
 		{
 			name:        "invalid unit",
 			input:       []string{"2q"},
 			flagDefault: []time.Duration{},
-			expectedErr: `invalid argument "2q" for "--ds" flag: time: unknown unit "q" in duration "2q"`,
+			expectedErr: `invalid argument "2q" for "--ds" flag: element 1: time: unknown unit "q" in duration "2q"`,
 		},
 		{
 			name:        "invalid duration",
 			input:       []string{"blabla"},
 			flagDefault: []time.Duration{},
-			expectedErr: `invalid argument "blabla" for "--ds" flag: time: invalid duration "blabla"`,
+			expectedErr: `invalid argument "blabla" for "--ds" flag: element 1: time: invalid duration "blabla"`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"2m,2h"},
 			flagDefault: []time.Duration{},
-			expectedErr: `invalid argument "2m,2h" for "--ds" flag: time: unknown unit "m," in duration "2m,2h"`,
+			expectedErr: `invalid argument "2m,2h" for "--ds" flag: element 1: time: unknown unit "m," in duration "2m,2h"`,
 		},
 		{
 			name:              "defaults returned",
@@ -0,0 +1,95 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"os"
+	"strings"
+	"unicode"
+)
+
+// SetEnvPrefix binds every flag that is not bound to an environment variable
+// with OptEnv to a variable derived from prefix and the flag name, e.g. the
+// flag --log-level is bound to MYAPP_LOG_LEVEL with the prefix "myapp". The
+// name is upper-cased and every character that is not a letter or digit is
+// replaced by an underscore. This applies to flags defined before and after
+// calling SetEnvPrefix, an empty prefix removes the derived bindings.
+func (fs *FlagSet) SetEnvPrefix(prefix string) {
+	fs.envPrefix = prefix
+	fs.InvalidateUsageCache()
+	for _, flag := range fs.orderedFormal {
+		fs.deriveEnvVar(flag)
+	}
+}
+
+// SetEnvPrefix sets the environment variable prefix of the command-line
+// flags. See FlagSet.SetEnvPrefix for more information.
+func SetEnvPrefix(prefix string) {
+	CommandLine.SetEnvPrefix(prefix)
+}
+
+// EnvBindings returns the environment variable each flag is bound to, keyed by
+// flag name, after the env prefix was applied. Flags that are not bound to an
+// environment variable are not included. This is useful to generate
+// documentation or deployment manifests.
+func (fs *FlagSet) EnvBindings() map[string]string {
+	bindings := make(map[string]string)
+	for _, flag := range fs.orderedFormal {
+		if flag.EnvVar != "" {
+			bindings[flag.Name] = flag.EnvVar
+		}
+	}
+	return bindings
+}
+
+// EnvBindings returns the environment variable bindings of the command-line
+// flags. See FlagSet.EnvBindings for more information.
+func EnvBindings() map[string]string {
+	return CommandLine.EnvBindings()
+}
+
+// deriveEnvVar binds flag to the environment variable derived from the env
+// prefix, unless it was bound explicitly.
+func (fs *FlagSet) deriveEnvVar(flag *Flag) {
+	if flag.negates != nil || (flag.EnvVar != "" && !flag.envDerived) {
+		return
+	}
+	flag.EnvVar = ""
+	flag.envDerived = fs.envPrefix != ""
+	if flag.envDerived {
+		flag.EnvVar = envVarName(strings.TrimSuffix(fs.envPrefix, "_") + "_" + flag.Name)
+	}
+}
+
+func envVarName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, name)
+}
+
+// applyEnv sets the flags that were not set on the command line from their
+// bound environment variables.
+func (fs *FlagSet) applyEnv(fn parseFunc) error {
+	var err error
+	fs.VisitAll(func(flag *Flag) {
+		if err != nil || flag.Changed || flag.EnvVar == "" {
+			return
+		}
+
+		value, ok := os.LookupEnv(flag.EnvVar)
+		if !ok {
+			return
+		}
+
+		if fnErr := fn(flag, value); fnErr != nil {
+			err = fs.failf("%w (from environment variable %s)", fnErr, flag.EnvVar)
+			return
+		}
+		flag.Source = SourceEnv
+	})
+	return err
+}
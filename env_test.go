@@ -0,0 +1,136 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func setEnv(t *testing.T, key, value string) {
+	t.Helper()
+	if err := os.Setenv(key, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Unsetenv(key) })
+}
+
+func TestOptEnv(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_ENV_PORT", "8080")
+	setEnv(t, "ZFLAG_TEST_ENV_NAME", "from-env")
+	setEnv(t, "ZFLAG_TEST_ENV_BAD", "abc")
+
+	tests := []struct {
+		name         string
+		args         []string
+		expectedPort int
+		expectedName string
+	}{
+		{name: "no args", args: []string{}, expectedPort: 8080, expectedName: "from-env"},
+		{name: "args take precedence", args: []string{"--port=9090"}, expectedPort: 9090, expectedName: "from-env"},
+	}
+
+	for _, test := range tests {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		port := f.Int("port", 80, "usage", zflag.OptEnv("ZFLAG_TEST_ENV_PORT"))
+		name := f.String("name", "", "usage", zflag.OptEnv("ZFLAG_TEST_ENV_NAME"), zflag.OptRequired())
+		unset := f.String("unset", "default", "usage", zflag.OptEnv("ZFLAG_TEST_ENV_UNSET"))
+
+		assertNoErr(t, f.Parse(test.args))
+		assertEqualf(t, test.expectedPort, *port, "%s: expected port %d, got %d", test.name, test.expectedPort, *port)
+		assertEqualf(t, test.expectedName, *name, "%s: expected name %q, got %q", test.name, test.expectedName, *name)
+		assertEqual(t, "default", *unset)
+		assertEqual(t, true, f.Changed("name"))
+		assertEqual(t, false, f.Changed("unset"))
+	}
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("bad", 0, "usage", zflag.OptEnv("ZFLAG_TEST_ENV_BAD"))
+	err := f.Parse(nil)
+	assertErrMsg(t, `invalid argument "abc" for "--bad" flag: strconv.ParseInt: parsing "abc": invalid syntax (from environment variable ZFLAG_TEST_ENV_BAD)`, err)
+}
+
+func TestOptEnvPanicOnError(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_ENV_BAD", "abc")
+
+	f := zflag.NewFlagSet("test", zflag.PanicOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("bad", 0, "usage", zflag.OptEnv("ZFLAG_TEST_ENV_BAD"))
+
+	defer assertPanic(t)()
+	_ = f.Parse(nil)
+}
+
+func TestEnvAndConfigKeyInUsage(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.Int("port", 80, "port to listen on", zflag.OptEnv("MY_APP_PORT"), zflag.OptConfigKey("server.port"))
+	f.String("name", "", "the name", zflag.OptEnv("MY_APP_NAME"))
+
+	expected := `      --name string   the name (env: MY_APP_NAME)
+      --port int      port to listen on (default 80) (env: MY_APP_PORT) (config: server.port)
+`
+	assertEqual(t, expected, f.FlagUsages())
+}
+
+func TestOptEnvEmpty(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.Int("port", 80, "usage", zflag.OptEnv(""))
+}
+
+func TestFlagSource(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_SOURCE", "from-env")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("args", "", "usage")
+	f.String("env", "", "usage", zflag.OptEnv("ZFLAG_TEST_SOURCE"))
+	f.String("set", "", "usage")
+	f.String("default", "", "usage")
+
+	assertNoErr(t, f.Set("set", "value"))
+	assertNoErr(t, f.Parse([]string{"--args=value"}))
+
+	assertEqual(t, zflag.SourceArgs, f.Lookup("args").Source)
+	assertEqual(t, zflag.SourceEnv, f.Lookup("env").Source)
+	assertEqual(t, zflag.SourceSet, f.Lookup("set").Source)
+	assertEqual(t, "", f.Lookup("default").Source)
+}
+
+func TestEnvPrefix(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_PREFIX_LOG_LEVEL", "debug")
+	setEnv(t, "ZFLAG_TEST_PREFIX_PORT", "8080")
+
+	f := zflag.New("test", zflag.WithEnvPrefix("zflag_test_prefix_"))
+	logLevel := f.String("log-level", "info", "usage")
+	f.String("name", "", "usage", zflag.OptEnv("ZFLAG_TEST_NAME"))
+	f.SetEnvPrefix("zflag_test_prefix")
+	port := f.Int("port", 80, "usage")
+	f.Bool("dry.run", false, "usage")
+
+	assertDeepEqual(t, map[string]string{
+		"dry.run":   "ZFLAG_TEST_PREFIX_DRY_RUN",
+		"log-level": "ZFLAG_TEST_PREFIX_LOG_LEVEL",
+		"name":      "ZFLAG_TEST_NAME",
+		"port":      "ZFLAG_TEST_PREFIX_PORT",
+	}, f.EnvBindings())
+	usages := f.FlagUsages()
+	assertEqualf(t, true, strings.Contains(usages, "(env: ZFLAG_TEST_PREFIX_DRY_RUN)"), "expected derived env var in usage, got:\n%s", usages)
+
+	assertNoErr(t, f.Parse(nil))
+	assertEqual(t, "debug", *logLevel)
+	assertEqual(t, 8080, *port)
+
+	f.SetEnvPrefix("")
+	assertDeepEqual(t, map[string]string{"name": "ZFLAG_TEST_NAME"}, f.EnvBindings())
+}
@@ -4,10 +4,91 @@
 package zflag
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 )
 
+// UserInputError is returned by Parse for errors in the arguments or the
+// environment variables passed by the user, e.g. an unknown flag or an
+// invalid value. Programs can tell the categories of errors apart with
+// errors.As, e.g. to choose an exit code.
+type UserInputError struct {
+	Err error
+}
+
+var _ error = (*UserInputError)(nil)
+
+func (e UserInputError) Error() string {
+	return e.Err.Error()
+}
+
+func (e UserInputError) Unwrap() error {
+	return e.Err
+}
+
+// ConstraintError is returned by Parse and Validate if the flags were parsed
+// successfully but violate a constraint, e.g. a required flag is not set or
+// mutually exclusive flags were set together.
+type ConstraintError struct {
+	Err error
+}
+
+var _ error = (*ConstraintError)(nil)
+
+func (e ConstraintError) Error() string {
+	return e.Err.Error()
+}
+
+func (e ConstraintError) Unwrap() error {
+	return e.Err
+}
+
+// DefinitionError is returned by Parse and Validate for mistakes in the
+// definition of the flags that can only be detected while parsing, e.g. a
+// flag implying a flag that is not defined. Mistakes detected when a flag is
+// defined panic instead.
+type DefinitionError struct {
+	Err error
+}
+
+var _ error = (*DefinitionError)(nil)
+
+func (e DefinitionError) Error() string {
+	return e.Err.Error()
+}
+
+func (e DefinitionError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned by SafeParse if parsing panicked. Value is the value
+// passed to panic and Stack the stack trace of the panicking goroutine.
+type PanicError struct {
+	Value interface{}
+	Stack []byte
+}
+
+var _ error = (*PanicError)(nil)
+
+func (e PanicError) Error() string {
+	return fmt.Sprintf("zflag: panic while parsing: %v", e.Value)
+}
+
+// Unwrap returns Value if it is an error.
+func (e PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// isCategorized reports whether err already carries an error category.
+func isCategorized(err error) bool {
+	var userErr UserInputError
+	var constraintErr ConstraintError
+	var definitionErr DefinitionError
+	return errors.As(err, &userErr) || errors.As(err, &constraintErr) || errors.As(err, &definitionErr)
+}
+
 func getFlagWithDashes(name string) string {
 	dash := "--"
 	if len(name) == 1 {
@@ -18,7 +99,8 @@ func getFlagWithDashes(name string) string {
 }
 
 type UnknownFlagError struct {
-	name string
+	name       string
+	suggestion string
 }
 
 var _ error = (*UnknownFlagError)(nil)
@@ -28,7 +110,55 @@ func NewUnknownFlagError(name string) error {
 }
 
 func (e UnknownFlagError) Error() string {
-	return fmt.Sprintf("unknown flag: %s", getFlagWithDashes(e.name))
+	return fmt.Sprintf("unknown flag: %s%s", getFlagWithDashes(e.name), formatSuggestion(e.suggestion))
+}
+
+// AmbiguousFlagError is returned if an abbreviated long flag matches multiple
+// flags, see FlagSet.AllowAbbreviations.
+type AmbiguousFlagError struct {
+	Name       string
+	Candidates []string
+}
+
+var _ error = (*AmbiguousFlagError)(nil)
+
+func (e AmbiguousFlagError) Error() string {
+	return fmt.Sprintf("ambiguous flag: --%s matches %s", e.Name, strings.Join(e.Candidates, ", "))
+}
+
+// SyntaxError is returned by Parse for a malformed long flag argument, e.g.
+// --=value, --= or --name==value. Index is the index of the argument within
+// the parsed arguments and Offset the byte offset of the offending character
+// within the argument. The value of secret flags is masked in Arg.
+type SyntaxError struct {
+	Arg    string
+	Index  int
+	Offset int
+	Reason string
+}
+
+var _ error = (*SyntaxError)(nil)
+
+func (e SyntaxError) Error() string {
+	return fmt.Sprintf("bad flag syntax: %q: %s (argument %d, offset %d)", e.Arg, e.Reason, e.Index, e.Offset)
+}
+
+// checkLongArgSyntax checks the long flag argument s, split into its name and
+// inline value, for an empty name or a doubled '='.
+func (fs *FlagSet) checkLongArgSyntax(s string, index int, split []string) error {
+	if flag := fs.lookup(fs.normalizeFlagName(split[0])); flag != nil && flag.Secret && len(split) == 2 {
+		s = "--" + split[0] + "=" + secretMask
+	}
+
+	switch {
+	case split[0] == "" && split[1] == "":
+		return SyntaxError{Arg: s, Index: index, Offset: 2, Reason: "missing flag name and value after '--'"}
+	case split[0] == "":
+		return SyntaxError{Arg: s, Index: index, Offset: 2, Reason: "missing flag name before '='"}
+	case len(split) == 2 && strings.HasPrefix(split[1], "="):
+		return SyntaxError{Arg: s, Index: index, Offset: 3 + len(split[0]), Reason: "unexpected second '='"}
+	}
+	return nil
 }
 
 type MissingFlagsError []string
@@ -48,6 +178,39 @@ func (e MissingFlagsError) Error() string {
 	return fmt.Sprintf(`required flag(s) %s not set`, strings.Join(flagNames, `, `))
 }
 
+// MutuallyExclusiveFlagsError is returned when more than one flag of a set
+// of mutually exclusive flags was set, see Group.MarkMutuallyExclusive.
+type MutuallyExclusiveFlagsError []string
+
+var _ error = (*MutuallyExclusiveFlagsError)(nil)
+
+func (e MutuallyExclusiveFlagsError) Error() string {
+	flagNames := make([]string, 0, len(e))
+	for _, s := range e {
+		flagNames = append(flagNames, fmt.Sprintf("%q", s))
+	}
+
+	return fmt.Sprintf(`flags %s are mutually exclusive, but were set together`, strings.Join(flagNames, `, `))
+}
+
+// FlagDependencyError is returned when a flag is set without a flag it
+// requires, see OptRequires, or together with a flag it conflicts with, see
+// OptConflictsWith.
+type FlagDependencyError struct {
+	Flag     string
+	Other    string
+	Conflict bool
+}
+
+var _ error = (*FlagDependencyError)(nil)
+
+func (e FlagDependencyError) Error() string {
+	if e.Conflict {
+		return fmt.Sprintf("flag %q cannot be used with flag %q", getFlagWithDashes(e.Flag), getFlagWithDashes(e.Other))
+	}
+	return fmt.Sprintf("flag %q requires flag %q to be set", getFlagWithDashes(e.Flag), getFlagWithDashes(e.Other))
+}
+
 type InvalidArgumentError struct {
 	flagName string
 	value    interface{}
@@ -67,6 +230,11 @@ func NewInvalidArgumentError(err error, f *Flag, value interface{}) error {
 		flagName = getFlagWithDashes(f.Name)
 	}
 
+	if f.Secret {
+		err = redactError(err)
+		value = secretMask
+	}
+
 	return InvalidArgumentError{
 		flagName: flagName,
 		value:    value,
@@ -81,3 +249,52 @@ func (e InvalidArgumentError) Error() string {
 func (e InvalidArgumentError) Unwrap() error {
 	return e.err
 }
+
+// ElementError is returned by slice and map flags when a single element of the
+// value is invalid. Position is the 1-based position of the element within a
+// slice flag, Key is the key of the element within a map flag.
+type ElementError struct {
+	Position int
+	Key      string
+	Err      error
+}
+
+var _ error = (*ElementError)(nil)
+
+// NewElementError wraps err with the 1-based position of the invalid element
+// of a slice flag. It returns nil if err is nil.
+func NewElementError(position int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return ElementError{Position: position, Err: err}
+}
+
+// NewKeyError wraps err with the key of the invalid element of a map flag. It
+// returns nil if err is nil.
+func NewKeyError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return ElementError{Key: key, Err: err}
+}
+
+func (e ElementError) Error() string {
+	if e.Position == 0 {
+		return fmt.Sprintf("key %q: %s", e.Key, e.Err)
+	}
+	return fmt.Sprintf("element %d: %s", e.Position, e.Err)
+}
+
+func (e ElementError) Unwrap() error {
+	return e.Err
+}
+
+// elementPosition returns the position of the element added by Set to a slice
+// of length n, which replaces the slice when it was not changed yet.
+func elementPosition(changed bool, n int) int {
+	if !changed {
+		return 1
+	}
+	return n + 1
+}
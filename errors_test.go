@@ -0,0 +1,77 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestErrorCategories(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		category string
+	}{
+		{name: "unknown flag", args: []string{"--unknown"}, category: "user input"},
+		{name: "invalid value", args: []string{"--port=abc"}, category: "user input"},
+		{name: "missing argument", args: []string{"--port"}, category: "user input"},
+		{name: "missing required flag", args: []string{}, category: "constraint"},
+		{name: "dependency", args: []string{"--name=x", "--tls"}, category: "constraint"},
+		{name: "implied flag not defined", args: []string{"--name=x", "--debug"}, category: "definition"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("name", "", "name", zflag.OptRequired())
+			f.Int("port", 0, "port")
+			f.Bool("tls", false, "tls", zflag.OptRequires("port"))
+			f.Bool("debug", false, "debug", zflag.OptImplies("trace", "true"))
+
+			err := f.Parse(test.args)
+			assertErr(t, err)
+
+			var userErr zflag.UserInputError
+			var constraintErr zflag.ConstraintError
+			var definitionErr zflag.DefinitionError
+			var category string
+			switch {
+			case errors.As(err, &userErr):
+				category = "user input"
+			case errors.As(err, &constraintErr):
+				category = "constraint"
+			case errors.As(err, &definitionErr):
+				category = "definition"
+			}
+			assertEqualf(t, test.category, category, "unexpected category for error %v", err)
+		})
+	}
+}
+
+func TestErrorCategoriesUnwrap(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Int("port", 0, "port")
+	err := f.Parse([]string{"--port=abc"})
+
+	var invalidErr zflag.InvalidArgumentError
+	assertEqual(t, true, errors.As(err, &invalidErr))
+	assertErrMsg(t, `invalid argument "abc" for "--port" flag: strconv.ParseInt: parsing "abc": invalid syntax`, err)
+
+	var missingErr zflag.MissingFlagsError
+	f.String("name", "", "name", zflag.OptRequired())
+	assertEqual(t, true, errors.As(f.Validate(), &missingErr))
+}
@@ -0,0 +1,20 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// SetExpandFunc enables the expansion of $var and ${var} references in flag
+// values, which are replaced by os.Expand using mapping before the value is
+// set. Pass os.Getenv to expand environment variables, or nil to disable
+// expansion, which is the default. Expansion applies to all values passed to
+// Set, including those read from the environment or config files.
+func (fs *FlagSet) SetExpandFunc(mapping func(string) string) {
+	fs.expandFunc = mapping
+}
+
+// SetExpandFunc enables the expansion of $var and ${var} references in the
+// values of the command-line flags. See FlagSet.SetExpandFunc for more
+// information.
+func SetExpandFunc(mapping func(string) string) {
+	CommandLine.SetExpandFunc(mapping)
+}
@@ -0,0 +1,86 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSetExpandFunc(t *testing.T) {
+	t.Parallel()
+
+	vars := map[string]string{
+		"HOME": "/home/gopher",
+		"PORT": "8080",
+	}
+	mapping := func(name string) string {
+		return vars[name]
+	}
+
+	tests := []struct {
+		name    string
+		mapping func(string) string
+		args    []string
+		path    string
+		port    int
+	}{
+		{
+			name:    "expanded",
+			mapping: mapping,
+			args:    []string{"--path=$HOME/data", "--port", "${PORT}"},
+			path:    "/home/gopher/data",
+			port:    8080,
+		},
+		{
+			name:    "undefined",
+			mapping: mapping,
+			args:    []string{"--path=$UNDEFINED/data"},
+			path:    "/data",
+		},
+		{
+			name: "disabled",
+			args: []string{"--path=$HOME/data"},
+			path: "$HOME/data",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.SetExpandFunc(test.mapping)
+			path := f.String("path", "", "path")
+			port := f.Int("port", 0, "port")
+
+			err := f.Parse(test.args)
+			assertNoErr(t, err)
+			assertEqual(t, test.path, *path)
+			assertEqual(t, test.port, *port)
+		})
+	}
+}
+
+func TestSetExpandFuncEnv(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_EXPAND_VALUE", "${ZFLAG_TEST_EXPAND}")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetExpandFunc(func(name string) string {
+		if name == "ZFLAG_TEST_EXPAND" {
+			return "from-mapping"
+		}
+		return ""
+	})
+	value := f.String("value", "", "value", zflag.OptEnv("ZFLAG_TEST_EXPAND_VALUE"))
+
+	err := f.Parse(nil)
+	assertNoErr(t, err)
+	assertEqual(t, "from-mapping", *value)
+}
@@ -3,7 +3,10 @@
 
 package zflag
 
-import "os"
+import (
+	"context"
+	"os"
+)
 
 // Additional routines compiled into the package only during testing.
 
@@ -33,3 +36,7 @@ func GetFlagFormalField(f *FlagSet) map[NormalizedName]*Flag {
 func CallDefaultUsage(f *FlagSet) {
 	f.defaultUsage()
 }
+
+func ReloadOn(f *FlagSet, ctx context.Context, ch <-chan os.Signal, onReload func(changed []*Flag, err error)) {
+	f.reloadOn(ctx, ch, onReload)
+}
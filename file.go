@@ -0,0 +1,29 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"os"
+	"strings"
+)
+
+// readValueFromFile returns the contents of the file at path if value has the
+// form @path, trimmed if trim is set. A value starting with @@ is returned with the first @
+// removed, so values starting with a literal @ can still be passed.
+func readValueFromFile(value string, trim bool) (string, error) {
+	switch {
+	case strings.HasPrefix(value, "@@"):
+		return value[1:], nil
+	case strings.HasPrefix(value, "@"):
+		contents, err := os.ReadFile(value[1:])
+		if err != nil {
+			return value, err
+		}
+		if !trim {
+			return string(contents), nil
+		}
+		return strings.TrimSpace(string(contents)), nil
+	}
+	return value, nil
+}
@@ -0,0 +1,117 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptValueFromFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	token := filepath.Join(dir, "token")
+	err := ioutil.WriteFile(token, []byte("  s3cr3t\n"), 0o600)
+	assertNoErr(t, err)
+	port := filepath.Join(dir, "port")
+	err = ioutil.WriteFile(port, []byte("8080\n"), 0o600)
+	assertNoErr(t, err)
+
+	tests := []struct {
+		name        string
+		args        []string
+		token       string
+		port        int
+		plain       string
+		expectedErr string
+		errIs       error
+	}{
+		{
+			name:  "read from file",
+			args:  []string{"--token=@" + token, "--port", "@" + port},
+			token: "s3cr3t",
+			port:  8080,
+		},
+		{
+			name:  "literal value",
+			args:  []string{"--token=value"},
+			token: "value",
+		},
+		{
+			name:  "escaped at",
+			args:  []string{"--token=@@value"},
+			token: "@value",
+		},
+		{
+			name:  "not enabled",
+			args:  []string{"--plain=@" + token},
+			plain: "@" + token,
+		},
+		{
+			name:  "missing file",
+			args:  []string{"--port=@" + filepath.Join(dir, "missing")},
+			errIs: os.ErrNotExist,
+		},
+		{
+			name:        "secret file contents are masked",
+			args:        []string{"--pin=@" + token},
+			expectedErr: `invalid argument "********" for "--pin" flag: invalid value`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			tokenValue := f.String("token", "", "token", zflag.OptValueFromFile())
+			portValue := f.Int("port", 0, "port", zflag.OptValueFromFile())
+			plainValue := f.String("plain", "", "plain")
+			f.Int("pin", 0, "pin", zflag.OptValueFromFile(), zflag.OptSecret())
+
+			err := f.Parse(test.args)
+			if test.errIs != nil {
+				assertEqualf(t, true, errors.Is(err, test.errIs), "expected error %v to be %v", err, test.errIs)
+				return
+			}
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.token, *tokenValue)
+			assertEqual(t, test.port, *portValue)
+			assertEqual(t, test.plain, *plainValue)
+		})
+	}
+}
+
+func TestOptRaw(t *testing.T) {
+	t.Parallel()
+
+	payload := filepath.Join(t.TempDir(), "payload")
+	assertNoErr(t, ioutil.WriteFile(payload, []byte("  \xffpayload\n"), 0o600))
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StrictUTF8 = true
+	f.SetExpandFunc(func(string) string { return "expanded" })
+	raw := f.String("raw", "", "usage", zflag.OptRaw())
+	file := f.String("file", "", "usage", zflag.OptRaw(), zflag.OptValueFromFile())
+	plain := f.String("plain", "", "usage")
+
+	err := f.Parse([]string{"--raw", " $HOME\xff ", "--file", "@" + payload, "--plain", " $HOME "})
+	assertNoErr(t, err)
+	assertEqual(t, " $HOME\xff ", *raw)
+	assertEqual(t, "  \xffpayload\n", *file)
+	assertEqual(t, " expanded ", *plain)
+}
@@ -0,0 +1,52 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// Filter returns a new FlagSet with the flags for which keep returns true,
+// e.g. the flags of a single group or the flags that were changed, so help
+// output, completion or forwarding can operate on a subset of the flags.
+//
+// The flags of the new FlagSet are copies that share the Value of the
+// original flags, so setting a flag in either FlagSet changes the value in
+// both, while Changed and the other properties of the flags are tracked
+// separately from then on. The new FlagSet has the same name and copies the
+// settings of fs that affect parsing and usage output.
+func (fs *FlagSet) Filter(keep func(*Flag) bool) *FlagSet {
+	filtered := NewFlagSet(fs.name, fs.errorHandling)
+	filtered.SortFlags = fs.SortFlags
+	filtered.ParseErrorsAllowList = fs.ParseErrorsAllowList
+	filtered.DisableBuiltinHelp = fs.DisableBuiltinHelp
+	filtered.DisableSuggestions = fs.DisableSuggestions
+	filtered.ShowDeprecated = fs.ShowDeprecated
+	filtered.ConsumedValueCheck = fs.ConsumedValueCheck
+	filtered.StrictUTF8 = fs.StrictUTF8
+	filtered.FlagUsageFormatter = fs.FlagUsageFormatter
+	filtered.output = fs.output
+	filtered.interspersed = fs.interspersed
+	filtered.normalizeNameFunc = fs.normalizeNameFunc
+	filtered.groupOrder = fs.groupOrder
+	filtered.expandFunc = fs.expandFunc
+	filtered.envPrefix = fs.envPrefix
+	filtered.pathBase = fs.pathBase
+	for group, desc := range fs.groupDescriptions {
+		filtered.SetGroupDescription(group, desc)
+	}
+
+	for _, flag := range fs.orderedFormal {
+		if !keep(flag) {
+			continue
+		}
+
+		clone := *flag
+		filtered.AddFlag(&clone)
+		if clone.Changed {
+			if filtered.actual == nil {
+				filtered.actual = make(map[NormalizedName]*Flag)
+			}
+			filtered.actual[NormalizedName(clone.Name)] = &clone
+			filtered.orderedActual = append(filtered.orderedActual, &clone)
+		}
+	}
+	return filtered
+}
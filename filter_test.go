@@ -0,0 +1,48 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	host := f.String("host", "localhost", "host", zflag.OptGroup("network"), zflag.OptShorthand('H'))
+	port := f.Int("port", 80, "port", zflag.OptGroup("network"))
+	f.Bool("verbose", false, "verbose output")
+	f.SetGroupDescription("network", "Network settings.")
+	assertNoErr(t, f.Parse([]string{"--port=8080", "--verbose"}))
+
+	network := f.Filter(func(flag *zflag.Flag) bool { return flag.Group == "network" })
+	assertEqual(t, "test", network.Name())
+	assertEqual(t, "Network settings.", network.GroupDescription("network"))
+	assertEqual(t, (*zflag.Flag)(nil), network.Lookup("verbose"))
+	assertEqual(t, 'H', network.Lookup("host").Shorthand)
+	assertEqual(t, true, network.Changed("port"))
+	assertEqual(t, false, network.Changed("host"))
+
+	// values are shared, the changed state is not
+	assertNoErr(t, network.Parse([]string{"-H", "example.com"}))
+	assertEqual(t, "example.com", *host)
+	assertEqual(t, true, network.Changed("host"))
+	assertEqual(t, false, f.Changed("host"))
+	assertNoErr(t, f.Set("port", "9090"))
+	assertEqual(t, 9090, network.MustGetInt("port"))
+	assertEqual(t, 9090, *port)
+
+	changed := f.Filter(func(flag *zflag.Flag) bool { return flag.Changed })
+	names := make([]string, 0)
+	changed.Visit(func(flag *zflag.Flag) {
+		names = append(names, flag.Name)
+	})
+	assertDeepEqual(t, []string{"port", "verbose"}, names)
+	assertDeepEqual(t, []string{"--port=9090", "--verbose=true"}, changed.ToArgs())
+}
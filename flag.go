@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
+	"runtime/debug"
 	"sort"
 	"strconv"
 	"strings"
@@ -19,6 +21,18 @@ import (
 // ErrHelp is the error returned if the flag -help is invoked but no such flag is defined.
 var ErrHelp = errors.New("zflag: help requested")
 
+// ErrVersion is the error returned if the flag -version is invoked but no such
+// flag is defined, and a version was set using SetVersion or SetVersionFunc.
+var ErrVersion = errors.New("zflag: version requested")
+
+// ErrInvalidUTF8 is the error wrapped when a flag name or value is not valid
+// UTF-8 and StrictUTF8 is enabled.
+var ErrInvalidUTF8 = errors.New("invalid UTF-8")
+
+// ErrEmptyValue is the error wrapped when an empty value is passed to a flag
+// defined with OptNonEmpty.
+var ErrEmptyValue = errors.New("value must not be empty")
+
 // ErrorHandling defines how to handle flag parsing errors.
 type ErrorHandling int
 
@@ -62,6 +76,59 @@ type FlagSet struct {
 	// DisableBuiltinHelp toggles the built-in convention of handling -h and --help
 	DisableBuiltinHelp bool
 
+	// DisableSuggestions toggles suggesting the nearest flag when an unknown
+	// flag or shorthand is passed.
+	DisableSuggestions bool
+
+	// ShowDeprecated toggles listing deprecated flags in usage output, which
+	// are otherwise hidden, along with deprecated shorthands. Both are
+	// annotated with their deprecation message.
+	ShowDeprecated bool
+
+	// ConsumedValueCheck configures what happens when a flag consumes a value
+	// that is also the name of a flag, which is likely a missing value.
+	ConsumedValueCheck ConsumedValueCheck
+
+	// AllowAbbreviations accepts unambiguous prefixes of long flag names,
+	// e.g. --verb for --verbose, like GNU getopt_long. A prefix matching
+	// several flags is rejected with an AmbiguousFlagError.
+	AllowAbbreviations bool
+
+	// AllowSlashFlags accepts Windows-style flags, /name and /name:value,
+	// next to dashed flags. Only arguments naming a defined flag, or /? for
+	// help, are treated as flags, so paths remain positional arguments.
+	AllowSlashFlags bool
+
+	// SingleDashLongFlags treats arguments like -name and -name=value as long
+	// flags rather than clusters of shorthands, like the standard library
+	// flag package does. Single characters, e.g. -v, are still shorthands.
+	SingleDashLongFlags bool
+
+	// NegativeNumbersAsArgs treats arguments that are negative numbers, e.g.
+	// -1 or -2.5, as positional arguments, unless their first digit is a
+	// defined shorthand.
+	NegativeNumbersAsArgs bool
+
+	// NegativePrefix is the prefix of the negated form of flags with
+	// AddNegative set, e.g. "disable-" for --disable-color. It defaults to
+	// "no-".
+	NegativePrefix string
+
+	// RegisterNegativeFlags registers the negated form of each negatable flag
+	// defined afterwards as a hidden flag, so it can be looked up and is
+	// visited like other flags, e.g. by shell completions. Setting the hidden
+	// flag sets the original flag instead.
+	RegisterNegativeFlags bool
+
+	// StrictUTF8 rejects flag names and values that are not valid UTF-8,
+	// for programs passing them on to systems that assume valid strings.
+	StrictUTF8 bool
+
+	// CacheUsage caches the usage information rendered for each group and
+	// width, for programs printing the usage repeatedly. See
+	// InvalidateUsageCache.
+	CacheUsage bool
+
 	// FlagUsageFormatter allows for custom formatting of flag usage output.
 	// Each individual item needs to be implemented. See FlagUsagesForGroupWrapped for info on what gets passed.
 	FlagUsageFormatter FlagUsageFormatter
@@ -72,9 +139,13 @@ type FlagSet struct {
 	orderedActual     []*Flag
 	sortedActual      []*Flag
 	formal            map[NormalizedName]*Flag
+	aliases           map[NormalizedName]*Flag
+	deprecatedAliases map[NormalizedName]string
 	orderedFormal     []*Flag
 	sortedFormal      []*Flag
 	shorthands        map[rune]*Flag
+	negShorthands     map[rune]*Flag // negShorthands maps the shorthands of negated forms, see OptNegativeShorthand
+	atomicShorthands  map[string]*Flag
 	args              []string // arguments after flags
 	argsLenAtDash     int      // len(args) when a '--' was located when parsing, or -1 if no --
 	errorHandling     ErrorHandling
@@ -84,6 +155,27 @@ type FlagSet struct {
 
 	addedGoFlagSets []*goflag.FlagSet
 	unknownFlags    []string
+
+	groupOrder        []string
+	groupDescriptions map[string]string
+	exclusiveFlags    []exclusiveFlags
+	typeRegistry      map[reflect.Type]*registeredType
+	helpGroup         string // helpGroup is the group requested with --help=<group>
+	versionFunc       func()
+	argRewriters      []ArgRewriter
+	preParseHooks     []func(args []string) ([]string, error)
+	postParseHooks    []func(fs *FlagSet) error
+	expandFunc        func(string) string
+	states            []*flagSetState
+	deferredDefines   []func(fs *FlagSet)
+	schemaVersion     int
+	stateMigration    StateMigration
+	parent            *FlagSet
+	completionMode    bool
+	envPrefix         string
+	pathBase          string
+	usageCache        map[usageCacheKey]string
+	argsUsage         string
 }
 
 // A Flag represents the state of a flag.
@@ -97,6 +189,9 @@ type Flag struct {
 	DisablePrintDefault bool                // DisablePrintDefault toggles printing of the default value in usage message.
 	Value               Value               // Value of the value as set.
 	AddNegative         bool                // AddNegative automatically add a --no-<flag> option for boolean flags.
+	NegativeShorthand   rune                // NegativeShorthand is a one-letter abbreviation of the negated form of the flag.
+	AtomicShorthand     string              // AtomicShorthand is a multi-letter shorthand that is not split into single-letter shorthands, see OptAtomicShorthand.
+	Greedy              bool                // Greedy makes a slice flag consume the following arguments up to the next flag, see OptGreedy.
 	DefValue            string              // DefValue should contain the default value (as text); for usage message.
 	Changed             bool                // Changed contains whether the user set the value (or if left to default).
 	Deprecated          string              // Deprecated is a string printed for a deprecation notice.
@@ -105,7 +200,46 @@ type Flag struct {
 	ShorthandDeprecated string              // ShorthandDeprecated is a string printed for a deprecation notice of the Shorthand.
 	Group               string              // Group contains the flag group.
 	Annotations         map[string][]string // Annotations are used to annotate this specific flag for your application; e.g. it is used by zulu.Command bash completion code.
-}
+	EnvVar              string              // EnvVar is the environment variable the flag is read from if not set on the command line.
+	ConfigKey           string              // ConfigKey is the key of the flag in config files.
+	Secret              bool                // Secret masks the value of the flag in usage, exports and error messages.
+	ValueFromFile       bool                // ValueFromFile reads the value from a file if it is passed as @path.
+	Dynamic             bool                // Dynamic marks the flag as safe to change while the program is running.
+	Source              string              // Source is where the value was last set from, see SourceArgs and friends, or empty if never set.
+	NoArgDefault        string              // NoArgDefault is the value used if the flag is present without a value, e.g. --flag instead of --flag=value.
+	Raw                 bool                // Raw passes values to Value.Set unmodified, without expansion, trimming or UTF-8 validation.
+	NonEmpty            bool                // NonEmpty rejects empty or whitespace-only values from any source.
+	Path                bool                // Path normalizes the separators of the value and resolves it against the path base, see OptPath.
+	DefaultText         string              // DefaultText is shown as the default value in usage messages instead of DefValue.
+	Aliases             []string            // Aliases are additional long names of the flag, which are not shown in usage messages.
+	Requires            []string            // Requires lists the flags that must be set if this flag is set.
+	ConflictsWith       []string            // ConflictsWith lists the flags that must not be set if this flag is set.
+
+	setHooks    []func() error               // setHooks are called after the value of the flag was set successfully, an error undoes the Set.
+	visibleWhen func(*FlagSet) bool          // visibleWhen hides the flag from usage output when it returns false.
+	defaultFunc func() string                // defaultFunc computes the default value at parse time, see OptDefaultFunc.
+	implies     []implication                // implies lists the values applied to other flags when the flag is set, see OptImplies.
+	requiredIf  func(*FlagSet) bool          // requiredIf makes the flag required when it returns true, see OptRequiredIf.
+	requiredOn  *requiredIfCondition         // requiredOn is the condition of OptRequiredIfFlagSet, which is described in the help JSON.
+	onChange    []func(old, new interface{}) // onChange is called after the value of the flag was set, see OptOnChange.
+	used        bool                         // used is set once the value was read, see UnusedFlags.
+	envDerived  bool                         // envDerived is set if EnvVar was derived from the env prefix, see SetEnvPrefix.
+	negative    *Flag                        // negative is the hidden flag registered for the negated form, see RegisterNegativeFlags.
+	negates     *Flag                        // negates is the flag this hidden flag is the negated form of.
+	normalizer  *sliceNormalizer             // normalizer keeps the elements of slice flags unique or sorted, see OptUnique.
+}
+
+// Sources of flag values, as recorded in Flag.Source.
+const (
+	// SourceArgs is used for values set from the arguments passed to Parse.
+	SourceArgs = "args"
+	// SourceEnv is used for values set from an environment variable.
+	SourceEnv = "env"
+	// SourceSet is used for values set by calling FlagSet.Set directly.
+	SourceSet = "set"
+	// SourceImplied is used for values implied by another flag, see OptImplies.
+	SourceImplied = "implied"
+)
 
 // Value is the interface to the dynamic value stored in a flag.
 // (The default value is represented as a string.)
@@ -136,6 +270,15 @@ type SliceValue interface {
 	GetSlice() []string
 }
 
+// MapValue is a secondary interface to all flags which hold a map of values,
+// allowing full control over the value of map flags, like SliceValue.
+type MapValue interface {
+	// ReplaceMap will fully overwrite any data currently in the flag value map.
+	ReplaceMap(map[string]string) error
+	// GetMap returns the flag value map with its values as strings.
+	GetMap() map[string]string
+}
+
 // BoolFlag is an optional interface to indicate boolean flags that can be
 // supplied without a value text
 type BoolFlag interface {
@@ -148,6 +291,49 @@ type OptionalValue interface {
 	IsOptional() bool
 }
 
+// NegatableValue is implemented by values that are not boolean, but accept a
+// --no-<name> form if the flag has AddNegative set, e.g. to disable a single
+// element of a slice. Negate returns the value passed to Set for the value
+// of the negated form, and reports errors the same way as Set, e.g. wrapped
+// in an ElementError.
+type NegatableValue interface {
+	Value
+	Negate(val string) (string, error)
+}
+
+// isNegatable reports whether the flag accepts the --no-<name> form.
+func isNegatable(flag *Flag) bool {
+	if !flag.AddNegative {
+		return false
+	}
+	_, isBoolFlag := flag.Value.(BoolFlag)
+	_, isNegatableValue := flag.Value.(NegatableValue)
+	return isBoolFlag || isNegatableValue
+}
+
+// negativePrefix returns the prefix of the negated form of flags, see
+// NegativePrefix.
+func (fs *FlagSet) negativePrefix() string {
+	if fs.NegativePrefix == "" {
+		return "no-"
+	}
+	return fs.NegativePrefix
+}
+
+// lookupNegated returns the negatable flag name is the negated form of, or nil
+// if name is not a negated form.
+func (fs *FlagSet) lookupNegated(name string) *Flag {
+	prefix := fs.negativePrefix()
+	if len(name) <= len(prefix) || !strings.HasPrefix(name, prefix) {
+		return nil
+	}
+	flag := fs.lookup(fs.normalizeFlagName(name[len(prefix):]))
+	if flag == nil || !isNegatable(flag) {
+		return nil
+	}
+	return flag
+}
+
 // sortFlags returns the flags as a slice in lexicographical sorted order.
 func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 	list := make(sort.StringSlice, len(flags))
@@ -172,6 +358,7 @@ func sortFlags(flags map[NormalizedName]*Flag) []*Flag {
 func (fs *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedName) {
 	fs.normalizeNameFunc = n
 	fs.sortedFormal = fs.sortedFormal[:0]
+	fs.InvalidateUsageCache()
 	for fname, flag := range fs.formal {
 		nname := fs.normalizeFlagName(flag.Name)
 		if fname == nname {
@@ -185,6 +372,14 @@ func (fs *FlagSet) SetNormalizeFunc(n func(f *FlagSet, name string) NormalizedNa
 			fs.actual[nname] = flag
 		}
 	}
+	if len(fs.aliases) > 0 {
+		fs.aliases = make(map[NormalizedName]*Flag)
+		for _, flag := range fs.formal {
+			for _, alias := range flag.Aliases {
+				fs.aliases[fs.normalizeFlagName(alias)] = flag
+			}
+		}
+	}
 }
 
 // GetNormalizeFunc returns the previously set NormalizeFunc of a function which
@@ -346,8 +541,16 @@ func Get(name string) (interface{}, error) {
 }
 
 // Lookup returns the Flag structure of the named flag, returning nil if none exists.
+// Flags that are not defined in the FlagSet are looked up in its parent, see
+// SetParent.
 func (fs *FlagSet) Lookup(name string) *Flag {
-	return fs.lookup(fs.normalizeFlagName(name))
+	if flag := fs.lookup(fs.normalizeFlagName(name)); flag != nil {
+		return flag
+	}
+	if fs.parent != nil {
+		return fs.parent.Lookup(name)
+	}
+	return nil
 }
 
 // ShorthandLookup returns the Flag structure of the shorthand flag,
@@ -390,7 +593,10 @@ func shorthandStrToRune(name string) (rune, error) {
 
 // lookup returns the Flag structure of the named flag, returning nil if none exists.
 func (fs *FlagSet) lookup(name NormalizedName) *Flag {
-	return fs.formal[name]
+	if flag, ok := fs.formal[name]; ok {
+		return flag
+	}
+	return fs.aliases[name]
 }
 
 // getFlagValue returns the value of a flag based on the requested name and type.
@@ -399,6 +605,7 @@ func (fs *FlagSet) getFlagValue(name string, fType string) (interface{}, error)
 	if flag == nil {
 		return nil, NewUnknownFlagError(name)
 	}
+	flag.used = true
 
 	if v, isTyped := flag.Value.(Typed); isTyped && fType != "" && v.Type() != fType {
 		return nil, fmt.Errorf("trying to get %q value of flag of type %q", fType, v.Type())
@@ -439,13 +646,48 @@ func ShorthandLookupStr(name string) *Flag {
 
 // Set sets the value of the named flag.
 func (fs *FlagSet) Set(name, value string) error {
-	normalName := fs.normalizeFlagName(name)
-	flag, ok := fs.formal[normalName]
-	if !ok {
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	if flag == nil {
 		return NewUnknownFlagError(name)
 	}
+	normalName := NormalizedName(flag.Name)
+
+	if fs.expandFunc != nil && !flag.Raw {
+		value = os.Expand(value, fs.expandFunc)
+	}
+
+	if flag.ValueFromFile {
+		var err error
+		if value, err = readValueFromFile(value, !flag.Raw); err != nil {
+			return NewInvalidArgumentError(err, flag, value)
+		}
+	}
 
-	err := flag.Value.Set(value)
+	if flag.Path {
+		value = fs.normalizePath(value)
+	}
+
+	if flag.NonEmpty && strings.TrimSpace(value) == "" {
+		return NewInvalidArgumentError(ErrEmptyValue, flag, value)
+	}
+
+	if fs.StrictUTF8 && !flag.Raw && !utf8.ValidString(value) {
+		return NewInvalidArgumentError(ErrInvalidUTF8, flag, value)
+	}
+
+	var old interface{}
+	if len(flag.onChange) > 0 {
+		old = snapshotValue(flag.Value)
+	}
+	if flag.normalizer != nil {
+		flag.normalizer.snapshot(flag.Changed)
+	}
+	var saved flagState
+	if len(flag.setHooks) > 0 {
+		saved = saveFlagState(flag)
+	}
+
+	err := fs.setValue(flag, value)
 	if err != nil {
 		return NewInvalidArgumentError(err, flag, value)
 	}
@@ -459,13 +701,42 @@ func (fs *FlagSet) Set(name, value string) error {
 
 		flag.Changed = true
 	}
+	flag.Source = SourceSet
+
+	for _, hook := range flag.setHooks {
+		if err := hook(); err != nil {
+			fs.undoSet(saved)
+			return NewInvalidArgumentError(err, flag, value)
+		}
+	}
+
+	if len(flag.onChange) > 0 {
+		current := snapshotValue(flag.Value)
+		for _, fn := range flag.onChange {
+			fn(old, current)
+		}
+	}
 
 	if flag.Deprecated != "" {
-		fmt.Fprintf(fs.Output(), "Flag --%s has been deprecated, %s\n", flag.Name, flag.Deprecated)
+		fs.warnf("Flag --%s has been deprecated, %s\n", flag.Name, flag.Deprecated)
 	}
 	return nil
 }
 
+// setValue passes value to the Set method of the flag value. An empty value
+// sets a slice flag to no elements instead, replacing the default, as ToArgs
+// represents slice flags set to no elements that way.
+func (fs *FlagSet) setValue(flag *Flag, value string) error {
+	sv, isSlice := flag.Value.(SliceValue)
+	if _, optional := flag.Value.(OptionalValue); value != "" || !isSlice || optional {
+		return flag.Value.Set(value)
+	}
+	if flag.Changed {
+		return nil
+	}
+	return sv.Replace([]string{})
+}
+
 // SetAnnotation allows one to set arbitrary annotations on this flag.
 // This is sometimes used by zulucmd/zulu programs which want to generate additional
 // bash completion information.
@@ -485,6 +756,7 @@ func (fs *FlagSet) Changed(name string) bool {
 	if flag == nil {
 		return false
 	}
+	flag.used = true
 	return flag.Changed
 }
 
@@ -496,9 +768,35 @@ func Set(name, value string) error {
 // PrintDefaults prints to standard error unless configured otherwise, the
 // default values of all defined command-line flags in the set. See the
 // documentation for the global function PrintDefaults for more information.
+// Flags that belong to a group are printed under a heading per group, in the
+// order of Groups, so the ungrouped flags come first unless SetGroupOrder
+// positions them elsewhere. If a group was requested using --help=<group>, only
+// the flags of that group are printed.
 func (fs *FlagSet) PrintDefaults() {
-	usages := fs.FlagUsages()
-	fmt.Fprint(fs.Output(), usages)
+	if fs.helpGroup != "" {
+		fmt.Fprint(fs.Output(), fs.groupHeading(fs.helpGroup), fs.FlagUsagesForGroup(fs.helpGroup))
+		return
+	}
+
+	printed := false
+	for _, group := range fs.Groups() {
+		groupUsages := fs.FlagUsagesForGroup(group)
+		switch {
+		case groupUsages == "":
+			continue
+		case group != "":
+			fmt.Fprint(fs.Output(), "\n", fs.groupHeading(group), groupUsages)
+		case printed:
+			fmt.Fprint(fs.Output(), "\n", groupUsages)
+		default:
+			fmt.Fprint(fs.Output(), groupUsages)
+		}
+		printed = true
+	}
+
+	if inherited := fs.InheritedFlagUsages(); inherited != "" {
+		fmt.Fprint(fs.Output(), "\nInherited Flags:\n", inherited)
+	}
 }
 
 // DefaultIsZeroValue returns true if the default value for this flag represents
@@ -549,9 +847,9 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 				name = ""
 			case "boolSlice":
 				name = "bools"
-			case "complex128":
+			case "complex64", "complex128":
 				name = "complex"
-			case "complex128Slice":
+			case "complex64Slice", "complex128Slice":
 				name = "complexes"
 			case "durationSlice":
 				name = "durations"
@@ -563,7 +861,13 @@ func UnquoteUsage(flag *Flag) (name string, usage string) {
 				name = "int"
 			case "intSlice", "int8Slice", "int16Slice", "int32Slice", "int64Slice":
 				name = "ints"
-			case "stringSlice":
+			case "bitmask":
+				if choices, ok := flag.Value.(ChoicesValue); ok {
+					name = strings.Join(choices.Choices(), "|")
+				}
+			case "logLevel":
+				name = "debug|info|warn|error"
+			case "stringSlice", "toggleSlice":
 				name = "strings"
 			case "uint8", "uint16", "uint32", "uint64":
 				name = "uint"
@@ -598,19 +902,20 @@ func unquoteBacktickFromUsage(name string, usage string) (string, string) {
 }
 
 // Splits the string `s` on whitespace into an initial substring up to
-// `i` runes in length and the remainder. Will go `slop` over `i` if
+// `i` columns in width and the remainder. Will go `slop` over `i` if
 // that encompasses the entire string (which allows the caller to
 // avoid short orphan words on the final line).
 func wrapN(i, slop int, s string) (string, string) {
-	if i+slop > len(s) {
+	if i+slop > displayWidth(s) {
 		return s, ""
 	}
 
-	w := strings.LastIndexAny(s[:i], " \t\n")
+	end := widthIndex(s, i)
+	w := strings.LastIndexAny(s[:end], " \t\n")
 	if w <= 0 {
 		return s, ""
 	}
-	nlPos := strings.LastIndex(s[:i], "\n")
+	nlPos := strings.LastIndex(s[:end], "\n")
 	if nlPos > 0 && nlPos < w {
 		return s[:nlPos], s[nlPos+1:]
 	}
@@ -670,7 +975,28 @@ func (fs *FlagSet) flagUsageFormatter() FlagUsageFormatter {
 		return fs.FlagUsageFormatter
 	}
 
-	return defaultUsageFormatter
+	negativePrefix := fs.negativePrefix()
+	if fs.ShowDeprecated {
+		return func(flag *Flag) (string, string) {
+			left, right := formatFlagUsage(flag, negativePrefix)
+			if flag.Shorthand != 0 && flag.ShorthandDeprecated != "" {
+				right += fmt.Sprintf(" (DEPRECATED shorthand -%c: %s)", flag.Shorthand, flag.ShorthandDeprecated)
+			}
+			return left, right
+		}
+	}
+
+	return func(flag *Flag) (string, string) {
+		return formatFlagUsage(flag, negativePrefix)
+	}
+}
+
+// isUsageHidden returns whether the flag should be left out of usage output.
+func (fs *FlagSet) isUsageHidden(flag *Flag) bool {
+	if flag.visibleWhen != nil && !flag.visibleWhen(fs) {
+		return true
+	}
+	return flag.Hidden && !(fs.ShowDeprecated && flag.Deprecated != "")
 }
 
 // FlagUsagesWrapped returns a string containing the usage information
@@ -684,41 +1010,53 @@ func (fs *FlagSet) FlagUsagesWrapped(cols int) string {
 // for all flags in the FlagSet for a group. Wrapped to `cols` columns (0 for no
 // wrapping).
 func (fs *FlagSet) FlagUsagesForGroupWrapped(group string, cols int) string {
+	return fs.cachedFlagUsages(group, cols, func() string {
+		var flags []*Flag
+		fs.VisitAll(func(flag *Flag) {
+			flags = append(flags, flag)
+		})
+		return fs.flagUsagesWrapped(flags, func(flag *Flag) bool { return flag.Group == group }, cols)
+	})
+}
+
+// flagUsagesWrapped returns the usage information of the flags for which
+// include returns true. The columns are aligned across all flags, so that
+// the sections of different groups line up.
+func (fs *FlagSet) flagUsagesWrapped(flags []*Flag, include func(flag *Flag) bool, cols int) string {
 	usageFormatter := fs.flagUsageFormatter()
 
 	var (
 		max, maxlen int
-		lines       = make(map[string][]string)
+		lines       []string
 	)
-	fs.VisitAll(func(flag *Flag) {
-		if flag.Hidden {
-			return
+	for _, flag := range flags {
+		if fs.isUsageHidden(flag) {
+			continue
 		}
 
 		line, right := usageFormatter(flag)
 
 		// This special character will be replaced with spacing once the
-		// correct alignment is calculated
-		line += "\x00"
-		if len(line) > maxlen {
-			maxlen = len(line)
+		// correct alignment is calculated. The column width is measured in
+		// terminal columns rather than bytes, to align non-ASCII text.
+		if width := displayWidth(line) + 1; width > maxlen {
+			maxlen = width
 		}
+		line += "\x00"
 
 		line += right
 
-		groupName := flag.Group
-		if _, ok := lines[groupName]; !ok {
-			lines[groupName] = make([]string, 0)
+		if include(flag) {
+			lines = append(lines, line)
+			max += len(line)
 		}
-		lines[groupName] = append(lines[groupName], line)
-		max += len(line) + len(groupName)
-	})
+	}
 
 	buf := new(bytes.Buffer)
 	buf.Grow(max)
-	for _, line := range lines[group] {
+	for _, line := range lines {
 		sidx := strings.Index(line, "\x00")
-		spacing := strings.Repeat(" ", maxlen-sidx)
+		spacing := strings.Repeat(" ", maxlen-displayWidth(line[:sidx]))
 		// maxlen + 2 comes from + 1 for the \x00 and + 1 for the (deliberate) off-by-one in maxlen-sidx
 		fmt.Fprintln(buf, line[:sidx], spacing, wrap(maxlen+2, cols, line[sidx+1:]))
 	}
@@ -738,31 +1076,6 @@ func (fs *FlagSet) FlagUsagesForGroup(group string) string {
 	return fs.FlagUsagesForGroupWrapped(group, 0)
 }
 
-// Groups return an array of unique flag groups sorted in the same order
-// as flags. Empty group (unassigned) is always placed at the beginning.
-func (fs *FlagSet) Groups() []string {
-	groupsMap := make(map[string]bool)
-	groups := make([]string, 0)
-	hasUngrouped := false
-	fs.VisitAll(func(flag *Flag) {
-		if flag.Group == "" {
-			hasUngrouped = true
-			return
-		}
-		if _, ok := groupsMap[flag.Group]; !ok {
-			groupsMap[flag.Group] = true
-			groups = append(groups, flag.Group)
-		}
-	})
-	sort.Strings(groups)
-
-	if hasUngrouped {
-		groups = append([]string{""}, groups...)
-	}
-
-	return groups
-}
-
 // PrintDefaults prints, to standard error unless configured otherwise,
 // a usage message showing the default settings of all defined
 // command-line flags.
@@ -875,11 +1188,8 @@ func (fs *FlagSet) Var(value Value, name, usage string, opts ...Opt) *Flag {
 func (fs *FlagSet) AddFlag(flag *Flag) {
 	normalizedFlagName := fs.normalizeFlagName(flag.Name)
 
-	_, alreadyThere := fs.formal[normalizedFlagName]
-	if alreadyThere {
-		msg := fmt.Sprintf("%s flag redefined: %s", fs.name, flag.Name)
-		fmt.Fprintln(fs.Output(), msg)
-		panic(msg) // Happens only if flags are declared with identical names
+	if fs.lookup(normalizedFlagName) != nil {
+		fs.panicRedefined(flag.Name)
 	}
 	if fs.formal == nil {
 		fs.formal = make(map[NormalizedName]*Flag)
@@ -887,7 +1197,15 @@ func (fs *FlagSet) AddFlag(flag *Flag) {
 
 	flag.Name = string(normalizedFlagName)
 	fs.formal[normalizedFlagName] = flag
+	fs.InvalidateUsageCache()
 	fs.orderedFormal = append(fs.orderedFormal, flag)
+	fs.deriveEnvVar(flag)
+	fs.addAliases(flag)
+	if fs.RegisterNegativeFlags && flag.negates == nil && isNegatable(flag) {
+		fs.registerNegativeFlag(flag)
+	}
+	fs.addNegativeShorthand(flag)
+	fs.addAtomicShorthand(flag)
 
 	if flag.Shorthand == 0 {
 		return
@@ -896,6 +1214,9 @@ func (fs *FlagSet) AddFlag(flag *Flag) {
 		fs.shorthands = make(map[rune]*Flag)
 	}
 	used, alreadyThere := fs.shorthands[flag.Shorthand]
+	if !alreadyThere {
+		used, alreadyThere = fs.negShorthands[flag.Shorthand]
+	}
 	if alreadyThere {
 		msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", flag.Shorthand, fs.name, used.Name)
 		fmt.Fprintln(fs.Output(), msg)
@@ -904,12 +1225,41 @@ func (fs *FlagSet) AddFlag(flag *Flag) {
 	fs.shorthands[flag.Shorthand] = flag
 }
 
+// panicRedefined panics as the flag name is already defined.
+func (fs *FlagSet) panicRedefined(name string) {
+	msg := fmt.Sprintf("%s flag redefined: %s", fs.name, name)
+	fmt.Fprintln(fs.Output(), msg)
+	panic(msg) // Happens only if flags are declared with identical names
+}
+
+// addAliases registers the aliases of flag, which must not be used by any
+// other flag.
+func (fs *FlagSet) addAliases(flag *Flag) {
+	for _, alias := range flag.Aliases {
+		name := fs.normalizeFlagName(alias)
+		if fs.lookup(name) != nil {
+			fs.panicRedefined(alias)
+		}
+		if fs.aliases == nil {
+			fs.aliases = make(map[NormalizedName]*Flag)
+		}
+		fs.aliases[name] = flag
+	}
+}
+
 // RemoveFlag will remove the flag from the FlagSet
 func (fs *FlagSet) RemoveFlag(name string) {
 	normalizedFlagName := fs.normalizeFlagName(name)
-	_, exists := fs.formal[normalizedFlagName]
+	flag, exists := fs.formal[normalizedFlagName]
 	if exists {
 		delete(fs.formal, normalizedFlagName)
+		for _, alias := range flag.Aliases {
+			delete(fs.aliases, fs.normalizeFlagName(alias))
+		}
+		if flag.negative != nil {
+			fs.RemoveFlag(flag.negative.Name)
+		}
+		fs.InvalidateUsageCache()
 	}
 }
 
@@ -920,7 +1270,7 @@ func (fs *FlagSet) AddFlagSet(newSet *FlagSet) {
 		return
 	}
 	newSet.VisitAll(func(flag *Flag) {
-		if fs.Lookup(flag.Name) == nil {
+		if fs.lookup(fs.normalizeFlagName(flag.Name)) == nil {
 			fs.AddFlag(flag)
 		}
 	})
@@ -937,19 +1287,31 @@ func Var(value Value, name, usage string, opts ...Opt) *Flag {
 }
 
 // failf prints to standard error a formatted error and usage message and
-// returns the error.
+// returns the error, as a UserInputError unless it is already categorized.
+// Nothing is printed in completion mode.
 func (fs *FlagSet) failf(format string, a ...interface{}) error {
-	fs.usage()
 	err := fmt.Errorf(format, a...)
+	if errors.Is(err, errStopIteration) {
+		return err
+	}
+	if !isCategorized(err) {
+		err = UserInputError{Err: err}
+	}
+	if fs.completionMode {
+		return err
+	}
+	fs.usage()
 	fmt.Fprintln(fs.Output())
 	fmt.Fprintln(fs.Output(), err)
 	return err
 }
 
 // usage calls the Usage method for the flag set, or the usage function if
-// the flag set is CommandLine.
+// the flag set is CommandLine. Nothing is printed in completion mode.
 func (fs *FlagSet) usage() {
 	switch {
+	case fs.completionMode:
+		return
 	case fs == CommandLine:
 		Usage()
 	case fs.Usage == nil:
@@ -983,36 +1345,75 @@ func (fs *FlagSet) stripUnknownFlagValue(args []string) []string {
 }
 
 //nolint:funlen
-func (fs *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs []string, err error) {
+func (fs *FlagSet) parseLongArg(s string, index int, args []string, fn parseFunc) (outArgs []string, err error) {
 	outArgs = args
 	name := s[2:]
-	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
+	if len(name) == 0 || name[0] == '-' {
 		err = fs.failf("bad flag syntax: %s", s)
 		return
 	}
 
-	hasNoPrefix := strings.HasPrefix(name, "no-")
 	split := strings.SplitN(name, "=", 2)
 	name = split[0]
-	flag, exists := fs.formal[fs.normalizeFlagName(name)]
-
-	if !exists && len(name) > 3 && hasNoPrefix {
-		bFlag, bExists := fs.formal[fs.normalizeFlagName(name[3:])]
-		if bExists && bFlag.AddNegative {
-			if _, isBoolFlag := bFlag.Value.(BoolFlag); isBoolFlag {
-				flag = bFlag
-				exists = bExists
-				name = name[3:]
-			}
+	if err = fs.checkLongArgSyntax(s, index, split); err != nil {
+		err = fs.failf("%w", err)
+		return
+	}
+	if fs.StrictUTF8 && !utf8.ValidString(name) {
+		err = fs.failf("bad flag syntax: %q: %w", s, ErrInvalidUTF8)
+		return
+	}
+	flag := fs.lookup(fs.normalizeFlagName(name))
+	exists := flag != nil
+
+	negated := false
+	if flag != nil && flag.negates != nil {
+		flag = flag.negates
+		name = flag.Name
+		negated = true
+	} else if !exists {
+		if bFlag := fs.lookupNegated(name); bFlag != nil {
+			flag = bFlag
+			exists = true
+			name = name[len(fs.negativePrefix()):]
+			negated = true
+		}
+	}
+
+	if !exists && fs.AllowAbbreviations && !fs.isBuiltinName(name) {
+		if flag, negated, err = fs.lookupAbbreviation(name); err != nil {
+			err = fs.failf("%w", err)
+			return
+		}
+		if exists = flag != nil; exists {
+			name = flag.Name
 		}
 	}
 
 	if !exists || (flag != nil && flag.ShorthandOnly) {
 		switch {
 		case !exists && name == "help" && !fs.DisableBuiltinHelp:
+			if len(split) == 2 && split[1] == "json" {
+				if err = fs.WriteHelpJSON(fs.helpJSONOutput()); err == nil {
+					err = ErrHelp
+				}
+				return
+			}
+			if len(split) == 2 {
+				group, ok := fs.findGroup(split[1])
+				if !ok {
+					err = fs.failf("unknown help group: %s", split[1])
+					return
+				}
+				fs.helpGroup = group
+			}
 			fs.usage()
 			err = ErrHelp
 			return
+		case !exists && name == "version" && fs.versionFunc != nil:
+			fs.versionFunc()
+			err = ErrVersion
+			return
 		case fs.ParseErrorsAllowList.UnknownFlags || (flag != nil && flag.ShorthandOnly):
 			// --unknown=unknownval arg ...
 			// we do not want to lose arg in this case
@@ -1023,11 +1424,13 @@ func (fs *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs
 			outArgs = fs.stripUnknownFlagValue(outArgs)
 			return
 		default:
-			err = fs.failf(NewUnknownFlagError(name).Error())
+			err = fs.failf("%s", UnknownFlagError{name: name, suggestion: fs.suggestFlag(name)})
 			return
 		}
 	}
 
+	fs.warnDeprecatedAlias(name)
+
 	_, flagIsBool := flag.Value.(BoolFlag)
 	_, isOptional := flag.Value.(OptionalValue)
 	nextArgIsFlagValue := len(outArgs) > 0 && len(outArgs[0]) > 0 && outArgs[0][0] != '-'
@@ -1036,27 +1439,43 @@ func (fs *FlagSet) parseLongArg(s string, args []string, fn parseFunc) (outArgs
 	switch {
 	case len(split) == 2: // '--flag=arg'
 		value = split[1]
-		if hasNoPrefix && flagIsBool {
+		if negated && flagIsBool {
 			err = fs.failf("flag cannot have a value: %s", s)
 			return
 		}
 	case flagIsBool: // '--[no-]flag' (arg was optional)
-		value = fmt.Sprintf("%t", !hasNoPrefix)
+		value = fmt.Sprintf("%t", !negated)
+	case flag.NoArgDefault != "": // '--flag' (arg defaults to NoArgDefault)
+		value = flag.NoArgDefault
 	case isOptional: // '--flag' (arg was optional)
 		value = ""
 	case nextArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(outArgs[0]))): // '--flag arg'
 		value = outArgs[0]
 		outArgs = outArgs[1:]
+		if err = fs.checkConsumedValue(flag, value); err != nil {
+			return
+		}
 	default: // '--flag' (arg was required)
 		err = fs.failf("flag needs an argument: %s", s)
 		return
 	}
 
+	if negatable, ok := flag.Value.(NegatableValue); ok && negated && !flagIsBool {
+		// '--no-flag arg'
+		negatedValue, negateErr := negatable.Negate(value)
+		if negateErr != nil {
+			err = fs.failf("%w", NewInvalidArgumentError(negateErr, flag, value))
+			return
+		}
+		value = negatedValue
+	}
+
 	err = fn(flag, value)
 	if err != nil {
-		err = fs.failf(err.Error())
+		err = fs.failf("%w", err)
+		return
 	}
-	return
+	return fs.consumeGreedy(flag, negated, outArgs, fn)
 }
 
 func isBool(v string) bool {
@@ -1065,18 +1484,30 @@ func isBool(v string) bool {
 }
 
 //nolint:funlen
-func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn parseFunc) (outShorts string, outArgs []string, err error) {
+func (fs *FlagSet) parseSingleShortArg(cluster, shorthands string, args []string, fn parseFunc) (outShorts string, outArgs []string, err error) {
 	outArgs = args
 	outShorts = shorthands[1:]
-	char, _ := utf8.DecodeRuneInString(shorthands)
+	char, size := utf8.DecodeRuneInString(shorthands)
+	if fs.StrictUTF8 && char == utf8.RuneError && size == 1 {
+		err = fs.failf("bad flag syntax: %q: %w", "-"+cluster, ErrInvalidUTF8)
+		return
+	}
 
 	flag, exists := fs.shorthands[char]
+	negated := false
+	if nFlag, ok := fs.negShorthands[char]; ok && !exists {
+		flag, exists, negated = nFlag, true, true
+	}
 	if !exists {
 		switch {
 		case char == 'h' && !fs.DisableBuiltinHelp:
 			fs.usage()
 			err = ErrHelp
 			return
+		case char == 'V' && fs.versionFunc != nil:
+			fs.versionFunc()
+			err = ErrVersion
+			return
 		case fs.ParseErrorsAllowList.UnknownFlags:
 			if len(shorthands) > 2 {
 				// '-f...'
@@ -1092,9 +1523,9 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 			return
 		default:
 			// fallback to a normal flag look up without any shorthand opts
-			flag = fs.Lookup(string(char))
+			flag = fs.lookup(fs.normalizeFlagName(string(char)))
 			if flag == nil || (flag.Shorthand > 0 && flag.Shorthand != char) {
-				err = fs.failf("unknown shorthand flag: %q in -%s", char, shorthands)
+				err = fs.failf("unknown shorthand flag: %q in -%s%s", char, shorthands, formatSuggestion(fs.suggestShorthand(cluster, char)))
 				return
 			}
 		}
@@ -1107,23 +1538,39 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 	nextShortArgIsFlagValue := len(shorthands) > 1
 	if len(shorthands) > 1 {
 		_, nextFlagExists := fs.shorthands[rune(shorthands[1])]
-		nextShortArgIsFlagValue = !nextFlagExists
+		_, nextNegFlagExists := fs.negShorthands[rune(shorthands[1])]
+		nextShortArgIsFlagValue = !nextFlagExists && !nextNegFlagExists
 	}
 
 	var value string
 	switch {
+	case negated && flagIsBool && len(shorthands) > 1 && shorthands[1] == '=':
+		err = fs.failf("flag cannot have a value: %q in -%s", char, shorthands)
+		return
+	case negated && flagIsBool:
+		// '-N' (negated bool)
+		value = "false"
 	case len(shorthands) > 2 && shorthands[1] == '=':
 		// '-f=arg'
 		value = shorthands[2:]
 		outShorts = ""
+	case flag.NoArgDefault != "":
+		// '-f' (arg defaults to NoArgDefault)
+		value = flag.NoArgDefault
 	case nextShortArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(shorthands[1:]))):
 		// '-farg'
 		value = shorthands[1:]
 		outShorts = ""
+		if err = fs.checkConsumedValue(flag, value); err != nil {
+			return
+		}
 	case nextArgIsFlagValue && (!flagIsBool || (flagIsBool && isBool(outArgs[0]))):
 		// '-f arg'
 		value = args[0]
 		outArgs = args[1:]
+		if err = fs.checkConsumedValue(flag, value); err != nil {
+			return
+		}
 	case flagIsBool, isOptional:
 		// '-f' (arg was optional)
 		value = ""
@@ -1133,13 +1580,27 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 		return
 	}
 
-	if flag.ShorthandDeprecated != "" {
-		fmt.Fprintf(fs.Output(), "Flag shorthand -%c has been deprecated, %s\n", flag.Shorthand, flag.ShorthandDeprecated)
+	if negatable, ok := flag.Value.(NegatableValue); ok && negated && !flagIsBool {
+		// '-N arg'
+		negatedValue, negateErr := negatable.Negate(value)
+		if negateErr != nil {
+			err = fs.failf("%w", NewInvalidArgumentError(negateErr, flag, value))
+			return
+		}
+		value = negatedValue
+	}
+
+	if flag.ShorthandDeprecated != "" && !negated {
+		fs.warnf("Flag shorthand -%c has been deprecated, %s\n", flag.Shorthand, flag.ShorthandDeprecated)
 	}
 
 	err = fn(flag, value)
 	if err != nil {
-		err = fs.failf(err.Error())
+		err = fs.failf("%w", err)
+		return
+	}
+	if outShorts == "" {
+		outArgs, err = fs.consumeGreedy(flag, negated, outArgs, fn)
 	}
 	return
 }
@@ -1147,10 +1608,13 @@ func (fs *FlagSet) parseSingleShortArg(shorthands string, args []string, fn pars
 func (fs *FlagSet) parseShortArg(s string, args []string, fn parseFunc) (outArgs []string, err error) {
 	outArgs = args
 	shorthands := s[1:]
+	if flag := fs.lookupAtomicShorthand(shorthands); flag != nil {
+		return fs.parseAtomicShorthandArg(s, flag, args, fn)
+	}
 
 	// "shorthands" can be a series of shorthand letters of flags (e.g. "-vvv").
 	for utf8.RuneCountInString(shorthands) > 0 {
-		shorthands, outArgs, err = fs.parseSingleShortArg(shorthands, args, fn)
+		shorthands, outArgs, err = fs.parseSingleShortArg(s[1:], shorthands, args, fn)
 		if err != nil {
 			return
 		}
@@ -1159,15 +1623,30 @@ func (fs *FlagSet) parseShortArg(s string, args []string, fn parseFunc) (outArgs
 	return
 }
 
-func (fs *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
+func (fs *FlagSet) parseArgs(args []string, fn parseFunc) error {
+	if complete, err := fs.scanArgs(args, fn); err != nil || !complete {
+		return err
+	}
+	return fs.finishParse(fn)
+}
+
+// scanArgs parses the flags in args, passing them to fn, and collects the
+// positional arguments. It reports whether all arguments were scanned, which
+// is not the case if interspersed is disabled and a positional argument was
+// found.
+func (fs *FlagSet) scanArgs(args []string, fn parseFunc) (complete bool, err error) {
+	total := len(args)
 	for len(args) > 0 {
 		s := args[0]
+		if !fs.isNegativeNumber(s) {
+			s = fs.translateArg(s)
+		}
 		args = args[1:]
-		if len(s) == 0 || s[0] != '-' || len(s) == 1 {
+		if len(s) == 0 || s[0] != '-' || len(s) == 1 || fs.isNegativeNumber(s) {
 			if !fs.interspersed {
 				fs.args = append(fs.args, s)
 				fs.args = append(fs.args, args...)
-				return nil
+				return false, nil
 			}
 			fs.args = append(fs.args, s)
 			continue
@@ -1179,7 +1658,7 @@ func (fs *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
 				fs.args = append(fs.args, args...)
 				break
 			}
-			args, err = fs.parseLongArg(s, args, fn)
+			args, err = fs.parseLongArg(s, total-len(args)-1, args, fn)
 		} else {
 			args, err = fs.parseShortArg(s, args, fn)
 		}
@@ -1187,37 +1666,82 @@ func (fs *FlagSet) parseArgs(args []string, fn parseFunc) (err error) {
 			return
 		}
 	}
+	return true, nil
+}
+
+// finishParse applies the environment variables, implications and defaults
+// to the flags not set on the command line, and validates the result.
+func (fs *FlagSet) finishParse(fn parseFunc) error {
+	if err := fs.applyEnv(fn); err != nil {
+		return err
+	}
+	if err := fs.applyImplications(); err != nil {
+		return err
+	}
+	if err := fs.applyDefaultFuncs(); err != nil {
+		return err
+	}
+	if err := fs.applyDeferredDefaults(); err != nil {
+		return err
+	}
+	return fs.validateAndRunPostParseHooks()
+}
 
-	return fs.Validate()
+// deferredDefault is implemented by values whose default can only be applied
+// once all options of the flag are known, e.g. as it opens a file.
+type deferredDefault interface {
+	applyDefault() error
+}
+
+// applyDeferredDefaults applies the defaults of the flags that were not set
+// and implement deferredDefault.
+func (fs *FlagSet) applyDeferredDefaults() error {
+	var err error
+	fs.VisitAll(func(flag *Flag) {
+		v, ok := flag.Value.(deferredDefault)
+		if err != nil || !ok || flag.Changed {
+			return
+		}
+		if applyErr := v.applyDefault(); applyErr != nil {
+			err = fs.failf("%w", NewInvalidArgumentError(applyErr, flag, flag.DefValue))
+		}
+	})
+	return err
 }
 
 var exitFn = func(code int) {
 	os.Exit(code)
 }
 
-func (fs *FlagSet) parseAll(arguments []string, fn parseFunc) error {
+// prepareParse prepares the flag set for parsing arguments, returning the
+// arguments after rewriting them and running the pre-parse hooks.
+func (fs *FlagSet) prepareParse(arguments []string) ([]string, error) {
+	fs.runDeferredDefines()
+	fs.refreshDefaultFuncs()
 	if fs.addedGoFlagSets != nil {
 		for _, goFlagSet := range fs.addedGoFlagSets {
 			if err := goFlagSet.Parse(nil); err != nil {
-				return err
+				return nil, err
 			}
 		}
 	}
 	fs.parsed = true
+	fs.helpGroup = ""
+	return fs.runPreParseHooks(fs.rewriteArgs(arguments))
+}
 
-	if len(arguments) == 0 {
-		return fs.Validate()
+func (fs *FlagSet) parseAll(arguments []string, fn parseFunc) error {
+	arguments, err := fs.prepareParse(arguments)
+	if err == nil {
+		fs.args = make([]string, 0, len(arguments))
+		err = fs.parseArgs(arguments, fn)
 	}
-
-	fs.args = make([]string, 0, len(arguments))
-
-	err := fs.parseArgs(arguments, fn)
 	if err != nil {
 		switch fs.errorHandling {
 		case ContinueOnError:
 			return err
 		case ExitOnError:
-			if err == ErrHelp {
+			if err == ErrHelp || err == ErrVersion {
 				exitFn(0)
 			}
 			exitFn(2)
@@ -1233,10 +1757,16 @@ func (fs *FlagSet) parseAll(arguments []string, fn parseFunc) error {
 // are defined and before flags are accessed by the program.
 // The return value will be ErrHelp if -help was set but not defined.
 func (fs *FlagSet) Parse(arguments []string) error {
-	set := func(flag *Flag, value string) error {
-		return fs.Set(flag.Name, value)
+	return fs.parseAll(arguments, fs.setArg)
+}
+
+// setArg sets the value of flag passed on the command line.
+func (fs *FlagSet) setArg(flag *Flag, value string) error {
+	if err := fs.Set(flag.Name, value); err != nil {
+		return err
 	}
-	return fs.parseAll(arguments, set)
+	flag.Source = SourceArgs
+	return nil
 }
 
 type parseFunc func(flag *Flag, value string) error
@@ -1262,6 +1792,36 @@ func Parse() {
 	_ = CommandLine.Parse(os.Args[1:])
 }
 
+// ParseWithError parses the command-line flags from os.Args[1:], like Parse,
+// but returns any error instead of handling it according to the error
+// handling of CommandLine. The return value will be ErrHelp or ErrVersion if
+// the help or version flag was given.
+func ParseWithError() error {
+	orig := CommandLine.errorHandling
+	CommandLine.errorHandling = ContinueOnError
+	defer func() {
+		CommandLine.errorHandling = orig
+	}()
+	return CommandLine.Parse(os.Args[1:])
+}
+
+// SafeParse parses the command-line flags from args, which should not include
+// the command name, like ParseWithError, but also recovers from panics, e.g.
+// caused by mistakes in the definition of the flags or a usage function,
+// which are returned as a PanicError. It never exits, making it suitable for
+// servers and plugins embedding zflag.
+func SafeParse(args []string) (err error) {
+	orig := CommandLine.errorHandling
+	CommandLine.errorHandling = ContinueOnError
+	defer func() {
+		CommandLine.errorHandling = orig
+		if r := recover(); r != nil {
+			err = PanicError{Value: r, Stack: debug.Stack()}
+		}
+	}()
+	return CommandLine.Parse(args)
+}
+
 // ParseAll parses the command-line flags from os.Args[1:] and called fn for each.
 // The arguments for fn are flag and value. Must be called after all flags are
 // defined and before flags are accessed by the program.
@@ -1310,20 +1870,78 @@ func (fs *FlagSet) Init(name string, errorHandling ErrorHandling) {
 	fs.argsLenAtDash = -1
 }
 
+// ErrorHandling returns the error handling behavior of the flag set.
+func (fs *FlagSet) ErrorHandling() ErrorHandling {
+	return fs.errorHandling
+}
+
+// SetErrorHandling changes the error handling behavior of the flag set.
+func (fs *FlagSet) SetErrorHandling(errorHandling ErrorHandling) {
+	fs.errorHandling = errorHandling
+}
+
+// SetErrorHandling changes the error handling behavior of the command-line
+// flags, which is ExitOnError by default.
+func SetErrorHandling(errorHandling ErrorHandling) {
+	CommandLine.SetErrorHandling(errorHandling)
+}
+
 // Validate ensures all flag values are valid.
 func (fs *FlagSet) Validate() error {
 	if !fs.ParseErrorsAllowList.RequiredFlags {
 		var missingFlagsErr MissingFlagsError
 		fs.VisitAll(func(f *Flag) {
-			if f.Required && !f.Changed {
+			if f.isRequired(fs) && !f.Changed {
 				missingFlagsErr.AddMissingFlag(f)
 			}
 		})
 
 		if len(missingFlagsErr) > 0 {
-			return missingFlagsErr
+			return ConstraintError{Err: missingFlagsErr}
+		}
+	}
+
+	for _, exclusive := range fs.exclusiveFlags {
+		if err := exclusive.validate(fs); err != nil {
+			return ConstraintError{Err: err}
+		}
+	}
+
+	for _, flag := range fs.GetFlags() {
+		if err := fs.validateDependencies(flag); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
+
+// isRequired reports whether the flag must be set, either as it is marked
+// required or as its OptRequiredIf condition holds.
+func (f *Flag) isRequired(fs *FlagSet) bool {
+	return f.Required || (f.requiredIf != nil && f.requiredIf(fs))
+}
+
+// validateDependencies checks the Requires and ConflictsWith constraints of
+// a flag that was set.
+func (fs *FlagSet) validateDependencies(flag *Flag) error {
+	for _, name := range flag.Requires {
+		other := fs.Lookup(name)
+		if other == nil {
+			return DefinitionError{Err: NewUnknownFlagError(name)}
+		}
+		if !other.Changed {
+			return ConstraintError{Err: FlagDependencyError{Flag: flag.Name, Other: other.Name}}
+		}
+	}
+	for _, name := range flag.ConflictsWith {
+		other := fs.Lookup(name)
+		if other == nil {
+			return DefinitionError{Err: NewUnknownFlagError(name)}
+		}
+		if other.Changed {
+			return ConstraintError{Err: FlagDependencyError{Flag: flag.Name, Other: other.Name, Conflict: true}}
+		}
+	}
+	return nil
+}
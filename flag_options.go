@@ -19,6 +19,7 @@ func applyFlagOptions(f *Flag, options ...Opt) error {
 }
 
 // OptAddNegative automatically adds a --no-<flag> option for boolean flags.
+// The prefix can be changed with FlagSet.NegativePrefix.
 func OptAddNegative() Opt {
 	return func(f *Flag) error {
 		f.AddNegative = true
@@ -26,6 +27,15 @@ func OptAddNegative() Opt {
 	}
 }
 
+// OptNegativeShorthand sets a one-letter abbreviation of the negated form of
+// the flag, e.g. -N for --no-color. The flag must have AddNegative set.
+func OptNegativeShorthand(shorthand rune) Opt {
+	return func(f *Flag) error {
+		f.NegativeShorthand = shorthand
+		return nil
+	}
+}
+
 // OptShorthand one-letter abbreviated flag
 func OptShorthand(shorthand rune) Opt {
 	return func(f *Flag) error {
@@ -44,6 +54,20 @@ func OptShorthandStr(shorthand string) Opt {
 	return OptShorthand(r)
 }
 
+// OptAlias adds alias as an additional long name of the flag, e.g. to accept
+// both --color and --colour. Aliases are resolved by Lookup and the parser,
+// but are not shown in usage messages. It may be repeated to add several
+// aliases.
+func OptAlias(alias string) Opt {
+	return func(f *Flag) error {
+		if alias == "" {
+			return fmt.Errorf("alias for flag %q must not be empty", f.Name)
+		}
+		f.Aliases = append(f.Aliases, alias)
+		return nil
+	}
+}
+
 // OptShorthandOnly If the user set only the shorthand
 func OptShorthandOnly() Opt {
 	return func(f *Flag) error {
@@ -84,6 +108,68 @@ func OptDisablePrintDefault() Opt {
 	}
 }
 
+// OptSecret mask the value of the flag in usage, exports and error messages.
+// The Value itself is not masked, String and Get still return the real value
+func OptSecret() Opt {
+	return func(f *Flag) error {
+		f.Secret = true
+		return nil
+	}
+}
+
+// OptValueFromFile read the value from the file at path when passed as @path, use @@ for a literal @
+func OptValueFromFile() Opt {
+	return func(f *Flag) error {
+		f.ValueFromFile = true
+		return nil
+	}
+}
+
+// OptRaw pass the value to the flag unmodified, without expansion, trimming of
+// values read from files or UTF-8 validation
+func OptRaw() Opt {
+	return func(f *Flag) error {
+		f.Raw = true
+		return nil
+	}
+}
+
+// OptNonEmpty rejects empty and whitespace-only values, e.g. --name "" or
+// --name=, whether they are passed on the command line or read from an
+// environment variable or any other source. It cannot be used with boolean
+// or optional-value flags, which receive an empty value when the value is
+// omitted.
+func OptNonEmpty() Opt {
+	return func(f *Flag) error {
+		_, isBool := f.Value.(BoolFlag)
+		_, isOptional := f.Value.(OptionalValue)
+		if isBool || isOptional {
+			return fmt.Errorf("flag %s cannot be required to be non-empty, as its value is optional", f.Name)
+		}
+		f.NonEmpty = true
+		return nil
+	}
+}
+
+// OptDynamic mark the flag as safe to change while the program is running
+func OptDynamic() Opt {
+	return func(f *Flag) error {
+		f.Dynamic = true
+		return nil
+	}
+}
+
+// OptNoArgDefault sets the value used if the flag is given without a value,
+// so --flag yields value while --flag=other yields other. As a consequence,
+// the value must be passed as --flag=other or -f=other, as the next argument
+// is never consumed.
+func OptNoArgDefault(value string) Opt {
+	return func(f *Flag) error {
+		f.NoArgDefault = value
+		return nil
+	}
+}
+
 // OptDefValue default value (as text); for usage message
 func OptDefValue(defValue string) Opt {
 	return func(f *Flag) error {
@@ -92,6 +178,16 @@ func OptDefValue(defValue string) Opt {
 	}
 }
 
+// OptDefaultText shows text as the default value in usage messages instead of
+// the actual default, e.g. "number of CPUs" for a default computed at startup.
+// Unlike OptDefValue, the default value itself is left untouched.
+func OptDefaultText(text string) Opt {
+	return func(f *Flag) error {
+		f.DefaultText = text
+		return nil
+	}
+}
+
 // OptDeprecated indicated that a flag is deprecated in your program. It will
 // continue to function but will not show up in help or usage messages. Using
 // this flag will also print the given usageMessage.
@@ -114,6 +210,17 @@ func OptHidden() Opt {
 	}
 }
 
+// OptVisibleWhen only shows the flag in usage output while visible returns
+// true. As it is evaluated when the usage is rendered, the flags parsed before
+// --help can be used to show only the relevant flags, e.g. for
+// "tool --mode=server --help".
+func OptVisibleWhen(visible func(fs *FlagSet) bool) Opt {
+	return func(f *Flag) error {
+		f.visibleWhen = visible
+		return nil
+	}
+}
+
 // OptRequired ensures that a flag must be changed
 func OptRequired() Opt {
 	return func(f *Flag) error {
@@ -122,6 +229,59 @@ func OptRequired() Opt {
 	}
 }
 
+// OptRequiredIf makes the flag required only if required returns true when
+// the FlagSet is validated, e.g. depending on the value of another flag.
+func OptRequiredIf(required func(fs *FlagSet) bool) Opt {
+	return func(f *Flag) error {
+		if required == nil {
+			return fmt.Errorf("required func for flag %q must be set", f.Name)
+		}
+		f.requiredIf = required
+		f.requiredOn = nil
+		return nil
+	}
+}
+
+// OptRequiredIfFlagSet makes the flag required only if the flag name was set
+// to value, e.g. a bucket flag that is required if --output=s3 is passed.
+func OptRequiredIfFlagSet(name, value string) Opt {
+	requiredIf := OptRequiredIf(func(fs *FlagSet) bool {
+		flag := fs.Lookup(name)
+		return flag != nil && flag.Changed && flag.Value.String() == value
+	})
+	return func(f *Flag) error {
+		if err := requiredIf(f); err != nil {
+			return err
+		}
+		f.requiredOn = &requiredIfCondition{name: name, value: value}
+		return nil
+	}
+}
+
+// requiredIfCondition is the condition of OptRequiredIfFlagSet.
+type requiredIfCondition struct {
+	name  string
+	value string
+}
+
+// OptRequires declares that the named flags must also be set if the flag is
+// set, which is checked when the FlagSet is parsed.
+func OptRequires(names ...string) Opt {
+	return func(f *Flag) error {
+		f.Requires = append(f.Requires, names...)
+		return nil
+	}
+}
+
+// OptConflictsWith declares that none of the named flags may be set if the
+// flag is set, which is checked when the FlagSet is parsed.
+func OptConflictsWith(names ...string) Opt {
+	return func(f *Flag) error {
+		f.ConflictsWith = append(f.ConflictsWith, names...)
+		return nil
+	}
+}
+
 // OptShorthandDeprecated If the shorthand of this flag is deprecated, this string is the new or now thing to use
 func OptShorthandDeprecated(msg string) Opt {
 	return func(f *Flag) error {
@@ -149,3 +309,29 @@ func OptAnnotation(key string, value []string) Opt {
 		return nil
 	}
 }
+
+// OptEnv binds the flag to the environment variable name. If the flag is not
+// set on the command line, its value is read from the environment variable
+// during parsing.
+func OptEnv(name string) Opt {
+	return func(f *Flag) error {
+		if name == "" {
+			return fmt.Errorf("environment variable for flag %q must be set", f.Name)
+		}
+
+		f.EnvVar = name
+		return nil
+	}
+}
+
+// OptConfigKey sets the key under which the flag is stored in config files.
+func OptConfigKey(key string) Opt {
+	return func(f *Flag) error {
+		if key == "" {
+			return fmt.Errorf("config key for flag %q must be set", f.Name)
+		}
+
+		f.ConfigKey = key
+		return nil
+	}
+}
@@ -4,6 +4,7 @@
 package zflag_test
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -319,6 +320,123 @@ func TestRequired(t *testing.T) {
 	}
 }
 
+func TestOptRequiredIf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name: "condition not met",
+			args: []string{"--output=file"},
+		},
+		{
+			name:          "flag value condition met",
+			args:          []string{"--output=s3"},
+			expectedError: `required flag(s) "--bucket" not set`,
+		},
+		{
+			name: "flag value condition met and set",
+			args: []string{"--output=s3", "--bucket=backups"},
+		},
+		{
+			name:          "func condition met",
+			args:          []string{"--output=file", "--compress"},
+			expectedError: `required flag(s) "--level" not set`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("output", "file", "output")
+			f.String("bucket", "", "bucket", zflag.OptRequiredIfFlagSet("output", "s3"))
+			compress := f.Bool("compress", false, "compress")
+			f.Int("level", 0, "compression level", zflag.OptRequiredIf(func(fs *zflag.FlagSet) bool {
+				return *compress
+			}))
+
+			err := f.Parse(test.args)
+			if test.expectedError == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedError, err)
+		})
+	}
+}
+
+func TestFlagDependencies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedError string
+	}{
+		{
+			name: "no flags set",
+			args: []string{},
+		},
+		{
+			name: "required flag set",
+			args: []string{"--tls-cert=cert.pem", "--tls-key=key.pem"},
+		},
+		{
+			name:          "required flag missing",
+			args:          []string{"--tls-cert=cert.pem"},
+			expectedError: `flag "--tls-cert" requires flag "--tls-key" to be set`,
+		},
+		{
+			name:          "conflicting flags set",
+			args:          []string{"--insecure", "--tls-cert=cert.pem", "--tls-key=key.pem"},
+			expectedError: `flag "--insecure" cannot be used with flag "--tls-cert"`,
+		},
+		{
+			name:          "unknown dependency",
+			args:          []string{"--debug"},
+			expectedError: `unknown flag: --trace`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("tls-cert", "", "certificate", zflag.OptRequires("tls-key"))
+			f.String("tls-key", "", "key")
+			f.Bool("insecure", false, "insecure", zflag.OptConflictsWith("tls-cert", "tls-key"))
+			f.Bool("debug", false, "debug", zflag.OptRequires("trace"))
+
+			err := f.Parse(test.args)
+			if test.expectedError == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedError, err)
+		})
+	}
+
+	var depErr zflag.FlagDependencyError
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("a", false, "a", zflag.OptConflictsWith("b"))
+	f.Bool("b", false, "b")
+	if err := f.Parse([]string{"-a", "-b"}); !errors.As(err, &depErr) {
+		t.Fatalf("expected a FlagDependencyError, got %v", err)
+	}
+	assertEqual(t, zflag.FlagDependencyError{Flag: "a", Other: "b", Conflict: true}, depErr)
+}
+
 func testParse(f *zflag.FlagSet, t *testing.T) {
 	if f.Parsed() {
 		t.Error("f.Parse() = true before Parse")
@@ -1220,6 +1338,69 @@ func TestChangingArgs(t *testing.T) {
 	}
 }
 
+func TestParseWithError(t *testing.T) {
+	fs := zflag.SetCommandLineForTest(t, zflag.NewFlagSet("cmd", zflag.ExitOnError))
+	fs.SetOutput(ioutil.Discard)
+	oldUsage := zflag.Usage
+	defer func() { zflag.Usage = oldUsage }()
+	zflag.Usage = func() {}
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	zflag.Int("int", 0, "")
+	os.Args = []string{"cmd", "--int=abc"}
+	err := zflag.ParseWithError()
+	assertErrMsg(t, `invalid argument "abc" for "--int" flag: strconv.ParseInt: parsing "abc": invalid syntax`, err)
+	assertEqual(t, zflag.ExitOnError, fs.ErrorHandling())
+
+	os.Args = []string{"cmd", "--help"}
+	assertEqual(t, zflag.ErrHelp, zflag.ParseWithError())
+}
+
+func TestSafeParse(t *testing.T) {
+	fs := zflag.SetCommandLineForTest(t, zflag.NewFlagSet("cmd", zflag.ExitOnError))
+	fs.SetOutput(ioutil.Discard)
+	oldUsage := zflag.Usage
+	defer func() { zflag.Usage = oldUsage }()
+	zflag.Usage = func() {}
+
+	zflag.Int("int", 0, "")
+	assertNoErr(t, zflag.SafeParse([]string{"--int=1"}))
+	assertEqual(t, 1, fs.MustGetInt("int"))
+
+	err := zflag.SafeParse([]string{"--int=abc"})
+	assertErrMsg(t, `invalid argument "abc" for "--int" flag: strconv.ParseInt: parsing "abc": invalid syntax`, err)
+	assertEqual(t, zflag.ExitOnError, fs.ErrorHandling())
+
+	zflag.Usage = func() { panic(errors.New("broken usage")) }
+	err = zflag.SafeParse([]string{"--help"})
+	assertErrMsg(t, "zflag: panic while parsing: broken usage", err)
+	var panicErr zflag.PanicError
+	if !errors.As(err, &panicErr) {
+		t.Fatalf("expected a PanicError, got %v", err)
+	}
+	if !strings.Contains(string(panicErr.Stack), "TestSafeParse") {
+		t.Fatalf("expected the stack to contain the test, got %s", panicErr.Stack)
+	}
+	assertEqual(t, zflag.ExitOnError, fs.ErrorHandling())
+}
+
+func TestSetErrorHandling(t *testing.T) {
+	fs := zflag.SetCommandLineForTest(t, zflag.NewFlagSet("cmd", zflag.ExitOnError))
+	fs.SetOutput(ioutil.Discard)
+	oldUsage := zflag.Usage
+	defer func() { zflag.Usage = oldUsage }()
+	zflag.Usage = func() {}
+
+	zflag.SetErrorHandling(zflag.ContinueOnError)
+	assertEqual(t, zflag.ContinueOnError, fs.ErrorHandling())
+	assertErrMsg(t, "unknown flag: --unknown", fs.Parse([]string{"--unknown"}))
+
+	zflag.SetErrorHandling(zflag.PanicOnError)
+	defer assertPanic(t)()
+	_ = fs.Parse([]string{"--unknown"})
+}
+
 // Test that -help invokes the usage message and returns ErrHelp.
 func TestHelp(t *testing.T) {
 	var helpCalled = false
@@ -1582,7 +1763,7 @@ const defaultOutput = `      --A                     for bootstrapping, allow 'a
       --customP custom        a VarP with default (default 10)
       --disableDefault int    A non-zero int with DisablePrintDefault
       --maxT timeout          set timeout for dial
-  -v, --verbose count         verbosity
+  -v, --verbose[=count]       verbosity
 `
 
 // Custom value that satisfies the Value interface.
@@ -1818,3 +1999,245 @@ func TestNoDuplicateUnknownFlagError(t *testing.T) {
 	count := strings.Count(buf.String(), substr)
 	assertEqualf(t, 1, count, "expected %q to appear in output exactly once, got %d", substr, count)
 }
+
+func TestNoArgDefault(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		args         []string
+		expected     string
+		expectedArgs []string
+	}{
+		{name: "not set", args: []string{}, expected: "off"},
+		{name: "long without value", args: []string{"--profile"}, expected: "cpu"},
+		{name: "long with value", args: []string{"--profile=mem"}, expected: "mem"},
+		{name: "long does not consume next arg", args: []string{"--profile", "mem"}, expected: "cpu", expectedArgs: []string{"mem"}},
+		{name: "short without value", args: []string{"-p"}, expected: "cpu"},
+		{name: "short with value", args: []string{"-p=mem"}, expected: "mem"},
+		{name: "short in cluster", args: []string{"-pv"}, expected: "cpu"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			profile := f.String("profile", "off", "usage", zflag.OptShorthand('p'), zflag.OptNoArgDefault("cpu"))
+			f.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+
+			assertNoErr(t, f.Parse(test.args))
+			assertEqual(t, test.expected, *profile)
+			if test.expectedArgs == nil {
+				assertEqual(t, 0, len(f.Args()))
+			} else {
+				assertDeepEqual(t, test.expectedArgs, f.Args())
+			}
+		})
+	}
+}
+
+func TestNoArgDefaultUsage(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("profile", "", "profile to record", zflag.OptNoArgDefault("cpu"))
+	f.Int("level", 1, "compression level", zflag.OptNoArgDefault("9"))
+
+	expected := `      --level int[=9]            compression level (default 1)
+      --profile string[="cpu"]   profile to record
+`
+	assertEqual(t, expected, f.FlagUsages())
+}
+
+func TestStrictUTF8(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		strict      bool
+		input       []string
+		expectedErr string
+	}{
+		{
+			name:  "valid value",
+			input: []string{"--str", "héllo"},
+		},
+		{
+			name:  "invalid value allowed",
+			input: []string{"--str", "h\xffllo"},
+		},
+		{
+			name:        "invalid value",
+			strict:      true,
+			input:       []string{"--str", "h\xffllo"},
+			expectedErr: `invalid argument "h\xffllo" for "-s, --str" flag: invalid UTF-8`,
+		},
+		{
+			name:        "invalid inline value",
+			strict:      true,
+			input:       []string{"-s=h\xffllo"},
+			expectedErr: `invalid argument "h\xffllo" for "-s, --str" flag: invalid UTF-8`,
+		},
+		{
+			name:        "invalid flag name",
+			strict:      true,
+			input:       []string{"--st\xffr=hello"},
+			expectedErr: `bad flag syntax: "--st\xffr=hello": invalid UTF-8`,
+		},
+		{
+			name:        "invalid shorthand",
+			strict:      true,
+			input:       []string{"-\xff"},
+			expectedErr: `bad flag syntax: "-\xff": invalid UTF-8`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.StrictUTF8 = test.strict
+			f.String("str", "", "usage", zflag.OptShorthand('s'))
+
+			err := f.Parse(test.input)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+			if !errors.Is(err, zflag.ErrInvalidUTF8) {
+				t.Fatalf("expected an ErrInvalidUTF8 error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestLongArgSyntaxError(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       []string
+		expected    zflag.SyntaxError
+		expectedErr string
+	}{
+		{
+			name:        "empty name",
+			input:       []string{"--=value"},
+			expected:    zflag.SyntaxError{Arg: "--=value", Index: 0, Offset: 2, Reason: "missing flag name before '='"},
+			expectedErr: `bad flag syntax: "--=value": missing flag name before '=' (argument 0, offset 2)`,
+		},
+		{
+			name:        "trailing equals",
+			input:       []string{"--str", "a", "--="},
+			expected:    zflag.SyntaxError{Arg: "--=", Index: 2, Offset: 2, Reason: "missing flag name and value after '--'"},
+			expectedErr: `bad flag syntax: "--=": missing flag name and value after '--' (argument 2, offset 2)`,
+		},
+		{
+			name:        "double equals",
+			input:       []string{"pos", "--str==value"},
+			expected:    zflag.SyntaxError{Arg: "--str==value", Index: 1, Offset: 6, Reason: "unexpected second '='"},
+			expectedErr: `bad flag syntax: "--str==value": unexpected second '=' (argument 1, offset 6)`,
+		},
+		{
+			name:        "double trailing equals",
+			input:       []string{"--str=="},
+			expected:    zflag.SyntaxError{Arg: "--str==", Index: 0, Offset: 6, Reason: "unexpected second '='"},
+			expectedErr: `bad flag syntax: "--str==": unexpected second '=' (argument 0, offset 6)`,
+		},
+		{
+			name:        "secret value masked",
+			input:       []string{"--token==x"},
+			expected:    zflag.SyntaxError{Arg: "--token=********", Index: 0, Offset: 8, Reason: "unexpected second '='"},
+			expectedErr: `bad flag syntax: "--token=********": unexpected second '=' (argument 0, offset 8)`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("str", "", "usage")
+			f.String("token", "", "usage", zflag.OptSecret())
+
+			err := f.Parse(test.input)
+			assertErrMsg(t, test.expectedErr, err)
+			var syntaxErr zflag.SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("expected a SyntaxError, got %v", err)
+			}
+			assertEqual(t, test.expected, syntaxErr)
+		})
+	}
+}
+
+func TestOptNonEmpty(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_NON_EMPTY", "")
+
+	tests := []struct {
+		name        string
+		input       []string
+		env         bool
+		expectedErr string
+	}{
+		{
+			name:  "value passed",
+			input: []string{"--name", "zflag"},
+		},
+		{
+			name:  "not passed",
+			input: []string{},
+		},
+		{
+			name:        "empty inline value",
+			input:       []string{"--name="},
+			expectedErr: `invalid argument "" for "-n, --name" flag: value must not be empty`,
+		},
+		{
+			name:        "whitespace shorthand value",
+			input:       []string{"-n= "},
+			expectedErr: `invalid argument " " for "-n, --name" flag: value must not be empty`,
+		},
+		{
+			name:        "empty environment variable",
+			input:       []string{},
+			env:         true,
+			expectedErr: `invalid argument "" for "-n, --name" flag: value must not be empty (from environment variable ZFLAG_TEST_NON_EMPTY)`,
+		},
+	}
+
+	for _, test := range tests {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		opts := []zflag.Opt{zflag.OptShorthand('n'), zflag.OptNonEmpty()}
+		if test.env {
+			opts = append(opts, zflag.OptEnv("ZFLAG_TEST_NON_EMPTY"))
+		}
+		f.String("name", "", "usage", opts...)
+
+		err := f.Parse(test.input)
+		if test.expectedErr == "" {
+			assertNoErr(t, err)
+			continue
+		}
+		assertErrMsg(t, test.expectedErr, err)
+		assertEqualf(t, true, errors.Is(err, zflag.ErrEmptyValue), "%s: expected ErrEmptyValue, got %v", test.name, err)
+	}
+}
+
+func TestOptNonEmptyOptionalValue(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.Bool("verbose", false, "usage", zflag.OptNonEmpty())
+}
@@ -0,0 +1,83 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flagexpvar publishes the values of the flags of a zflag.FlagSet,
+// so operators can confirm the running configuration from monitoring
+// systems. It is a separate package as importing expvar registers the
+// /debug/vars handler on http.DefaultServeMux.
+package flagexpvar
+
+import (
+	"expvar"
+	"fmt"
+	"reflect"
+	"unicode"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+// Publish publishes the value of every flag of fs through expvar, under the
+// name prefix followed by the flag name, e.g. "flags.port". Values are
+// evaluated whenever the variables are read, so later changes to dynamic
+// flags are reflected. Secret flags are skipped. Like expvar.Publish,
+// Publish panics if a variable with the same name is already published.
+func Publish(fs *zflag.FlagSet, prefix string) {
+	fs.VisitAll(func(flag *zflag.Flag) {
+		if flag.Secret {
+			return
+		}
+		expvar.Publish(prefix+flag.Name, expvar.Func(func() interface{} {
+			return value(flag)
+		}))
+	})
+}
+
+// Labels returns the current values of the flags of fs keyed by a valid
+// Prometheus label name, which is prefix followed by the flag name with all
+// invalid characters replaced by underscores, e.g. "flag_listen_addr". This
+// is meant to be used as the labels of an info metric. Secret flags are
+// skipped.
+func Labels(fs *zflag.FlagSet, prefix string) map[string]string {
+	labels := make(map[string]string)
+	fs.VisitAll(func(flag *zflag.Flag) {
+		if flag.Secret {
+			return
+		}
+		labels[labelName(prefix+flag.Name)] = flag.Value.String()
+	})
+	return labels
+}
+
+// value returns numeric and bool values as is, so they are published as JSON
+// numbers and booleans, and the textual representation of everything else.
+func value(flag *zflag.Flag) interface{} {
+	getter, ok := flag.Value.(zflag.Getter)
+	if !ok {
+		return flag.Value.String()
+	}
+
+	v := getter.Get()
+	if _, isStringer := v.(fmt.Stringer); isStringer {
+		return flag.Value.String()
+	}
+
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return v
+	}
+	return flag.Value.String()
+}
+
+func labelName(name string) string {
+	label := []rune(name)
+	for i, r := range label {
+		isValid := r < unicode.MaxASCII && (unicode.IsLetter(r) || r == '_' || (i > 0 && unicode.IsDigit(r)))
+		if !isValid {
+			label[i] = '_'
+		}
+	}
+	return string(label)
+}
@@ -0,0 +1,71 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flagexpvar_test
+
+import (
+	"expvar"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+	"github.com/zulucmd/zflag/v2/flagexpvar"
+)
+
+func newFlagSet() *zflag.FlagSet {
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.String("listen-addr", ":8080", "usage")
+	fs.Int("port", 80, "usage")
+	fs.Bool("verbose", false, "usage")
+	fs.Duration("timeout", time.Second, "usage")
+	fs.String("token", "s3cr3t", "usage", zflag.OptSecret())
+	return fs
+}
+
+func TestPublish(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	flagexpvar.Publish(fs, "flagexpvar_test.")
+
+	expected := map[string]string{
+		"flagexpvar_test.listen-addr": `":8080"`,
+		"flagexpvar_test.port":        `80`,
+		"flagexpvar_test.verbose":     `false`,
+		"flagexpvar_test.timeout":     `"1s"`,
+	}
+	for name, want := range expected {
+		v := expvar.Get(name)
+		if v == nil {
+			t.Fatalf("expected %s to be published", name)
+		}
+		if got := v.String(); got != want {
+			t.Fatalf("expected %s to be %s, got %s", name, want, got)
+		}
+	}
+	if expvar.Get("flagexpvar_test.token") != nil {
+		t.Fatal("expected secret flag not to be published")
+	}
+
+	if err := fs.Set("port", "8080"); err != nil {
+		t.Fatal(err)
+	}
+	if got := expvar.Get("flagexpvar_test.port").String(); got != "8080" {
+		t.Fatalf("expected the published value to follow the flag, got %s", got)
+	}
+}
+
+func TestLabels(t *testing.T) {
+	t.Parallel()
+
+	expected := map[string]string{
+		"flag_listen_addr": ":8080",
+		"flag_port":        "80",
+		"flag_verbose":     "false",
+		"flag_timeout":     "1s",
+	}
+	if got := flagexpvar.Labels(newFlagSet(), "flag_"); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
@@ -0,0 +1,223 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package flaghttp provides an HTTP handler exposing the flags of a
+// zflag.FlagSet, similar to the /debug/vars endpoint of expvar.
+//
+// A GET request lists all visible flags with their current value and the
+// source of that value as JSON. A POST request updates visible flags marked
+// with zflag.OptDynamic, using the form values of the request as flag names and
+// values, after which the updated list of flags is returned.
+//
+//	http.Handle("/debug/flags", flaghttp.Handler(fs, flaghttp.AllowLocal))
+package flaghttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+// SourceHTTP is the source recorded for values updated through the handler.
+const SourceHTTP = "http"
+
+const secretMask = "********"
+
+// AuthFunc authorizes a request to update flag. Returning an error rejects
+// the update with status 403 Forbidden.
+type AuthFunc func(r *http.Request, flag *zflag.Flag) error
+
+// AllowLocal is an AuthFunc that only accepts updates from the loopback
+// interface. Requests with an Origin header are rejected, as browsers send
+// it with cross-site form posts, which would otherwise let any web page
+// opened on the machine update the flags.
+func AllowLocal(r *http.Request, _ *zflag.Flag) error {
+	if r.Header.Get("Origin") != "" {
+		return errors.New("updates are not allowed from browsers")
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return errors.New("updates are only allowed from localhost")
+	}
+	return nil
+}
+
+type handler struct {
+	mu        sync.Mutex
+	fs        *zflag.FlagSet
+	authorize AuthFunc
+}
+
+// Handler returns an http.Handler listing the flags of fs, and updating its
+// dynamic flags for requests accepted by authorize. If authorize is nil, the
+// flags are read-only. Updates are serialized by the handler, but the
+// program itself is responsible for reading the values of dynamic flags in
+// a way that is safe for concurrent use.
+func Handler(fs *zflag.FlagSet, authorize AuthFunc) http.Handler {
+	return &handler{fs: fs, authorize: authorize}
+}
+
+type flagInfo struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Value   string `json:"value"`
+	Default string `json:"default"`
+	Source  string `json:"source"`
+	Changed bool   `json:"changed"`
+	Dynamic bool   `json:"dynamic"`
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+	case http.MethodPost:
+		if status, err := h.update(r); err != nil {
+			http.Error(w, err.Error(), status)
+			return
+		}
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(h.list())
+}
+
+func (h *handler) list() []flagInfo {
+	flags := []flagInfo{}
+	h.fs.VisitAll(func(flag *zflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+
+		info := flagInfo{
+			Name:    flag.Name,
+			Type:    "value",
+			Value:   flag.Value.String(),
+			Default: flag.DefValue,
+			Source:  flag.Source,
+			Changed: flag.Changed,
+			Dynamic: flag.Dynamic,
+		}
+		if typed, ok := flag.Value.(zflag.Typed); ok {
+			info.Type = typed.Type()
+		}
+		if info.Source == "" {
+			info.Source = "default"
+		}
+		if flag.Secret {
+			info.Value, info.Default = secretMask, secretMask
+		}
+		flags = append(flags, info)
+	})
+	return flags
+}
+
+// update applies the form values of r, returning the HTTP status on error.
+// All values are checked before any flag is set, and replace the elements
+// of slice and map flags.
+func (h *handler) update(r *http.Request) (int, error) {
+	if err := r.ParseForm(); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	names := make([]string, 0, len(r.PostForm))
+	for name := range r.PostForm {
+		flag := h.fs.Lookup(name)
+		switch {
+		case flag == nil || flag.Hidden:
+			return http.StatusNotFound, fmt.Errorf("unknown flag: %s", name)
+		case !flag.Dynamic:
+			return http.StatusForbidden, fmt.Errorf("flag %s cannot be changed at runtime", name)
+		case h.authorize == nil:
+			return http.StatusForbidden, errors.New("updates are not allowed")
+		}
+		if err := h.authorize(r, flag); err != nil {
+			return http.StatusForbidden, err
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	if err := h.check(names, r.PostForm); err != nil {
+		return http.StatusBadRequest, err
+	}
+
+	for _, name := range names {
+		flag := h.fs.Lookup(name)
+		fs := owner(h.fs, flag)
+		clearValue(flag)
+		for _, value := range r.PostForm[name] {
+			if err := fs.Set(flag.Name, value); err != nil {
+				return http.StatusBadRequest, err
+			}
+		}
+		flag.Source = SourceHTTP
+	}
+	return http.StatusOK, nil
+}
+
+// check tries the values on the flags, restoring their previous state
+// afterwards, so an invalid value is reported before any flag is changed.
+// The values are set through FlagSet.Set, so they are processed and
+// validated exactly as in update, which also means the set hooks and
+// OnChange callbacks run for them.
+func (h *handler) check(names []string, values map[string][]string) error {
+	pushed := make(map[*zflag.FlagSet]bool)
+	for _, name := range names {
+		fs := owner(h.fs, h.fs.Lookup(name))
+		if !pushed[fs] {
+			pushed[fs] = true
+			fs.PushState()
+			defer fs.PopState()
+		}
+	}
+
+	for _, name := range names {
+		flag := h.fs.Lookup(name)
+		fs := owner(h.fs, flag)
+		clearValue(flag)
+		for _, value := range values[name] {
+			if err := fs.Set(flag.Name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// owner returns the FlagSet defining flag, which is fs or the parent it is
+// inherited from, as Lookup falls back to the parents of fs but Set does not.
+func owner(fs *zflag.FlagSet, flag *zflag.Flag) *zflag.FlagSet {
+	for fs.Parent() != nil && fs.Parent().Lookup(flag.Name) == flag {
+		fs = fs.Parent()
+	}
+	return fs
+}
+
+// clearValue empties the value of a slice or map flag, so that setting it
+// replaces its elements instead of adding to them.
+func clearValue(flag *zflag.Flag) {
+	switch v := flag.Value.(type) {
+	case zflag.SliceValue:
+		_ = v.Replace(nil)
+	case zflag.MapValue:
+		_ = v.ReplaceMap(nil)
+	}
+}
@@ -0,0 +1,286 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package flaghttp_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+	"github.com/zulucmd/zflag/v2/flaghttp"
+)
+
+type flagInfo struct {
+	Name    string
+	Value   string
+	Default string
+	Source  string
+	Changed bool
+	Dynamic bool
+}
+
+func newFlagSet(t *testing.T) *zflag.FlagSet {
+	t.Helper()
+
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("log-level", "info", "log level", zflag.OptDynamic())
+	fs.Int("port", 80, "port")
+	fs.String("token", "s3cr3t", "token", zflag.OptSecret())
+	fs.Bool("internal", false, "internal", zflag.OptHidden())
+	if err := fs.Parse([]string{"--port=8080"}); err != nil {
+		t.Fatal(err)
+	}
+	return fs
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) map[string]flagInfo {
+	t.Helper()
+
+	var flags []flagInfo
+	if err := json.Unmarshal(rec.Body.Bytes(), &flags); err != nil {
+		t.Fatalf("unable to decode %q: %v", rec.Body.String(), err)
+	}
+	out := make(map[string]flagInfo, len(flags))
+	for _, flag := range flags {
+		out[flag.Name] = flag
+	}
+	return out
+}
+
+func allowAll(*http.Request, *zflag.Flag) error { return nil }
+
+func TestHandlerList(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	flaghttp.Handler(newFlagSet(t), nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/flags", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	flags := decode(t, rec)
+	expected := map[string]flagInfo{
+		"log-level": {Name: "log-level", Value: "info", Default: "info", Source: "default", Dynamic: true},
+		"port":      {Name: "port", Value: "8080", Default: "80", Source: zflag.SourceArgs, Changed: true},
+		"token":     {Name: "token", Value: "********", Default: "********", Source: "default"},
+	}
+	if len(flags) != len(expected) {
+		t.Fatalf("expected %d flags, got %v", len(expected), flags)
+	}
+	for name, want := range expected {
+		if flags[name] != want {
+			t.Fatalf("expected flag %s to be %+v, got %+v", name, want, flags[name])
+		}
+	}
+}
+
+func TestHandlerUpdate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		method    string
+		form      url.Values
+		authorize flaghttp.AuthFunc
+		status    int
+		logLevel  string
+	}{
+		{
+			name:      "update dynamic flag",
+			method:    http.MethodPost,
+			form:      url.Values{"log-level": {"debug"}},
+			authorize: allowAll,
+			status:    http.StatusOK,
+			logLevel:  "debug",
+		},
+		{
+			name:     "read-only",
+			method:   http.MethodPost,
+			form:     url.Values{"log-level": {"debug"}},
+			status:   http.StatusForbidden,
+			logLevel: "info",
+		},
+		{
+			name:      "denied",
+			method:    http.MethodPost,
+			form:      url.Values{"log-level": {"debug"}},
+			authorize: func(*http.Request, *zflag.Flag) error { return errors.New("denied") },
+			status:    http.StatusForbidden,
+			logLevel:  "info",
+		},
+		{
+			name:      "not dynamic",
+			method:    http.MethodPost,
+			form:      url.Values{"log-level": {"debug"}, "port": {"9090"}},
+			authorize: allowAll,
+			status:    http.StatusForbidden,
+			logLevel:  "info",
+		},
+		{
+			name:      "unknown flag",
+			method:    http.MethodPost,
+			form:      url.Values{"unknown": {"x"}},
+			authorize: allowAll,
+			status:    http.StatusNotFound,
+			logLevel:  "info",
+		},
+		{
+			name:      "local only",
+			method:    http.MethodPost,
+			form:      url.Values{"log-level": {"debug"}},
+			authorize: flaghttp.AllowLocal,
+			status:    http.StatusForbidden,
+			logLevel:  "info",
+		},
+		{
+			name:      "method not allowed",
+			method:    http.MethodDelete,
+			authorize: allowAll,
+			status:    http.StatusMethodNotAllowed,
+			logLevel:  "info",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := newFlagSet(t)
+			req := httptest.NewRequest(test.method, "/debug/flags", strings.NewReader(test.form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rec := httptest.NewRecorder()
+			flaghttp.Handler(fs, test.authorize).ServeHTTP(rec, req)
+
+			if rec.Code != test.status {
+				t.Fatalf("expected status %d, got %d: %s", test.status, rec.Code, rec.Body.String())
+			}
+			if got := fs.MustGetString("log-level"); got != test.logLevel {
+				t.Fatalf("expected log-level %q, got %q", test.logLevel, got)
+			}
+			if test.status == http.StatusOK {
+				if flag := decode(t, rec)["log-level"]; flag.Source != flaghttp.SourceHTTP || flag.Value != test.logLevel {
+					t.Fatalf("unexpected flag after update: %+v", flag)
+				}
+			}
+		})
+	}
+}
+
+func TestHandlerUpdateValues(t *testing.T) {
+	t.Parallel()
+
+	newFlagSets := func(t *testing.T) (*zflag.FlagSet, *zflag.FlagSet) {
+		parent := zflag.NewFlagSet("parent", zflag.ContinueOnError)
+		parent.SetOutput(ioutil.Discard)
+		parent.Int("workers", 1, "workers", zflag.OptDynamic())
+
+		fs := newFlagSet(t)
+		fs.StringSlice("tags", nil, "tags", zflag.OptDynamic())
+		fs.String("name", "app", "name", zflag.OptDynamic(), zflag.OptNonEmpty())
+		fs.Bool("debug", false, "debug", zflag.OptDynamic(), zflag.OptHidden())
+		if err := fs.Parse([]string{"--tags=a"}); err != nil {
+			t.Fatal(err)
+		}
+		fs.SetParent(parent)
+		return parent, fs
+	}
+
+	post := func(fs *zflag.FlagSet, form url.Values, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/debug/flags", strings.NewReader(form.Encode()))
+		req.RemoteAddr = "127.0.0.1:1234"
+		req.Header = header
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		rec := httptest.NewRecorder()
+		flaghttp.Handler(fs, flaghttp.AllowLocal).ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("slices are replaced and inherited flags set", func(t *testing.T) {
+		t.Parallel()
+
+		parent, fs := newFlagSets(t)
+		rec := post(fs, url.Values{"tags": {"a", "b"}, "workers": {"4"}}, http.Header{})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := fs.MustGetStringSlice("tags"); strings.Join(got, ",") != "a,b" {
+			t.Fatalf("expected tags [a b], got %q", got)
+		}
+		if got := parent.MustGetInt("workers"); got != 4 {
+			t.Fatalf("expected 4 workers, got %d", got)
+		}
+		if source := parent.Lookup("workers").Source; source != flaghttp.SourceHTTP {
+			t.Fatalf("expected source %q, got %q", flaghttp.SourceHTTP, source)
+		}
+	})
+
+	t.Run("invalid value changes no flag", func(t *testing.T) {
+		t.Parallel()
+
+		parent, fs := newFlagSets(t)
+		rec := post(fs, url.Values{"log-level": {"debug"}, "tags": {"c"}, "workers": {"many"}}, http.Header{})
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := fs.MustGetString("log-level"); got != "info" {
+			t.Fatalf("expected log-level info, got %q", got)
+		}
+		if got := fs.MustGetStringSlice("tags"); strings.Join(got, ",") != "a" {
+			t.Fatalf("expected tags [a], got %q", got)
+		}
+		if got := parent.MustGetInt("workers"); got != 1 || parent.Changed("workers") {
+			t.Fatalf("expected workers to be unchanged, got %d", got)
+		}
+	})
+
+	t.Run("values are validated by the flag set", func(t *testing.T) {
+		t.Parallel()
+
+		_, fs := newFlagSets(t)
+		rec := post(fs, url.Values{"log-level": {"debug"}, "name": {" "}}, http.Header{})
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := fs.MustGetString("log-level"); got != "info" || fs.Changed("log-level") {
+			t.Fatalf("expected log-level to be unchanged, got %q", got)
+		}
+		if got := fs.MustGetString("name"); got != "app" {
+			t.Fatalf("expected name app, got %q", got)
+		}
+	})
+
+	t.Run("hidden flags are not writable", func(t *testing.T) {
+		t.Parallel()
+
+		_, fs := newFlagSets(t)
+		rec := post(fs, url.Values{"debug": {"true"}}, http.Header{})
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("expected status 404, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if fs.MustGetBool("debug") {
+			t.Fatal("expected debug to be unchanged")
+		}
+	})
+
+	t.Run("browser requests are rejected", func(t *testing.T) {
+		t.Parallel()
+
+		_, fs := newFlagSets(t)
+		rec := post(fs, url.Values{"log-level": {"debug"}}, http.Header{"Origin": {"https://example.com"}})
+		if rec.Code != http.StatusForbidden {
+			t.Fatalf("expected status 403, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if got := fs.MustGetString("log-level"); got != "info" {
+			t.Fatalf("expected log-level info, got %q", got)
+		}
+	})
+}
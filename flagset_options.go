@@ -0,0 +1,148 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"io"
+)
+
+// FlagSetOpt configures a FlagSet created with New.
+type FlagSetOpt func(fs *FlagSet)
+
+// New returns a new, empty flag set with the specified name, configured by
+// the given options. Unless WithErrorHandling is passed, the flag set uses
+// the ContinueOnError error handling policy.
+func New(name string, opts ...FlagSetOpt) *FlagSet {
+	fs := NewFlagSet(name, ContinueOnError)
+	for _, opt := range opts {
+		opt(fs)
+	}
+	return fs
+}
+
+// WithErrorHandling sets the error handling policy.
+func WithErrorHandling(errorHandling ErrorHandling) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.errorHandling = errorHandling
+	}
+}
+
+// WithSort sets whether flags are sorted in help/usage messages.
+func WithSort(sort bool) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SortFlags = sort
+	}
+}
+
+// WithNormalizer sets the normalize func, see SetNormalizeFunc.
+func WithNormalizer(n func(f *FlagSet, name string) NormalizedName) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SetNormalizeFunc(n)
+	}
+}
+
+// WithGroups sets the order in which flag groups are printed, see SetGroupOrder.
+func WithGroups(order ...string) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SetGroupOrder(order)
+	}
+}
+
+// WithOutput sets the destination for usage and error messages.
+func WithOutput(output io.Writer) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SetOutput(output)
+	}
+}
+
+// WithInterspersed sets whether to support interspersed option/non-option arguments.
+func WithInterspersed(interspersed bool) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SetInterspersed(interspersed)
+	}
+}
+
+// WithUsage sets the function called when an error occurs while parsing flags.
+func WithUsage(usage func()) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.Usage = usage
+	}
+}
+
+// WithDisableBuiltinHelp disables the built-in handling of -h and --help.
+func WithDisableBuiltinHelp() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.DisableBuiltinHelp = true
+	}
+}
+
+// WithStrictUTF8 rejects flag names and values that are not valid UTF-8.
+func WithStrictUTF8() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.StrictUTF8 = true
+	}
+}
+
+// WithUsageCache caches the rendered usage information, see CacheUsage.
+func WithUsageCache() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.CacheUsage = true
+	}
+}
+
+// WithAbbreviations accepts unambiguous prefixes of long flag names, see
+// AllowAbbreviations.
+func WithAbbreviations() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.AllowAbbreviations = true
+	}
+}
+
+// WithSlashFlags accepts Windows-style /name and /name:value flags, see
+// AllowSlashFlags.
+func WithSlashFlags() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.AllowSlashFlags = true
+	}
+}
+
+// WithSingleDashLongFlags treats -name as a long flag, see
+// SingleDashLongFlags.
+func WithSingleDashLongFlags() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SingleDashLongFlags = true
+	}
+}
+
+// WithNegativeNumbersAsArgs treats negative numbers as positional arguments,
+// see NegativeNumbersAsArgs.
+func WithNegativeNumbersAsArgs() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.NegativeNumbersAsArgs = true
+	}
+}
+
+// WithNegativePrefix sets the prefix of the negated form of flags, see
+// NegativePrefix.
+func WithNegativePrefix(prefix string) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.NegativePrefix = prefix
+	}
+}
+
+// WithRegisterNegativeFlags registers the negated forms of flags as hidden
+// flags, see RegisterNegativeFlags.
+func WithRegisterNegativeFlags() FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.RegisterNegativeFlags = true
+	}
+}
+
+// WithEnvPrefix binds flags to environment variables derived from prefix, see
+// SetEnvPrefix.
+func WithEnvPrefix(prefix string) FlagSetOpt {
+	return func(fs *FlagSet) {
+		fs.SetEnvPrefix(prefix)
+	}
+}
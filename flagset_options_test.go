@@ -0,0 +1,80 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.New("test",
+		zflag.WithOutput(&buf),
+		zflag.WithSort(false),
+		zflag.WithInterspersed(false),
+		zflag.WithGroups("B", "A"),
+		zflag.WithNormalizer(func(f *zflag.FlagSet, name string) zflag.NormalizedName {
+			return zflag.NormalizedName(strings.ReplaceAll(name, "_", "-"))
+		}),
+	)
+	f.Bool("zeta", false, "zeta")
+	f.Bool("alpha", false, "alpha")
+	f.Bool("in-a", false, "in a", zflag.OptGroup("A"))
+	f.Bool("in-b", false, "in b", zflag.OptGroup("B"))
+
+	assertEqual(t, "test", f.Name())
+	assertEqual(t, &buf, f.Output())
+	assertEqual(t, false, f.SortFlags)
+	assertDeepEqual(t, []string{"", "B", "A"}, f.Groups())
+
+	err := f.Parse([]string{"--in_a", "arg", "--zeta"})
+	assertNoErr(t, err)
+	assertEqual(t, true, f.Changed("in-a"))
+	assertEqual(t, false, f.Changed("zeta"))
+	assertDeepEqual(t, []string{"arg", "--zeta"}, f.Args())
+
+	err = f.Parse([]string{"--unknown"})
+	assertErr(t, err)
+}
+
+func TestNewErrorHandling(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.New("test", zflag.WithErrorHandling(zflag.PanicOnError), zflag.WithOutput(ioutil.Discard))
+	defer assertPanic(t)()
+	_ = f.Parse([]string{"--unknown"})
+}
+
+func TestNewUsage(t *testing.T) {
+	t.Parallel()
+
+	called := false
+	f := zflag.New("test",
+		zflag.WithOutput(ioutil.Discard),
+		zflag.WithUsage(func() { called = true }),
+	)
+	err := f.Parse([]string{"--help"})
+	assertEqual(t, zflag.ErrHelp, err)
+	assertEqual(t, true, called)
+
+	f = zflag.New("test", zflag.WithOutput(ioutil.Discard), zflag.WithDisableBuiltinHelp())
+	err = f.Parse([]string{"--help"})
+	assertErrMsg(t, "unknown flag: --help", err)
+}
+
+func TestNewStrictUTF8(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.New("test", zflag.WithStrictUTF8(), zflag.WithOutput(ioutil.Discard))
+	f.String("str", "", "usage")
+	assertEqual(t, true, f.StrictUTF8)
+	assertErr(t, f.Parse([]string{"--str", "\xff"}))
+}
@@ -31,7 +31,7 @@ func (s *float32SliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	temp64, err := strconv.ParseFloat(val, 32)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 
 	if !s.changed {
@@ -74,7 +74,7 @@ func (s *float32SliceValue) toString(val float32) string {
 func (s *float32SliceValue) Append(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -86,7 +86,7 @@ func (s *float32SliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
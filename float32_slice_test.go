@@ -28,22 +28,22 @@ func TestFloat32Slice(t *testing.T) {
 			expectedValues: []float32{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []float32{},
-			expectedErr: `invalid argument "" for "--f32s" flag: strconv.ParseFloat: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []float32{},
+			expectedValues: []float32{},
 		},
 		{
 			name:        "invalid float32",
 			input:       []string{"blabla"},
 			flagDefault: []float32{},
-			expectedErr: `invalid argument "blabla" for "--f32s" flag: strconv.ParseFloat: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--f32s" flag: element 1: strconv.ParseFloat: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1.1,1.5"},
 			flagDefault: []float32{},
-			expectedErr: `invalid argument "1.1,1.5" for "--f32s" flag: strconv.ParseFloat: parsing "1.1,1.5": invalid syntax`,
+			expectedErr: `invalid argument "1.1,1.5" for "--f32s" flag: element 1: strconv.ParseFloat: parsing "1.1,1.5": invalid syntax`,
 		},
 		{
 			name:           "empty value passed",
@@ -35,7 +35,7 @@ func (s *float64SliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	out, err := strconv.ParseFloat(val, 64)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 
 	if !s.changed {
@@ -70,7 +70,7 @@ func (s *float64SliceValue) toString(val float64) string {
 func (s *float64SliceValue) Append(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -82,7 +82,7 @@ func (s *float64SliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
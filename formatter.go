@@ -15,9 +15,17 @@ import (
 // is added between left and right.
 type FlagUsageFormatter func(*Flag) (string, string)
 
-func defaultUsageFormatter(flag *Flag) (string, string) {
+// formatFlagUsage is the default FlagUsageFormatter, rendering the negated
+// form of negatable flags with negativePrefix.
+func formatFlagUsage(flag *Flag, negativePrefix string) (string, string) {
+	varname, usage := UnquoteUsage(flag)
+	// values that may be omitted are rendered as --flag[=value] and -f[value]
+	_, isOptional := flag.Value.(OptionalValue)
+	isOptional = isOptional && varname != "" && flag.NoArgDefault == ""
+
 	left := "  "
-	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
+	hasShorthand := flag.Shorthand != 0 && flag.ShorthandDeprecated == ""
+	if hasShorthand {
 		left += fmt.Sprintf("-%c", flag.Shorthand)
 		if !flag.ShorthandOnly {
 			left += ", "
@@ -25,15 +33,44 @@ func defaultUsageFormatter(flag *Flag) (string, string) {
 	} else {
 		left += "    "
 	}
-	left += "--"
-	if _, isBoolFlag := flag.Value.(BoolFlag); isBoolFlag && flag.AddNegative {
-		left += "[no-]"
+	if flag.NegativeShorthand != 0 && !flag.ShorthandOnly {
+		if !hasShorthand {
+			left = "  "
+		}
+		left += fmt.Sprintf("-%c, ", flag.NegativeShorthand)
+	}
+	if flag.AtomicShorthand != "" && !flag.ShorthandOnly {
+		if !hasShorthand && flag.NegativeShorthand == 0 {
+			left = "  "
+		}
+		left += "-" + flag.AtomicShorthand + ", "
 	}
-	left += flag.Name
 
-	varname, usage := UnquoteUsage(flag)
-	if varname != "" {
-		left += " " + varname
+	switch {
+	case hasShorthand && flag.ShorthandOnly && isOptional:
+		left += "[" + varname + "]"
+	case hasShorthand && flag.ShorthandOnly:
+		if varname != "" {
+			left += " " + varname
+		}
+	default:
+		left += "--"
+		if isNegatable(flag) {
+			left += "[" + negativePrefix + "]"
+		}
+		left += flag.Name
+		if isOptional {
+			left += "[=" + varname + "]"
+		} else if varname != "" {
+			left += " " + varname
+		}
+	}
+	if flag.NoArgDefault != "" && !flag.Secret {
+		if v, ok := flag.Value.(Typed); ok && isQuotedType(v.Type()) {
+			left += fmt.Sprintf("[=%q]", flag.NoArgDefault)
+		} else {
+			left += fmt.Sprintf("[=%s]", flag.NoArgDefault)
+		}
 	}
 
 	right := usage
@@ -41,16 +78,32 @@ func defaultUsageFormatter(flag *Flag) (string, string) {
 		right += " (required)"
 	}
 
-	if !flag.DisablePrintDefault && !flag.DefaultIsZeroValue() {
-		if v, ok := flag.Value.(Typed); ok && v.Type() == "string" {
+	switch {
+	case flag.DisablePrintDefault:
+	case flag.DefaultText != "":
+		right += fmt.Sprintf(" (default %s)", flag.DefaultText)
+	case !flag.Secret && !flag.DefaultIsZeroValue():
+		if v, ok := flag.Value.(Typed); ok && isQuotedType(v.Type()) {
 			right += fmt.Sprintf(" (default %q)", flag.DefValue)
 		} else {
 			right += fmt.Sprintf(" (default %s)", flag.DefValue)
 		}
 	}
+	if flag.EnvVar != "" {
+		right += fmt.Sprintf(" (env: %s)", flag.EnvVar)
+	}
+	if flag.ConfigKey != "" {
+		right += fmt.Sprintf(" (config: %s)", flag.ConfigKey)
+	}
 	if len(flag.Deprecated) != 0 {
 		right += fmt.Sprintf(" (DEPRECATED: %s)", flag.Deprecated)
 	}
 
 	return left, right
 }
+
+// isQuotedType reports whether values of the type are quoted in usage output,
+// as they may consist of whitespace.
+func isQuotedType(typ string) bool {
+	return typ == "string" || typ == "rune"
+}
@@ -0,0 +1,303 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package formmodel converts a zflag.FlagSet into a neutral description of
+// an input form, which TUI and GUI libraries can render as an interactive
+// configuration screen. Values entered in the form are applied back to the
+// FlagSet through Form.Apply.
+package formmodel
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+// Kind is the kind of input widget suited for a field.
+type Kind int
+
+const (
+	// KindText is a free text input.
+	KindText Kind = iota
+	// KindBool is a checkbox or toggle.
+	KindBool
+	// KindNumber is a numeric input, see Validation for its bounds.
+	KindNumber
+	// KindDuration is a duration input, e.g. "1h30m".
+	KindDuration
+	// KindChoice is a selection out of Validation.Choices.
+	KindChoice
+	// KindSecret is a text input whose contents should be masked.
+	KindSecret
+	// KindList is an input accepting multiple values.
+	KindList
+	// KindMap is an input accepting multiple key=value pairs.
+	KindMap
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindText:
+		return "text"
+	case KindBool:
+		return "bool"
+	case KindNumber:
+		return "number"
+	case KindDuration:
+		return "duration"
+	case KindChoice:
+		return "choice"
+	case KindSecret:
+		return "secret"
+	case KindList:
+		return "list"
+	case KindMap:
+		return "map"
+	}
+	return "unknown"
+}
+
+// Form describes all visible flags of a FlagSet.
+type Form struct {
+	Name     string
+	Sections []Section
+
+	fs *zflag.FlagSet
+}
+
+// Section is a flag group. The section of ungrouped flags has an empty name.
+type Section struct {
+	Name        string
+	Description string
+	Fields      []Field
+}
+
+// Field describes a single flag.
+type Field struct {
+	// Name is the name of the flag, which is used as key by Form.Apply.
+	Name string
+	// Label is a human-readable version of Name, e.g. "Listen addr".
+	Label string
+	// Help is the usage of the flag.
+	Help string
+	Kind Kind
+	// Type is the zflag type of the flag, e.g. "int64" or "stringSlice".
+	Type string
+	// Default is the default value, empty for secret flags.
+	Default string
+	// Value is the current value, empty for secret flags. For list and map
+	// fields, use Values.
+	Value string
+	// Values contains the current elements of list and map fields.
+	Values     []string
+	Validation Validation
+}
+
+// Validation describes the constraints on the value of a field.
+type Validation struct {
+	Required bool
+	// Choices contains the accepted values, if restricted.
+	Choices []string
+	// Integer is set for numeric fields that only accept whole numbers.
+	Integer bool
+	// Min and Max are the bounds of numeric fields.
+	Min, Max float64
+}
+
+// New returns the form model of the visible flags in fs, with a section per
+// flag group in the order returned by fs.Groups.
+func New(fs *zflag.FlagSet) *Form {
+	form := &Form{Name: fs.Name(), fs: fs}
+
+	fields := make(map[string][]Field)
+	fs.VisitAll(func(flag *zflag.Flag) {
+		if flag.Hidden || flag.Deprecated != "" {
+			return
+		}
+		fields[flag.Group] = append(fields[flag.Group], newField(flag))
+	})
+
+	for _, group := range fs.Groups() {
+		if len(fields[group]) == 0 {
+			continue
+		}
+		form.Sections = append(form.Sections, Section{
+			Name:        group,
+			Description: fs.GroupDescription(group),
+			Fields:      fields[group],
+		})
+	}
+
+	return form
+}
+
+// Field returns the field for the flag with the given name, or nil.
+func (f *Form) Field(name string) *Field {
+	for i := range f.Sections {
+		for j := range f.Sections[i].Fields {
+			if f.Sections[i].Fields[j].Name == name {
+				return &f.Sections[i].Fields[j]
+			}
+		}
+	}
+	return nil
+}
+
+// Apply sets the values entered in the form on the FlagSet the form was
+// created from, after which the FlagSet is validated. values is keyed by
+// the field name. The elements of list and map fields are set one by one,
+// replacing the elements the flag held before.
+func (f *Form) Apply(values map[string][]string) error {
+	for _, section := range f.Sections {
+		for _, field := range section.Fields {
+			fieldValues, ok := values[field.Name]
+			if !ok {
+				continue
+			}
+			if err := clearValue(f.fs.Lookup(field.Name)); err != nil {
+				return err
+			}
+			for _, value := range fieldValues {
+				if err := f.fs.Set(field.Name, value); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return f.fs.Validate()
+}
+
+// clearValue empties the value of a list or map flag, so that setting its
+// elements does not add to the elements it already holds.
+func clearValue(flag *zflag.Flag) error {
+	switch v := flag.Value.(type) {
+	case zflag.SliceValue:
+		return v.Replace(nil)
+	case zflag.MapValue:
+		return v.ReplaceMap(nil)
+	}
+	return nil
+}
+
+func newField(flag *zflag.Flag) Field {
+	_, usage := zflag.UnquoteUsage(flag)
+	field := Field{
+		Name:       flag.Name,
+		Label:      label(flag.Name),
+		Help:       usage,
+		Kind:       KindText,
+		Type:       "value",
+		Validation: Validation{Required: flag.Required},
+	}
+	if typed, ok := flag.Value.(zflag.Typed); ok {
+		field.Type = typed.Type()
+	}
+
+	switch {
+	case flag.Secret:
+		field.Kind = KindSecret
+		return field
+	case strings.HasPrefix(field.Type, "stringTo"):
+		field.Kind = KindMap
+	case strings.HasSuffix(field.Type, "Slice"):
+		field.Kind = KindList
+	}
+
+	field.Default = flag.DefValue
+	field.Value = flag.Value.String()
+	if sv, ok := flag.Value.(zflag.SliceValue); ok {
+		field.Values = sv.GetSlice()
+		field.Value = ""
+	}
+	if field.Kind == KindMap {
+		field.Values = mapValues(flag)
+		field.Value = ""
+	}
+	if field.Kind != KindText {
+		return field
+	}
+
+	if choices, ok := flag.Value.(zflag.ChoicesValue); ok {
+		field.Kind = KindChoice
+		field.Validation.Choices = choices.Choices()
+		return field
+	}
+
+	if _, isBool := flag.Value.(zflag.BoolFlag); isBool {
+		field.Kind = KindBool
+		return field
+	}
+
+	switch field.Type {
+	case "duration":
+		field.Kind = KindDuration
+	case "float32", "float64":
+		field.Kind = KindNumber
+		field.Validation.Min, field.Validation.Max = math.Inf(-1), math.Inf(1)
+	default:
+		if min, max, ok := integerBounds(field.Type); ok {
+			field.Kind = KindNumber
+			field.Validation.Integer = true
+			field.Validation.Min, field.Validation.Max = min, max
+		}
+	}
+	return field
+}
+
+func integerBounds(typ string) (min, max float64, ok bool) {
+	switch typ {
+	case "int", "int64":
+		return math.MinInt64, math.MaxInt64, true
+	case "int8":
+		return math.MinInt8, math.MaxInt8, true
+	case "int16":
+		return math.MinInt16, math.MaxInt16, true
+	case "int32":
+		return math.MinInt32, math.MaxInt32, true
+	case "uint", "uint64":
+		return 0, math.MaxUint64, true
+	case "uint8":
+		return 0, math.MaxUint8, true
+	case "uint16":
+		return 0, math.MaxUint16, true
+	case "uint32":
+		return 0, math.MaxUint32, true
+	case "count":
+		return 0, math.MaxInt64, true
+	}
+	return 0, 0, false
+}
+
+func mapValues(flag *zflag.Flag) []string {
+	getter, ok := flag.Value.(zflag.Getter)
+	if !ok {
+		return nil
+	}
+
+	v := reflect.ValueOf(getter.Get())
+	if v.Kind() != reflect.Map {
+		return nil
+	}
+
+	values := make([]string, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		values = append(values, fmt.Sprintf("%v=%v", iter.Key(), iter.Value()))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// label converts a kebab-case flag name into a label, e.g. "listen-addr"
+// becomes "Listen addr".
+func label(name string) string {
+	s := strings.NewReplacer("-", " ", "_", " ", ".", " ").Replace(name)
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
@@ -0,0 +1,127 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package formmodel_test
+
+import (
+	"io/ioutil"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+	"github.com/zulucmd/zflag/v2/formmodel"
+)
+
+func newFlagSet() *zflag.FlagSet {
+	fs := zflag.NewFlagSet("server", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.SetGroupDescription("Network", "Connection settings.")
+	fs.String("listen-addr", ":8080", "address to listen on", zflag.OptGroup("Network"), zflag.OptRequired())
+	fs.Uint8("retries", 3, "number of retries", zflag.OptGroup("Network"))
+	fs.Duration("timeout", time.Second, "request timeout", zflag.OptGroup("Network"))
+	fs.Bool("verbose", false, "verbose output")
+	fs.String("token", "s3cr3t", "api token", zflag.OptSecret())
+	fs.StringSlice("tag", []string{"a", "b"}, "tags")
+	fs.StringToInt("limit", map[string]int{"cpu": 2}, "limits")
+	fs.Float64("ratio", 0.5, "ratio")
+	fs.String("hidden", "", "hidden", zflag.OptHidden())
+	return fs
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	form := formmodel.New(newFlagSet())
+	if form.Name != "server" {
+		t.Fatalf("expected name server, got %q", form.Name)
+	}
+
+	expected := []formmodel.Section{
+		{
+			Fields: []formmodel.Field{
+				{Name: "limit", Label: "Limit", Help: "limits", Kind: formmodel.KindMap, Type: "stringToInt", Default: "[cpu=2]", Values: []string{"cpu=2"}},
+				{Name: "ratio", Label: "Ratio", Help: "ratio", Kind: formmodel.KindNumber, Type: "float64", Default: "0.5", Value: "0.5", Validation: formmodel.Validation{Min: math.Inf(-1), Max: math.Inf(1)}},
+				{Name: "tag", Label: "Tag", Help: "tags", Kind: formmodel.KindList, Type: "stringSlice", Default: "[a b]", Values: []string{"a", "b"}},
+				{Name: "token", Label: "Token", Help: "api token", Kind: formmodel.KindSecret, Type: "string"},
+				{Name: "verbose", Label: "Verbose", Help: "verbose output", Kind: formmodel.KindBool, Type: "bool", Default: "false", Value: "false"},
+			},
+		},
+		{
+			Name:        "Network",
+			Description: "Connection settings.",
+			Fields: []formmodel.Field{
+				{Name: "listen-addr", Label: "Listen addr", Help: "address to listen on", Kind: formmodel.KindText, Type: "string", Default: ":8080", Value: ":8080", Validation: formmodel.Validation{Required: true}},
+				{Name: "retries", Label: "Retries", Help: "number of retries", Kind: formmodel.KindNumber, Type: "uint8", Default: "3", Value: "3", Validation: formmodel.Validation{Integer: true, Min: 0, Max: 255}},
+				{Name: "timeout", Label: "Timeout", Help: "request timeout", Kind: formmodel.KindDuration, Type: "duration", Default: "1s", Value: "1s"},
+			},
+		},
+	}
+
+	if !reflect.DeepEqual(expected, form.Sections) {
+		t.Fatalf("expected sections:\n%+v\ngot:\n%+v", expected, form.Sections)
+	}
+}
+
+func TestFormApply(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	form := formmodel.New(fs)
+
+	err := form.Apply(map[string][]string{
+		"listen-addr": {":9090"},
+		"tag":         {"x", "y"},
+		"verbose":     {"true"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := fs.MustGetString("listen-addr"); got != ":9090" {
+		t.Fatalf("expected :9090, got %q", got)
+	}
+	if got := fs.MustGetStringSlice("tag"); !reflect.DeepEqual([]string{"x", "y"}, got) {
+		t.Fatalf("expected [x y], got %q", got)
+	}
+	if got := fs.MustGetBool("verbose"); !got {
+		t.Fatal("expected verbose to be set")
+	}
+
+	if form.Field("retries") == nil || form.Field("unknown") != nil {
+		t.Fatal("Field did not look up fields by name")
+	}
+
+	err = formmodel.New(newFlagSet()).Apply(map[string][]string{"retries": {"256"}})
+	if err == nil {
+		t.Fatal("expected an error for an out of range value")
+	}
+
+	err = formmodel.New(newFlagSet()).Apply(nil)
+	if err == nil || err.Error() != `required flag(s) "--listen-addr" not set` {
+		t.Fatalf("expected a required flag error, got %v", err)
+	}
+}
+
+func TestFormApplyReplacesParsedValues(t *testing.T) {
+	t.Parallel()
+
+	fs := newFlagSet()
+	if err := fs.Parse([]string{"--listen-addr=:80", "--tag=a", "--limit=mem=1"}); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	err := formmodel.New(fs).Apply(map[string][]string{
+		"tag":   {"a", "b"},
+		"limit": {"cpu=4"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if got := fs.MustGetStringSlice("tag"); !reflect.DeepEqual([]string{"a", "b"}, got) {
+		t.Fatalf("expected [a b], got %q", got)
+	}
+	if got := fs.MustGetStringToInt("limit"); !reflect.DeepEqual(map[string]int{"cpu": 4}, got) {
+		t.Fatalf("expected map[cpu:4], got %v", got)
+	}
+}
@@ -86,7 +86,7 @@ func FromGoFlag(goflag *goflag.Flag) *Flag {
 
 // AddGoFlag will add the given *flag.Flag to the zflag.FlagSet
 func (fs *FlagSet) AddGoFlag(goflag *goflag.Flag) {
-	if fs.Lookup(goflag.Name) != nil {
+	if fs.lookup(fs.normalizeFlagName(goflag.Name)) != nil {
 		return
 	}
 	newflag := FromGoFlag(goflag)
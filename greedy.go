@@ -0,0 +1,48 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import "fmt"
+
+// OptGreedy makes a slice flag consume the arguments following its value,
+// until the next flag or --, e.g. --files a.txt b.txt c.txt, instead of
+// requiring the flag to be repeated. Each argument is appended separately.
+func OptGreedy() Opt {
+	return func(f *Flag) error {
+		if _, ok := f.Value.(SliceValue); !ok {
+			return fmt.Errorf("greedy consumption is only supported for slice flags, but %q is not a slice flag", f.Name)
+		}
+		f.Greedy = true
+		return nil
+	}
+}
+
+// isGreedyValue reports whether arg is consumed by a greedy flag.
+func isGreedyValue(arg string) bool {
+	return len(arg) > 0 && arg[0] != '-'
+}
+
+// consumeGreedy passes the arguments following the value of flag to fn while
+// they are not flags, if flag has Greedy set. Values of the negated form of
+// the flag are negated as well.
+func (fs *FlagSet) consumeGreedy(flag *Flag, negated bool, args []string, fn parseFunc) ([]string, error) {
+	if !flag.Greedy {
+		return args, nil
+	}
+	for len(args) > 0 && isGreedyValue(args[0]) {
+		value := args[0]
+		if negatable, ok := flag.Value.(NegatableValue); ok && negated {
+			negatedValue, err := negatable.Negate(value)
+			if err != nil {
+				return args, fs.failf("%w", NewInvalidArgumentError(err, flag, value))
+			}
+			value = negatedValue
+		}
+		if err := fn(flag, value); err != nil {
+			return args, fs.failf("%w", err)
+		}
+		args = args[1:]
+	}
+	return args, nil
+}
@@ -0,0 +1,116 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptGreedy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         []string
+		expectedErr   string
+		expectedFiles []string
+		expectedArgs  []string
+	}{
+		{
+			name:          "long flag",
+			input:         []string{"--files", "a.txt", "b.txt", "c.txt", "-v", "pos"},
+			expectedFiles: []string{"a.txt", "b.txt", "c.txt"},
+			expectedArgs:  []string{"pos"},
+		},
+		{
+			name:          "inline value",
+			input:         []string{"--files=a.txt", "b.txt"},
+			expectedFiles: []string{"a.txt", "b.txt"},
+			expectedArgs:  []string{},
+		},
+		{
+			name:          "shorthand",
+			input:         []string{"-vf", "a.txt", "b.txt", "--", "c.txt"},
+			expectedFiles: []string{"a.txt", "b.txt"},
+			expectedArgs:  []string{"c.txt"},
+		},
+		{
+			name:          "repeated",
+			input:         []string{"-f", "a.txt", "b.txt", "--files", "c.txt"},
+			expectedFiles: []string{"a.txt", "b.txt", "c.txt"},
+			expectedArgs:  []string{},
+		},
+		{
+			name:        "invalid element",
+			input:       []string{"--ports", "80", "http"},
+			expectedErr: `invalid argument "http" for "--ports" flag: element 2: strconv.Atoi: parsing "http": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			files := fs.StringSlice("files", nil, "usage", zflag.OptShorthand('f'), zflag.OptGreedy())
+			fs.IntSlice("ports", nil, "usage", zflag.OptGreedy())
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedFiles, *files)
+			assertDeepEqual(t, test.expectedArgs, fs.Args())
+		})
+	}
+}
+
+func TestOptGreedyNegated(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithOutput(ioutil.Discard))
+	fs.ToggleSlice("feature", nil, "usage", zflag.OptGreedy())
+
+	assertNoErr(t, fs.Parse([]string{"--feature", "a", "b", "--no-feature", "c", "d"}))
+	assertDeepEqual(t, []zflag.Toggle{{Name: "a", Enabled: true}, {Name: "b", Enabled: true}, {Name: "c"}, {Name: "d"}}, fs.MustGetToggleSlice("feature"))
+}
+
+func TestOptGreedyClassifyAndHelpJSON(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithOutput(ioutil.Discard))
+	fs.StringSlice("files", nil, "usage", zflag.OptGreedy())
+
+	tokens := fs.Classify([]string{"--files", "a", "b", "--", "c"})
+	kinds := make([]zflag.TokenKind, 0, len(tokens))
+	for _, token := range tokens {
+		kinds = append(kinds, token.Kind)
+	}
+	assertDeepEqual(t, []zflag.TokenKind{zflag.TokenLongFlag, zflag.TokenValue, zflag.TokenValue, zflag.TokenTerminator, zflag.TokenPositional}, kinds)
+
+	var out bytes.Buffer
+	assertNoErr(t, fs.WriteHelpJSON(&out))
+	proxy, err := zflag.ReadHelpJSON(&out)
+	assertNoErr(t, err)
+	assertEqual(t, true, proxy.Lookup("files").Greedy)
+}
+
+func TestOptGreedyInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+
+	defer assertPanic(t)()
+	fs.String("file", "", "usage", zflag.OptGreedy())
+}
@@ -0,0 +1,250 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Group is a handle to a flag group, returned by FlagSet.Group. Flags defined
+// through the handle are assigned to the group, so OptGroup does not need to
+// be repeated for each of them, and constraints on the flags of the group are
+// declared on the handle.
+//
+// The handle only provides helpers for the string, []string, bool, int and
+// time.Duration types, and Var for flags with a custom Value. Flags of other
+// types are defined on the FlagSet with OptGroup(g.Name()) instead.
+type Group struct {
+	fs   *FlagSet
+	name string
+}
+
+// Group returns a handle to the flag group name, setting its description
+// unless desc is empty. See SetGroupDescription.
+func (fs *FlagSet) Group(name, desc string) *Group {
+	if desc != "" {
+		fs.SetGroupDescription(name, desc)
+	}
+	return &Group{fs: fs, name: name}
+}
+
+// Name returns the name of the group.
+func (g *Group) Name() string {
+	return g.name
+}
+
+// Flags returns the flags of the group, in lexicographical order.
+func (g *Group) Flags() []*Flag {
+	var flags []*Flag
+	g.fs.VisitAll(func(flag *Flag) {
+		if flag.Group == g.name {
+			flags = append(flags, flag)
+		}
+	})
+	return flags
+}
+
+// MarkMutuallyExclusive declares that at most one of the named flags may be
+// set, which is checked when the FlagSet is parsed. Without names, it applies
+// to all flags of the group, including those defined later. It panics if one
+// of the names is not defined.
+func (g *Group) MarkMutuallyExclusive(names ...string) {
+	for _, name := range names {
+		if g.fs.Lookup(name) == nil {
+			panic(NewUnknownFlagError(name))
+		}
+	}
+	g.fs.exclusiveFlags = append(g.fs.exclusiveFlags, exclusiveFlags{
+		group: g.name,
+		names: append([]string(nil), names...),
+	})
+}
+
+// Var defines a flag in the group, see FlagSet.Var.
+func (g *Group) Var(value Value, name, usage string, opts ...Opt) *Flag {
+	return g.fs.Var(value, name, usage, g.opts(opts)...)
+}
+
+// StringVar defines a string flag in the group, see FlagSet.StringVar.
+func (g *Group) StringVar(p *string, name string, value string, usage string, opts ...Opt) {
+	g.fs.StringVar(p, name, value, usage, g.opts(opts)...)
+}
+
+// String defines a string flag in the group, see FlagSet.String.
+func (g *Group) String(name string, value string, usage string, opts ...Opt) *string {
+	return g.fs.String(name, value, usage, g.opts(opts)...)
+}
+
+// StringSliceVar defines a []string flag in the group, see FlagSet.StringSliceVar.
+func (g *Group) StringSliceVar(p *[]string, name string, value []string, usage string, opts ...Opt) {
+	g.fs.StringSliceVar(p, name, value, usage, g.opts(opts)...)
+}
+
+// StringSlice defines a []string flag in the group, see FlagSet.StringSlice.
+func (g *Group) StringSlice(name string, value []string, usage string, opts ...Opt) *[]string {
+	return g.fs.StringSlice(name, value, usage, g.opts(opts)...)
+}
+
+// BoolVar defines a bool flag in the group, see FlagSet.BoolVar.
+func (g *Group) BoolVar(p *bool, name string, value bool, usage string, opts ...Opt) {
+	g.fs.BoolVar(p, name, value, usage, g.opts(opts)...)
+}
+
+// Bool defines a bool flag in the group, see FlagSet.Bool.
+func (g *Group) Bool(name string, value bool, usage string, opts ...Opt) *bool {
+	return g.fs.Bool(name, value, usage, g.opts(opts)...)
+}
+
+// IntVar defines an int flag in the group, see FlagSet.IntVar.
+func (g *Group) IntVar(p *int, name string, value int, usage string, opts ...Opt) {
+	g.fs.IntVar(p, name, value, usage, g.opts(opts)...)
+}
+
+// Int defines an int flag in the group, see FlagSet.Int.
+func (g *Group) Int(name string, value int, usage string, opts ...Opt) *int {
+	return g.fs.Int(name, value, usage, g.opts(opts)...)
+}
+
+// DurationVar defines a time.Duration flag in the group, see FlagSet.DurationVar.
+func (g *Group) DurationVar(p *time.Duration, name string, value time.Duration, usage string, opts ...Opt) {
+	g.fs.DurationVar(p, name, value, usage, g.opts(opts)...)
+}
+
+// Duration defines a time.Duration flag in the group, see FlagSet.Duration.
+func (g *Group) Duration(name string, value time.Duration, usage string, opts ...Opt) *time.Duration {
+	return g.fs.Duration(name, value, usage, g.opts(opts)...)
+}
+
+// opts prepends OptGroup to opts.
+func (g *Group) opts(opts []Opt) []Opt {
+	return append([]Opt{OptGroup(g.name)}, opts...)
+}
+
+// exclusiveFlags is a set of mutually exclusive flags. If names is empty, it
+// consists of all flags of the group.
+type exclusiveFlags struct {
+	group string
+	names []string
+}
+
+// flags returns the mutually exclusive flags.
+func (e exclusiveFlags) flags(fs *FlagSet) []*Flag {
+	if len(e.names) == 0 {
+		return fs.Group(e.group, "").Flags()
+	}
+	var flags []*Flag
+	for _, name := range e.names {
+		flags = append(flags, fs.Lookup(name))
+	}
+	return flags
+}
+
+func (e exclusiveFlags) validate(fs *FlagSet) error {
+	var set MutuallyExclusiveFlagsError
+	for _, flag := range e.flags(fs) {
+		if flag.Changed {
+			set = append(set, getFlagWithDashes(flag.Name))
+		}
+	}
+	if len(set) > 1 {
+		return set
+	}
+	return nil
+}
+
+// SetGroupOrder sets the order in which flag groups are returned by Groups,
+// and therefore printed in usage output. Groups that are not part of order are
+// placed after the ordered groups, sorted alphabetically. The empty group
+// (unassigned) may be included in order to position it, otherwise it is
+// always placed at the beginning.
+func (fs *FlagSet) SetGroupOrder(order []string) {
+	fs.groupOrder = order
+}
+
+// SetGroupDescription sets a description for group, which is printed
+// below the group heading in usage output.
+func (fs *FlagSet) SetGroupDescription(group, desc string) {
+	if fs.groupDescriptions == nil {
+		fs.groupDescriptions = make(map[string]string)
+	}
+	fs.groupDescriptions[group] = desc
+}
+
+// GroupDescription returns the description of group, as set by
+// SetGroupDescription.
+func (fs *FlagSet) GroupDescription(group string) string {
+	return fs.groupDescriptions[group]
+}
+
+// Groups return an array of unique flag groups. Groups are ordered as set by
+// SetGroupOrder, and the remainder is sorted alphabetically. Empty group
+// (unassigned) is placed at the beginning, unless it is part of the group order.
+func (fs *FlagSet) Groups() []string {
+	groupsMap := make(map[string]bool)
+	groups := make([]string, 0)
+	fs.VisitAll(func(flag *Flag) {
+		if _, ok := groupsMap[flag.Group]; !ok {
+			groupsMap[flag.Group] = true
+			if flag.Group != "" {
+				groups = append(groups, flag.Group)
+			}
+		}
+	})
+	sort.Strings(groups)
+
+	ordered := make([]string, 0, len(groupsMap))
+	if groupsMap[""] && !containsString(fs.groupOrder, "") {
+		ordered = append(ordered, "")
+	}
+	for _, group := range fs.groupOrder {
+		if groupsMap[group] {
+			ordered = append(ordered, group)
+			delete(groupsMap, group)
+		}
+	}
+	for _, group := range groups {
+		if groupsMap[group] {
+			ordered = append(ordered, group)
+		}
+	}
+
+	return ordered
+}
+
+// HelpGroup returns the flag group requested using --help=<group> during the
+// last parse, or an empty string if help was not requested for a group.
+// This is useful for custom Usage functions, to only print the usage of the
+// requested group.
+func (fs *FlagSet) HelpGroup() string {
+	return fs.helpGroup
+}
+
+// findGroup returns the group matching name case-insensitively.
+func (fs *FlagSet) findGroup(name string) (string, bool) {
+	for _, group := range fs.Groups() {
+		if group != "" && strings.EqualFold(group, name) {
+			return group, true
+		}
+	}
+	return "", false
+}
+
+func (fs *FlagSet) groupHeading(group string) string {
+	heading := group + ":\n"
+	if desc := fs.GroupDescription(group); desc != "" {
+		heading += "  " + strings.ReplaceAll(desc, "\n", "\n  ") + "\n"
+	}
+	return heading
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,248 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestGroupOrder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		order    []string
+		expected []string
+	}{
+		{
+			name:     "alphabetical by default",
+			expected: []string{"", "alpha", "beta", "gamma"},
+		},
+		{
+			name:     "ordered groups first",
+			order:    []string{"gamma", "alpha"},
+			expected: []string{"", "gamma", "alpha", "beta"},
+		},
+		{
+			name:     "ungrouped can be positioned",
+			order:    []string{"beta", ""},
+			expected: []string{"beta", "", "alpha", "gamma"},
+		},
+		{
+			name:     "unknown groups are ignored",
+			order:    []string{"delta", "beta"},
+			expected: []string{"", "beta", "alpha", "gamma"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.Bool("ungrouped", false, "usage")
+			f.Bool("b", false, "usage", zflag.OptGroup("beta"))
+			f.Bool("g", false, "usage", zflag.OptGroup("gamma"))
+			f.Bool("a", false, "usage", zflag.OptGroup("alpha"))
+			f.SetGroupOrder(test.order)
+
+			assertDeepEqual(t, test.expected, f.Groups())
+		})
+	}
+}
+
+func TestGroupDescriptionInDefaults(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Bool("verbose", false, "verbose output")
+	f.String("host", "", "host to connect to", zflag.OptGroup("network"))
+	f.Int("retries", 0, "number of retries", zflag.OptGroup("behaviour"))
+	f.SetGroupOrder([]string{"network"})
+	f.SetGroupDescription("network", "Settings for connecting\nto the server.")
+
+	assertEqual(t, "Settings for connecting\nto the server.", f.GroupDescription("network"))
+	assertEqual(t, "", f.GroupDescription("behaviour"))
+
+	f.PrintDefaults()
+	expected := `      --verbose       verbose output
+
+network:
+  Settings for connecting
+  to the server.
+      --host string   host to connect to
+
+behaviour:
+      --retries int   number of retries
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+func TestGroupOrderUngroupedInDefaults(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Bool("verbose", false, "verbose output")
+	f.String("host", "", "host to connect to", zflag.OptGroup("Net"))
+	f.Int("retries", 0, "number of retries", zflag.OptGroup("Behaviour"))
+	f.SetGroupOrder([]string{"Net", ""})
+
+	f.PrintDefaults()
+	expected := `
+Net:
+      --host string   host to connect to
+
+      --verbose       verbose output
+
+Behaviour:
+      --retries int   number of retries
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+func TestHelpGroup(t *testing.T) {
+	t.Parallel()
+
+	newFlagSet := func(buf *bytes.Buffer) *zflag.FlagSet {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(buf)
+		f.Bool("verbose", false, "verbose output")
+		f.String("host", "", "host to connect to", zflag.OptGroup("Network"))
+		f.Int("retries", 0, "number of retries", zflag.OptGroup("Behaviour"))
+		f.SetGroupDescription("Network", "Connection settings.")
+		return f
+	}
+
+	var buf bytes.Buffer
+	f := newFlagSet(&buf)
+	err := f.Parse([]string{"--help=network"})
+	assertEqual(t, zflag.ErrHelp, err)
+	assertEqual(t, "Network", f.HelpGroup())
+	expected := `Usage of test:
+Network:
+  Connection settings.
+      --host string   host to connect to
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+
+	buf.Reset()
+	err = f.Parse([]string{"--help=unknown"})
+	assertErrMsg(t, "unknown help group: unknown", err)
+
+	buf.Reset()
+	err = f.Parse([]string{"--help"})
+	assertEqual(t, zflag.ErrHelp, err)
+	assertEqual(t, "", f.HelpGroup())
+}
+
+func TestGroupHandle(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	g := f.Group("network", "Settings for connecting to the server.")
+	host := g.String("host", "", "host to connect to")
+	g.Duration("timeout", time.Second, "timeout", zflag.OptShorthand('t'))
+	g.Var(new(flagVar), "proxy", "proxy servers")
+	f.IntSlice("ports", nil, "ports", zflag.OptGroup(g.Name()))
+	f.Bool("verbose", false, "verbose output")
+
+	assertEqual(t, "network", g.Name())
+	assertEqual(t, "Settings for connecting to the server.", f.GroupDescription("network"))
+	assertEqual(t, "network", f.Lookup("host").Group)
+	assertEqual(t, "network", f.Lookup("timeout").Group)
+	assertEqual(t, 't', f.Lookup("timeout").Shorthand)
+	assertEqual(t, "", f.Lookup("verbose").Group)
+
+	flags := g.Flags()
+	assertEqual(t, 4, len(flags))
+	assertEqual(t, "host", flags[0].Name)
+	assertEqual(t, "ports", flags[1].Name)
+	assertEqual(t, "proxy", flags[2].Name)
+	assertEqual(t, "timeout", flags[3].Name)
+
+	assertNoErr(t, f.Parse([]string{"--host", "example.com"}))
+	assertEqual(t, "example.com", *host)
+}
+
+func TestGroupMarkMutuallyExclusive(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		names       []string
+		input       []string
+		expectedErr string
+	}{
+		{
+			name:  "one flag set",
+			names: []string{"json", "yaml"},
+			input: []string{"--json", "--color"},
+		},
+		{
+			name:        "named flags set together",
+			names:       []string{"json", "yaml"},
+			input:       []string{"--yaml", "--json"},
+			expectedErr: `flags "--json", "--yaml" are mutually exclusive, but were set together`,
+		},
+		{
+			name:  "unnamed flag not restricted",
+			names: []string{"json", "yaml"},
+			input: []string{"--yaml", "--color"},
+		},
+		{
+			name:        "whole group",
+			input:       []string{"--yaml", "--color"},
+			expectedErr: `flags "--color", "--yaml" are mutually exclusive, but were set together`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			g := f.Group("output", "")
+			g.Bool("json", false, "json output")
+			g.Bool("yaml", false, "yaml output")
+			g.MarkMutuallyExclusive(test.names...)
+			g.Bool("color", false, "colored output")
+
+			err := f.Parse(test.input)
+			if test.expectedErr == "" {
+				assertNoErr(t, err)
+				return
+			}
+			assertErrMsg(t, test.expectedErr, err)
+			var exclusiveErr zflag.MutuallyExclusiveFlagsError
+			if !errors.As(err, &exclusiveErr) {
+				t.Fatalf("expected a MutuallyExclusiveFlagsError, got %T", err)
+			}
+		})
+	}
+}
+
+func TestGroupMarkMutuallyExclusiveUnknownFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	g := f.Group("output", "")
+	g.Bool("json", false, "json output")
+
+	defer assertPanic(t)()
+	g.MarkMutuallyExclusive("json", "yaml")
+}
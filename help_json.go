@@ -0,0 +1,343 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+)
+
+// ChoicesValue is implemented by values that only accept a fixed set of
+// values, which are exported as the choices of a flag by WriteHelpJSON.
+type ChoicesValue interface {
+	Value
+	Choices() []string
+}
+
+// helpJSON is the document written by WriteHelpJSON.
+type helpJSON struct {
+	Name              string          `json:"name"`
+	NegativePrefix    string          `json:"negativePrefix,omitempty"`
+	Groups            []helpJSONGroup `json:"groups,omitempty"`
+	Flags             []helpJSONFlag  `json:"flags"`
+	MutuallyExclusive [][]string      `json:"mutuallyExclusive,omitempty"`
+}
+
+type helpJSONGroup struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+type helpJSONFlag struct {
+	Name          string              `json:"name"`
+	Aliases       []string            `json:"aliases,omitempty"`
+	Shorthand     string              `json:"shorthand,omitempty"`
+	NegShorthand  string              `json:"negativeShorthand,omitempty"`
+	AtomicShort   string              `json:"atomicShorthand,omitempty"`
+	ShorthandOnly bool                `json:"shorthandOnly,omitempty"`
+	Usage         string              `json:"usage"`
+	Type          string              `json:"type"`
+	Default       string              `json:"default,omitempty"`
+	DefaultText   string              `json:"defaultText,omitempty"`
+	NoArgDefault  string              `json:"noArgDefault,omitempty"`
+	Group         string              `json:"group,omitempty"`
+	Arity         helpJSONArity       `json:"arity"`
+	Repeatable    bool                `json:"repeatable,omitempty"`
+	Delimiter     string              `json:"delimiter,omitempty"`
+	Separator     string              `json:"separator,omitempty"`
+	Choices       []string            `json:"choices,omitempty"`
+	Negatable     bool                `json:"negatable,omitempty"`
+	Env           string              `json:"env,omitempty"`
+	ConfigKey     string              `json:"configKey,omitempty"`
+	Secret        bool                `json:"secret,omitempty"`
+	Deprecated    string              `json:"deprecated,omitempty"`
+	Constraints   helpJSONConstraints `json:"constraints"`
+}
+
+// helpJSONArity is the number of values a flag takes per occurrence. Max is
+// -1 for greedy flags, which take any number of values.
+type helpJSONArity struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+type helpJSONConstraints struct {
+	Required      bool               `json:"required,omitempty"`
+	RequiredIf    *helpJSONCondition `json:"requiredIf,omitempty"`
+	Requires      []string           `json:"requires,omitempty"`
+	ConflictsWith []string           `json:"conflictsWith,omitempty"`
+}
+
+// helpJSONCondition makes a flag required if the flag Flag is set to Value,
+// see OptRequiredIfFlagSet.
+type helpJSONCondition struct {
+	Flag  string `json:"flag"`
+	Value string `json:"value"`
+}
+
+// WriteHelpJSON writes a machine-readable description of all visible flags
+// as JSON to w, so external tools can generate input forms for a program.
+// This is what --help=json prints, to standard output unless SetOutput was
+// called.
+//
+// Next to the definition of each flag, the arity and how repeated values are
+// combined are described. Values can report the choices they accept by
+// implementing ChoicesValue, and the delimiter used to split a single value
+// into multiple elements by implementing a Delimiter() string method.
+// Defaults of secret flags are left out.
+//
+// The constraints checked by Validate are described as well: required flags,
+// the flags each flag requires or conflicts with, and the sets of mutually
+// exclusive flags. Of the flags required under a condition, only those
+// defined with OptRequiredIfFlagSet are described, as the functions passed
+// to OptRequiredIf cannot be.
+func (fs *FlagSet) WriteHelpJSON(w io.Writer) error {
+	doc := helpJSON{
+		Name:           fs.name,
+		NegativePrefix: fs.NegativePrefix,
+		Flags:          []helpJSONFlag{},
+	}
+
+	for _, group := range fs.Groups() {
+		if group != "" {
+			doc.Groups = append(doc.Groups, helpJSONGroup{Name: group, Description: fs.GroupDescription(group)})
+		}
+	}
+
+	fs.VisitAll(func(flag *Flag) {
+		if fs.isUsageHidden(flag) {
+			return
+		}
+		f := newHelpJSONFlag(flag)
+		f.Constraints.Requires = fs.visibleFlagNames(flag.Requires)
+		f.Constraints.ConflictsWith = fs.visibleFlagNames(flag.ConflictsWith)
+		doc.Flags = append(doc.Flags, f)
+	})
+
+	for _, exclusive := range fs.exclusiveFlags {
+		var names []string
+		for _, flag := range exclusive.flags(fs) {
+			names = append(names, flag.Name)
+		}
+		if names = fs.visibleFlagNames(names); len(names) > 1 {
+			doc.MutuallyExclusive = append(doc.MutuallyExclusive, names)
+		}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(doc)
+}
+
+// visibleFlagNames returns the names of the flags out of names which are
+// shown in usage, leaving out the others as they are not part of the JSON.
+func (fs *FlagSet) visibleFlagNames(names []string) []string {
+	var visible []string
+	for _, name := range names {
+		if flag := fs.Lookup(name); flag != nil && !fs.isUsageHidden(flag) {
+			visible = append(visible, flag.Name)
+		}
+	}
+	return visible
+}
+
+// helpJSONOutput returns the writer --help=json prints to, which is the
+// output of the FlagSet if set, and standard output otherwise, so the JSON
+// can be piped to other programs.
+func (fs *FlagSet) helpJSONOutput() io.Writer {
+	if fs.output == nil {
+		return os.Stdout
+	}
+	return fs.output
+}
+
+func newHelpJSONFlag(flag *Flag) helpJSONFlag {
+	varname, usage := UnquoteUsage(flag)
+	f := helpJSONFlag{
+		Name:          flag.Name,
+		Aliases:       flag.Aliases,
+		AtomicShort:   flag.AtomicShorthand,
+		ShorthandOnly: flag.ShorthandOnly,
+		Usage:         usage,
+		Type:          strings.TrimSpace(varname),
+		Group:         flag.Group,
+		Arity:         helpJSONArity{Min: 1, Max: 1},
+		Env:           flag.EnvVar,
+		ConfigKey:     flag.ConfigKey,
+		Secret:        flag.Secret,
+		Deprecated:    flag.Deprecated,
+		DefaultText:   flag.DefaultText,
+		Constraints:   helpJSONConstraints{Required: flag.Required},
+	}
+	if cond := flag.requiredOn; cond != nil {
+		f.Constraints.RequiredIf = &helpJSONCondition{Flag: cond.name, Value: cond.value}
+	}
+	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
+		f.Shorthand = string(flag.Shorthand)
+	}
+	if flag.NegativeShorthand != 0 {
+		f.NegShorthand = string(flag.NegativeShorthand)
+	}
+	if typed, ok := flag.Value.(Typed); ok {
+		f.Type = typed.Type()
+	}
+	if !flag.Secret {
+		f.Default = flag.DefValue
+		f.NoArgDefault = flag.NoArgDefault
+	}
+
+	_, isBoolFlag := flag.Value.(BoolFlag)
+	_, isOptional := flag.Value.(OptionalValue)
+	if isBoolFlag || isOptional || flag.NoArgDefault != "" {
+		f.Arity.Min = 0
+	}
+	if flag.Greedy {
+		f.Arity.Max = -1
+	}
+
+	f.Negatable = isNegatable(flag)
+
+	f.Repeatable = isRepeatable(flag)
+	if getter, ok := flag.Value.(Getter); ok {
+		if v := reflect.ValueOf(getter.Get()); v.IsValid() && v.Kind() == reflect.Map {
+			f.Repeatable = true
+			f.Separator = "="
+		}
+	}
+
+	if delimited, ok := flag.Value.(interface{ Delimiter() string }); ok {
+		f.Delimiter = delimited.Delimiter()
+	}
+	if choices, ok := flag.Value.(ChoicesValue); ok {
+		f.Choices = choices.Choices()
+	}
+
+	return f
+}
+
+// ReadHelpJSON reads the document written by WriteHelpJSON, e.g. the
+// --help=json output of another program built with zflag, and returns a
+// proxy FlagSet defining the same flags, so wrappers can validate the flags
+// of a program they run and forward them with ToArgs. The values of the
+// proxy flags are stored as strings, and only checked against the choices
+// of the original flags; Get returns the last value, or all values for
+// repeatable flags. The constraints of the original flags are checked when
+// the proxy is parsed. Environment variables and config keys are not bound.
+func ReadHelpJSON(r io.Reader) (*FlagSet, error) {
+	var doc helpJSON
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unable to decode help JSON: %w", err)
+	}
+
+	fs := NewFlagSet(doc.Name, ContinueOnError)
+	fs.NegativePrefix = doc.NegativePrefix
+	for _, group := range doc.Groups {
+		fs.SetGroupDescription(group.Name, group.Description)
+	}
+
+	// definition errors are returned rather than printed
+	fs.SetOutput(ioutil.Discard)
+	for _, f := range doc.Flags {
+		if err := fs.defineProxyFlag(f); err != nil {
+			return nil, err
+		}
+	}
+	for _, names := range doc.MutuallyExclusive {
+		if err := fs.markProxyExclusive(names); err != nil {
+			return nil, err
+		}
+	}
+	fs.SetOutput(nil)
+	return fs, nil
+}
+
+func (fs *FlagSet) defineProxyFlag(f helpJSONFlag) (err error) {
+	opts := []Opt{OptGroup(f.Group)}
+	if f.Shorthand != "" {
+		opts = append(opts, OptShorthandStr(f.Shorthand))
+	}
+	if f.ShorthandOnly {
+		opts = append(opts, OptShorthandOnly())
+	}
+	if f.Negatable {
+		opts = append(opts, OptAddNegative())
+	}
+	if f.NegShorthand != "" {
+		opts = append(opts, OptNegativeShorthand([]rune(f.NegShorthand)[0]))
+	}
+	if f.Arity.Max < 0 {
+		opts = append(opts, OptGreedy())
+	}
+	if f.AtomicShort != "" {
+		opts = append(opts, OptAtomicShorthand(f.AtomicShort))
+	}
+	if f.NoArgDefault != "" {
+		opts = append(opts, OptNoArgDefault(f.NoArgDefault))
+	}
+	if f.DefaultText != "" {
+		opts = append(opts, OptDefaultText(f.DefaultText))
+	}
+	if f.Secret {
+		opts = append(opts, OptSecret())
+	}
+	if f.Deprecated != "" {
+		opts = append(opts, OptDeprecated(f.Deprecated))
+	}
+	if f.Constraints.Required {
+		opts = append(opts, OptRequired())
+	}
+	if cond := f.Constraints.RequiredIf; cond != nil {
+		opts = append(opts, OptRequiredIfFlagSet(cond.Flag, cond.Value))
+	}
+	if len(f.Constraints.Requires) > 0 {
+		opts = append(opts, OptRequires(f.Constraints.Requires...))
+	}
+	if len(f.Constraints.ConflictsWith) > 0 {
+		opts = append(opts, OptConflictsWith(f.Constraints.ConflictsWith...))
+	}
+	for _, alias := range f.Aliases {
+		opts = append(opts, OptAlias(alias))
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to define flag %q: %v", f.Name, r)
+		}
+	}()
+	fs.Var(newProxyFlagValue(f), f.Name, f.Usage, opts...)
+	return nil
+}
+
+func (fs *FlagSet) markProxyExclusive(names []string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("unable to mark flags %q mutually exclusive: %v", names, r)
+		}
+	}()
+	fs.Group("", "").MarkMutuallyExclusive(names...)
+	return nil
+}
+
+// newProxyFlagValue returns a proxy value accepting the same forms as the
+// original flag described by f.
+func newProxyFlagValue(f helpJSONFlag) Value {
+	pv := newProxyValue(f)
+	optional := f.Arity.Min == 0 && f.NoArgDefault == ""
+	switch {
+	case optional && f.Type == "bool":
+		return &proxyBoolValue{pv}
+	case optional && f.Repeatable:
+		return &proxyOptionalSliceValue{&proxySliceValue{pv}}
+	case optional:
+		return &proxyOptionalValue{pv}
+	case f.Repeatable:
+		return &proxySliceValue{pv}
+	}
+	return pv
+}
@@ -0,0 +1,282 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type choiceValue struct {
+	value   string
+	choices []string
+}
+
+func (c *choiceValue) String() string     { return c.value }
+func (c *choiceValue) Set(s string) error { c.value = s; return nil }
+func (c *choiceValue) Type() string       { return "choice" }
+func (c *choiceValue) Choices() []string  { return c.choices }
+func (c *choiceValue) Delimiter() string  { return "," }
+
+func TestWriteHelpJSON(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetGroupDescription("Network", "Connection settings.")
+	f.Bool("verbose", false, "verbose output", zflag.OptShorthand('v'), zflag.OptAddNegative())
+	f.String("host", "localhost", "host to connect to", zflag.OptGroup("Network"), zflag.OptEnv("HOST"), zflag.OptRequired())
+	f.String("token", "s3cr3t", "the `secret` token", zflag.OptSecret(), zflag.OptConfigKey("auth.token"))
+	f.StringSlice("tag", nil, "tags")
+	f.StringToString("label", nil, "labels")
+	f.Count("level", "level")
+	f.Var(&choiceValue{value: "json", choices: []string{"json", "text"}}, "format", "output format")
+	f.String("hidden", "", "hidden", zflag.OptHidden())
+
+	var buf bytes.Buffer
+	err := f.WriteHelpJSON(&buf)
+	assertNoErr(t, err)
+
+	expected := `{
+  "name": "test",
+  "groups": [
+    {
+      "name": "Network",
+      "description": "Connection settings."
+    }
+  ],
+  "flags": [
+    {
+      "name": "format",
+      "usage": "output format",
+      "type": "choice",
+      "default": "json",
+      "arity": {
+        "min": 1,
+        "max": 1
+      },
+      "delimiter": ",",
+      "choices": [
+        "json",
+        "text"
+      ],
+      "constraints": {}
+    },
+    {
+      "name": "host",
+      "usage": "host to connect to",
+      "type": "string",
+      "default": "localhost",
+      "group": "Network",
+      "arity": {
+        "min": 1,
+        "max": 1
+      },
+      "env": "HOST",
+      "constraints": {
+        "required": true
+      }
+    },
+    {
+      "name": "label",
+      "usage": "labels",
+      "type": "stringToString",
+      "default": "[]",
+      "arity": {
+        "min": 1,
+        "max": 1
+      },
+      "repeatable": true,
+      "separator": "=",
+      "constraints": {}
+    },
+    {
+      "name": "level",
+      "usage": "level",
+      "type": "count",
+      "default": "0",
+      "arity": {
+        "min": 0,
+        "max": 1
+      },
+      "repeatable": true,
+      "constraints": {}
+    },
+    {
+      "name": "tag",
+      "usage": "tags",
+      "type": "stringSlice",
+      "default": "[]",
+      "arity": {
+        "min": 1,
+        "max": 1
+      },
+      "repeatable": true,
+      "constraints": {}
+    },
+    {
+      "name": "token",
+      "usage": "the secret token",
+      "type": "string",
+      "arity": {
+        "min": 1,
+        "max": 1
+      },
+      "configKey": "auth.token",
+      "secret": true,
+      "constraints": {}
+    },
+    {
+      "name": "verbose",
+      "shorthand": "v",
+      "usage": "verbose output",
+      "type": "bool",
+      "default": "false",
+      "arity": {
+        "min": 0,
+        "max": 1
+      },
+      "negatable": true,
+      "constraints": {}
+    }
+  ]
+}
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+func TestHelpJSONFlag(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Int("port", 80, "port")
+
+	err := f.Parse([]string{"--help=json"})
+	assertEqual(t, zflag.ErrHelp, err)
+
+	var doc struct {
+		Name  string
+		Flags []struct {
+			Name    string
+			Default string
+		}
+	}
+	err = json.Unmarshal(buf.Bytes(), &doc)
+	assertNoErr(t, err)
+	assertEqual(t, "test", doc.Name)
+	assertEqual(t, 1, len(doc.Flags))
+	assertEqual(t, "port", doc.Flags[0].Name)
+	assertEqual(t, "80", doc.Flags[0].Default)
+}
+
+func TestReadHelpJSON(t *testing.T) {
+	t.Parallel()
+
+	original := zflag.NewFlagSet("tool", zflag.ContinueOnError)
+	original.SetGroupDescription("Network", "Connection settings.")
+	original.Bool("verbose", false, "verbose output", zflag.OptShorthand('v'), zflag.OptAddNegative())
+	original.String("host", "localhost", "host to connect to", zflag.OptGroup("Network"), zflag.OptRequired(), zflag.OptAlias("server"))
+	original.StringSlice("tag", nil, "tags", zflag.OptShorthand('t'))
+	original.Count("level", "level", zflag.OptShorthand('l'))
+	original.Var(&choiceValue{value: "json", choices: []string{"json", "text"}}, "format", "output format")
+	original.String("mode", "auto", "mode", zflag.OptNoArgDefault("fast"))
+
+	var buf bytes.Buffer
+	assertNoErr(t, original.WriteHelpJSON(&buf))
+	proxy, err := zflag.ReadHelpJSON(&buf)
+	assertNoErr(t, err)
+	proxy.SetOutput(ioutil.Discard)
+
+	assertEqual(t, "tool", proxy.Name())
+	assertEqual(t, "Connection settings.", proxy.GroupDescription("Network"))
+	assertEqual(t, original.FlagUsages(), proxy.FlagUsages())
+
+	args := []string{"--no-verbose", "--server=example.com", "-t", "a", "-t", "b", "-ll", "--format=text,json", "--mode"}
+	assertNoErr(t, proxy.Parse(args))
+	assertDeepEqual(t, []string{
+		"--format=text,json",
+		"--host=example.com",
+		"--level=",
+		"--level=",
+		"--mode=fast",
+		"--tag=a",
+		"--tag=b",
+		"--verbose=false",
+	}, proxy.ToArgs())
+
+	assertNoErr(t, original.Parse(proxy.ToArgs()))
+	assertEqual(t, 2, original.MustGetCount("level"))
+	assertDeepEqual(t, []string{"a", "b"}, original.MustGetStringSlice("tag"))
+	assertEqual(t, "example.com", original.MustGetString("host"))
+
+	assertErrMsg(t, `invalid argument "xml" for "--format" flag: must be one of json, text`, proxy.Parse([]string{"--host=h", "--format=xml"}))
+
+	proxy, err = zflag.ReadHelpJSON(bytes.NewBufferString(`{"name": "tool", "flags": [{"name": "host", "type": "string", "constraints": {"required": true}}]}`))
+	assertNoErr(t, err)
+	proxy.SetOutput(ioutil.Discard)
+	assertErrMsg(t, `required flag(s) "--host" not set`, proxy.Parse(nil))
+}
+
+func TestHelpJSONConstraints(t *testing.T) {
+	t.Parallel()
+
+	original := zflag.NewFlagSet("tool", zflag.ContinueOnError)
+	original.String("output", "file", "output")
+	original.String("bucket", "", "bucket", zflag.OptRequiredIfFlagSet("output", "s3"))
+	original.String("user", "", "user", zflag.OptRequires("password"), zflag.OptConflictsWith("anonymous", "hidden"))
+	original.String("password", "", "password")
+	original.Bool("anonymous", false, "anonymous")
+	original.Bool("hidden", false, "hidden", zflag.OptHidden())
+	format := original.Group("Format", "")
+	format.Bool("json", false, "json")
+	format.Bool("yaml", false, "yaml")
+	format.MarkMutuallyExclusive()
+	original.Group("", "").MarkMutuallyExclusive("password", "hidden")
+
+	var buf bytes.Buffer
+	assertNoErr(t, original.WriteHelpJSON(&buf))
+
+	var doc struct {
+		Flags []struct {
+			Name        string
+			Constraints json.RawMessage
+		}
+		MutuallyExclusive [][]string
+	}
+	assertNoErr(t, json.Unmarshal(buf.Bytes(), &doc))
+	constraints := make(map[string]string)
+	for _, f := range doc.Flags {
+		constraints[f.Name] = string(bytes.Join(bytes.Fields(f.Constraints), nil))
+	}
+	assertEqual(t, `{"requiredIf":{"flag":"output","value":"s3"}}`, constraints["bucket"])
+	assertEqual(t, `{"requires":["password"],"conflictsWith":["anonymous"]}`, constraints["user"])
+	assertDeepEqual(t, [][]string{{"json", "yaml"}}, doc.MutuallyExclusive)
+
+	parse := func(args ...string) error {
+		proxy, err := zflag.ReadHelpJSON(bytes.NewReader(buf.Bytes()))
+		assertNoErr(t, err)
+		proxy.SetOutput(ioutil.Discard)
+		return proxy.Parse(args)
+	}
+	assertErrMsg(t, `required flag(s) "--bucket" not set`, parse("--output=s3"))
+	assertErrMsg(t, `flag "--user" requires flag "--password" to be set`, parse("--user=u"))
+	assertErrMsg(t, `flag "--user" cannot be used with flag "--anonymous"`, parse("--user=u", "--password=p", "--anonymous"))
+	assertErrMsg(t, `flags "--json", "--yaml" are mutually exclusive, but were set together`, parse("--json", "--yaml"))
+	assertNoErr(t, parse("--output=s3", "--bucket=b", "--user=u", "--password=p", "--json"))
+}
+
+func TestReadHelpJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	_, err := zflag.ReadHelpJSON(bytes.NewBufferString(`{`))
+	assertErrMsg(t, "unable to decode help JSON: unexpected EOF", err)
+
+	_, err = zflag.ReadHelpJSON(bytes.NewBufferString(`{"name": "tool", "flags": [{"name": "a", "type": "string"}, {"name": "a", "type": "string"}]}`))
+	assertErrMsg(t, `unable to define flag "a": tool flag redefined: a`, err)
+}
@@ -0,0 +1,57 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// AddPreParseHook appends hook to the hooks run at the start of every Parse,
+// after the argument rewriters, e.g. to load a config file named in the
+// arguments. Each hook receives the arguments returned by the previous one
+// and must not modify the slice it is passed, but return a new one instead.
+// An error returned by a hook aborts Parse and is returned as is.
+func (fs *FlagSet) AddPreParseHook(hook func(args []string) ([]string, error)) {
+	fs.preParseHooks = append(fs.preParseHooks, hook)
+}
+
+// AddPreParseHook appends hook to the pre-parse hooks of the command-line
+// flags. See FlagSet.AddPreParseHook for more information.
+func AddPreParseHook(hook func(args []string) ([]string, error)) {
+	CommandLine.AddPreParseHook(hook)
+}
+
+// AddPostParseHook appends hook to the hooks run at the end of every
+// successful Parse, after the flags were validated, e.g. to check
+// constraints across several flags. The hooks are run in the order they
+// were added, and the first error returned is returned by Parse.
+func (fs *FlagSet) AddPostParseHook(hook func(fs *FlagSet) error) {
+	fs.postParseHooks = append(fs.postParseHooks, hook)
+}
+
+// AddPostParseHook appends hook to the post-parse hooks of the command-line
+// flags. See FlagSet.AddPostParseHook for more information.
+func AddPostParseHook(hook func(fs *FlagSet) error) {
+	CommandLine.AddPostParseHook(hook)
+}
+
+func (fs *FlagSet) runPreParseHooks(args []string) ([]string, error) {
+	for _, hook := range fs.preParseHooks {
+		var err error
+		if args, err = hook(args); err != nil {
+			return nil, err
+		}
+	}
+	return args, nil
+}
+
+// validateAndRunPostParseHooks validates the flags, and runs the post-parse
+// hooks if they are valid.
+func (fs *FlagSet) validateAndRunPostParseHooks() error {
+	if err := fs.Validate(); err != nil {
+		return err
+	}
+	for _, hook := range fs.postParseHooks {
+		if err := hook(fs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
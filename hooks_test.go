@@ -0,0 +1,131 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestParseHooks(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		args          []string
+		expectedErr   string
+		expectedCalls []string
+	}{
+		{
+			name:          "no arguments",
+			args:          []string{},
+			expectedCalls: []string{"pre 1", "pre 2", "post 1", "post 2"},
+		},
+		{
+			name:          "arguments rewritten by hooks",
+			args:          []string{"--profile=dev", "arg"},
+			expectedCalls: []string{"pre 1", "pre 2", "post 1", "post 2"},
+		},
+		{
+			name:          "pre-parse hook error",
+			args:          []string{"fail-pre"},
+			expectedErr:   "pre failed",
+			expectedCalls: []string{"pre 1"},
+		},
+		{
+			name:          "post-parse hook error",
+			args:          []string{"--port=1"},
+			expectedErr:   "port must be at least 1024 for the dev profile",
+			expectedCalls: []string{"pre 1", "pre 2", "post 1"},
+		},
+		{
+			name:          "invalid flags skip post-parse hooks",
+			args:          []string{"--port=abc"},
+			expectedErr:   `invalid argument "abc" for "--port" flag: strconv.ParseInt: parsing "abc": invalid syntax`,
+			expectedCalls: []string{"pre 1", "pre 2"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var calls []string
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			profile := f.String("profile", "", "profile")
+			port := f.Int("port", 8080, "port")
+			f.AddPreParseHook(func(args []string) ([]string, error) {
+				calls = append(calls, "pre 1")
+				if len(args) > 0 && args[0] == "fail-pre" {
+					return nil, errors.New("pre failed")
+				}
+				return args, nil
+			})
+			f.AddPreParseHook(func(args []string) ([]string, error) {
+				calls = append(calls, "pre 2")
+				return append([]string{"--profile=dev"}, args...), nil
+			})
+			f.AddPostParseHook(func(fs *zflag.FlagSet) error {
+				calls = append(calls, "post 1")
+				if *profile == "dev" && *port < 1024 {
+					return errors.New("port must be at least 1024 for the dev profile")
+				}
+				return nil
+			})
+			f.AddPostParseHook(func(fs *zflag.FlagSet) error {
+				calls = append(calls, "post 2")
+				return nil
+			})
+
+			err := f.Parse(test.args)
+			assertDeepEqual(t, test.expectedCalls, calls)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, "dev", *profile)
+		})
+	}
+}
+
+func TestPreParseHookErrorHandling(t *testing.T) {
+	newFlagSet := func(errorHandling zflag.ErrorHandling) *zflag.FlagSet {
+		f := zflag.NewFlagSet("test", errorHandling)
+		f.SetOutput(ioutil.Discard)
+		f.AddPreParseHook(func(args []string) ([]string, error) {
+			return nil, errors.New("pre failed")
+		})
+		return f
+	}
+
+	t.Run("ExitOnError", func(t *testing.T) {
+		code := -1
+		zflag.SetExitFunc(func(c int) {
+			code = c
+		})
+		defer zflag.SetExitFunc(os.Exit)
+
+		assertNoErr(t, newFlagSet(zflag.ExitOnError).Parse([]string{"arg"}))
+		assertEqual(t, 2, code)
+	})
+
+	t.Run("PanicOnError", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			err, ok := r.(error)
+			if !ok {
+				t.Fatalf("expected a panic with an error, got %v", r)
+			}
+			assertErrMsg(t, "pre failed", err)
+		}()
+		_ = newFlagSet(zflag.PanicOnError).Parse([]string{"arg"})
+	})
+}
@@ -0,0 +1,112 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// HostAndPort is a network address consisting of a host and a port, as used by
+// HostPortVar.
+type HostAndPort struct {
+	Host string
+	Port uint16
+}
+
+// String returns the address in the form "host:port", using brackets for
+// IPv6 hosts.
+func (hp HostAndPort) String() string {
+	if hp.Host == "" && hp.Port == 0 {
+		return ""
+	}
+	return net.JoinHostPort(hp.Host, strconv.FormatUint(uint64(hp.Port), 10))
+}
+
+// -- hostPort Value
+type hostPortValue struct {
+	value       *HostAndPort
+	constraints portConstraints
+}
+
+var _ Value = (*hostPortValue)(nil)
+var _ Getter = (*hostPortValue)(nil)
+var _ Typed = (*hostPortValue)(nil)
+
+func newHostPortValue(val HostAndPort, p *HostAndPort) *hostPortValue {
+	*p = val
+	return &hostPortValue{value: p}
+}
+
+func (h *hostPortValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	host, port, err := net.SplitHostPort(val)
+	if err != nil {
+		return fmt.Errorf("invalid address %q, expected host:port", val)
+	}
+	p, err := h.constraints.parse(port)
+	if err != nil {
+		return err
+	}
+	*h.value = HostAndPort{Host: host, Port: p}
+	return nil
+}
+
+func (h *hostPortValue) Get() interface{} {
+	return *h.value
+}
+
+func (h *hostPortValue) Type() string {
+	return "hostPort"
+}
+
+func (h *hostPortValue) String() string { return h.value.String() }
+
+// GetHostPort return the HostAndPort value of a flag with the given name
+func (fs *FlagSet) GetHostPort(name string) (HostAndPort, error) {
+	val, err := fs.getFlagValue(name, "hostPort")
+	if err != nil {
+		return HostAndPort{}, err
+	}
+	return val.(HostAndPort), nil
+}
+
+// MustGetHostPort is like GetHostPort, but panics on error.
+func (fs *FlagSet) MustGetHostPort(name string) HostAndPort {
+	val, err := fs.GetHostPort(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// HostPortVar defines a "host:port" flag with specified name, default value, and usage string.
+// The argument p points to a HostAndPort variable in which to store the value of the flag.
+// The address is split with net.SplitHostPort, and the port is validated like PortVar.
+func (fs *FlagSet) HostPortVar(p *HostAndPort, name string, value HostAndPort, usage string, opts ...Opt) {
+	fs.Var(newHostPortValue(value, p), name, usage, opts...)
+}
+
+// HostPortVar defines a "host:port" flag with specified name, default value, and usage string.
+// The argument p points to a HostAndPort variable in which to store the value of the flag.
+// The address is split with net.SplitHostPort, and the port is validated like PortVar.
+func HostPortVar(p *HostAndPort, name string, value HostAndPort, usage string, opts ...Opt) {
+	CommandLine.HostPortVar(p, name, value, usage, opts...)
+}
+
+// HostPort defines a "host:port" flag with specified name, default value, and usage string.
+// The return value is the address of a HostAndPort variable that stores the value of the flag.
+func (fs *FlagSet) HostPort(name string, value HostAndPort, usage string, opts ...Opt) *HostAndPort {
+	var p HostAndPort
+	fs.HostPortVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// HostPort defines a "host:port" flag with specified name, default value, and usage string.
+// The return value is the address of a HostAndPort variable that stores the value of the flag.
+func HostPort(name string, value HostAndPort, usage string, opts ...Opt) *HostAndPort {
+	return CommandLine.HostPort(name, value, usage, opts...)
+}
@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestHostPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault zflag.HostAndPort
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    zflag.HostAndPort
+		expectedStr string
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: zflag.HostAndPort{},
+		},
+		{
+			name:        "missing port",
+			input:       []string{"localhost"},
+			expectedErr: `invalid argument "localhost" for "--addr" flag: invalid address "localhost", expected host:port`,
+		},
+		{
+			name:        "invalid port",
+			input:       []string{"localhost:99999"},
+			expectedErr: `invalid argument "localhost:99999" for "--addr" flag: invalid port "99999", expected a number between 1 and 65535`,
+		},
+		{
+			name:        "zero port not allowed",
+			input:       []string{":0"},
+			expectedErr: `invalid argument ":0" for "--addr" flag: invalid port "0", expected a number between 1 and 65535`,
+		},
+		{
+			name:        "zero port allowed",
+			opts:        []zflag.Opt{zflag.OptPortAllowZero()},
+			input:       []string{":0"},
+			expected:    zflag.HostAndPort{Port: 0},
+			expectedStr: "",
+		},
+		{
+			name:        "host and port",
+			input:       []string{"example.com:443"},
+			expected:    zflag.HostAndPort{Host: "example.com", Port: 443},
+			expectedStr: "example.com:443",
+		},
+		{
+			name:        "ipv6 host",
+			input:       []string{"[::1]:8080"},
+			expected:    zflag.HostAndPort{Host: "::1", Port: 8080},
+			expectedStr: "[::1]:8080",
+		},
+		{
+			name:        "empty host",
+			input:       []string{":8080"},
+			expected:    zflag.HostAndPort{Port: 8080},
+			expectedStr: ":8080",
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: zflag.HostAndPort{Host: "localhost", Port: 80},
+			expected:    zflag.HostAndPort{Host: "localhost", Port: 80},
+			expectedStr: "localhost:80",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v zflag.HostAndPort
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.HostPortVar(&v, "addr", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--addr", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+			assertEqual(t, test.expectedStr, f.Lookup("addr").Value.String())
+
+			got, err := f.GetHostPort("addr")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetHostPort("addr"))
+		})
+	}
+}
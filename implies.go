@@ -0,0 +1,64 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// implication is a value applied to another flag when a flag is set, see
+// OptImplies.
+type implication struct {
+	name  string
+	value string
+}
+
+// OptImplies sets the flag name to value whenever the flag is set, unless
+// name was set explicitly, e.g. to let --debug imply --log-level=debug. It
+// may be repeated to imply several flags, and implied flags may imply others
+// in turn. Setting a boolean flag to false implies nothing. Implied values
+// are applied after the arguments and environment variables, with Source set
+// to SourceImplied.
+func OptImplies(name, value string) Opt {
+	return func(f *Flag) error {
+		if name == "" {
+			return fmt.Errorf("implied flag name for flag %q must be set", f.Name)
+		}
+		f.implies = append(f.implies, implication{name: name, value: value})
+		return nil
+	}
+}
+
+// applyImplications applies the implied values of the flags that were set.
+// As flags set by an implication are appended to orderedActual, their
+// implications are applied as well, the first implication of a flag winning.
+func (fs *FlagSet) applyImplications() error {
+	for i := 0; i < len(fs.orderedActual); i++ {
+		flag := fs.orderedActual[i]
+		if len(flag.implies) == 0 || isUnsetBool(flag) {
+			continue
+		}
+
+		for _, implied := range flag.implies {
+			target := fs.lookup(fs.normalizeFlagName(implied.name))
+			if target == nil {
+				return fs.failf("%w (implied by flag --%s)", DefinitionError{Err: NewUnknownFlagError(implied.name)}, flag.Name)
+			}
+			if target.Changed {
+				continue
+			}
+			if err := fs.Set(target.Name, implied.value); err != nil {
+				return fs.failf("%w (implied by flag --%s)", DefinitionError{Err: err}, flag.Name)
+			}
+			target.Source = SourceImplied
+		}
+	}
+	return nil
+}
+
+// isUnsetBool reports whether flag is a boolean flag set to false.
+func isUnsetBool(flag *Flag) bool {
+	v, ok := flag.Value.(BoolFlag)
+	return ok && v.IsBoolFlag() && v.String() == "false"
+}
@@ -0,0 +1,101 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptImplies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		args            []string
+		expectedLevel   string
+		expectedVerbose bool
+		expectedSource  string
+		expectedErr     string
+	}{
+		{
+			name:          "not set",
+			args:          []string{},
+			expectedLevel: "info",
+		},
+		{
+			name:            "implies values",
+			args:            []string{"--debug"},
+			expectedLevel:   "debug",
+			expectedVerbose: true,
+			expectedSource:  zflag.SourceImplied,
+		},
+		{
+			name:            "explicit value wins",
+			args:            []string{"--log-level=warn", "--debug"},
+			expectedLevel:   "warn",
+			expectedVerbose: true,
+			expectedSource:  zflag.SourceArgs,
+		},
+		{
+			name:          "false bool implies nothing",
+			args:          []string{"--debug=false"},
+			expectedLevel: "info",
+		},
+		{
+			name:            "chained implication",
+			args:            []string{"--trace"},
+			expectedLevel:   "debug",
+			expectedVerbose: true,
+			expectedSource:  zflag.SourceImplied,
+		},
+		{
+			name:        "invalid implied value",
+			args:        []string{"--quiet"},
+			expectedErr: `invalid argument "loud" for "--verbose" flag: strconv.ParseBool: parsing "loud": invalid syntax (implied by flag --quiet)`,
+		},
+		{
+			name:        "unknown implied flag",
+			args:        []string{"--missing"},
+			expectedErr: `unknown flag: --nope (implied by flag --missing)`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			level := f.String("log-level", "info", "log level")
+			verbose := f.Bool("verbose", false, "verbose output")
+			f.Bool("debug", false, "debug mode", zflag.OptImplies("log-level", "debug"), zflag.OptImplies("verbose", "true"))
+			f.Bool("trace", false, "trace mode", zflag.OptImplies("debug", "true"))
+			f.Bool("quiet", false, "quiet mode", zflag.OptImplies("verbose", "loud"))
+			f.Bool("missing", false, "missing", zflag.OptImplies("nope", "true"))
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedLevel, *level)
+			assertEqual(t, test.expectedVerbose, *verbose)
+			assertEqual(t, test.expectedSource, f.Lookup("log-level").Source)
+		})
+	}
+}
+
+func TestOptImpliesEmptyName(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.Bool("debug", false, "debug mode", zflag.OptImplies("", "true"))
+}
@@ -0,0 +1,59 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// SetParent links the FlagSet to a parent, whose flags are inherited: Lookup,
+// Changed and the Get functions fall back to the parent for flags that are
+// not defined in the FlagSet itself, and the usage lists them in a separate
+// section. This allows layered global and local flags without copying them,
+// e.g. by parsing the global flags with the parent and the remaining
+// arguments with the child. Pass nil to unlink the FlagSet. It panics if
+// the link would create a cycle.
+func (fs *FlagSet) SetParent(parent *FlagSet) {
+	for p := parent; p != nil; p = p.parent {
+		if p == fs {
+			panic("zflag: setting the parent would create a cycle")
+		}
+	}
+	fs.parent = parent
+}
+
+// Parent returns the parent of the FlagSet, as set with SetParent.
+func (fs *FlagSet) Parent() *FlagSet {
+	return fs.parent
+}
+
+// InheritedFlags returns the flags of the ancestors of the FlagSet that are
+// not shadowed by a flag with the same name closer to the FlagSet.
+func (fs *FlagSet) InheritedFlags() []*Flag {
+	var flags []*Flag
+	seen := make(map[NormalizedName]bool)
+	fs.VisitAll(func(flag *Flag) {
+		seen[fs.normalizeFlagName(flag.Name)] = true
+	})
+
+	for p := fs.parent; p != nil; p = p.parent {
+		p.VisitAll(func(flag *Flag) {
+			name := fs.normalizeFlagName(flag.Name)
+			if !seen[name] {
+				seen[name] = true
+				flags = append(flags, flag)
+			}
+		})
+	}
+	return flags
+}
+
+// InheritedFlagUsagesWrapped returns a string containing the usage
+// information for all inherited flags, see InheritedFlags. Wrapped to `cols`
+// columns (0 for no wrapping).
+func (fs *FlagSet) InheritedFlagUsagesWrapped(cols int) string {
+	return fs.flagUsagesWrapped(fs.InheritedFlags(), func(*Flag) bool { return true }, cols)
+}
+
+// InheritedFlagUsages returns a string containing the usage information for
+// all inherited flags, see InheritedFlags.
+func (fs *FlagSet) InheritedFlagUsages() string {
+	return fs.InheritedFlagUsagesWrapped(0)
+}
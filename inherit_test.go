@@ -0,0 +1,84 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSetParent(t *testing.T) {
+	t.Parallel()
+
+	parent := zflag.NewFlagSet("parent", zflag.ContinueOnError)
+	parent.SetOutput(ioutil.Discard)
+	parent.SetInterspersed(false)
+	parent.Bool("verbose", false, "verbose output")
+	parent.String("name", "global", "name")
+
+	child := zflag.NewFlagSet("child", zflag.ContinueOnError)
+	child.SetOutput(ioutil.Discard)
+	child.SetParent(parent)
+	child.String("name", "local", "name")
+	child.Int("port", 0, "port")
+	assertEqual(t, parent, child.Parent())
+
+	assertNoErr(t, parent.Parse([]string{"--verbose", "serve", "--port", "80"}))
+	assertNoErr(t, child.Parse(parent.Args()[1:]))
+
+	assertEqual(t, true, child.MustGetBool("verbose"))
+	assertEqual(t, true, child.Changed("verbose"))
+	assertEqual(t, parent.Lookup("verbose"), child.Lookup("verbose"))
+	assertEqual(t, "local", child.MustGetString("name"))
+	assertEqual(t, 80, child.MustGetInt("port"))
+	assertEqual(t, (*zflag.Flag)(nil), parent.Lookup("port"))
+
+	err := child.Parse([]string{"--verbose"})
+	assertErrMsg(t, "unknown flag: --verbose", err)
+}
+
+func TestSetParentCycle(t *testing.T) {
+	t.Parallel()
+
+	a := zflag.NewFlagSet("a", zflag.ContinueOnError)
+	b := zflag.NewFlagSet("b", zflag.ContinueOnError)
+	b.SetParent(a)
+
+	defer assertPanic(t)()
+	a.SetParent(b)
+}
+
+func TestInheritedFlagUsages(t *testing.T) {
+	t.Parallel()
+
+	root := zflag.NewFlagSet("root", zflag.ContinueOnError)
+	root.String("config", "", "config file")
+	root.Bool("debug", false, "debug output", zflag.OptHidden())
+
+	parent := zflag.NewFlagSet("parent", zflag.ContinueOnError)
+	parent.SetParent(root)
+	parent.Bool("verbose", false, "verbose output")
+	parent.String("name", "", "name")
+
+	child := zflag.NewFlagSet("child", zflag.ContinueOnError)
+	child.SetParent(parent)
+	child.String("name", "", "name")
+
+	var buf bytes.Buffer
+	child.SetOutput(&buf)
+	child.PrintDefaults()
+
+	expected := `      --name string   name
+
+Inherited Flags:
+      --verbose         verbose output
+      --config string   config file
+`
+	assertEqual(t, expected, buf.String())
+	assertEqual(t, 3, len(child.InheritedFlags()))
+	assertEqual(t, "", root.InheritedFlagUsages())
+}
@@ -28,22 +28,22 @@ func TestInt16Slice(t *testing.T) {
 			expectedValues: []int16{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []int16{},
-			expectedErr: `invalid argument "" for "--i16s" flag: strconv.ParseInt: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []int16{},
+			expectedValues: []int16{},
 		},
 		{
 			name:        "invalid int16",
 			input:       []string{"blabla"},
 			flagDefault: []int16{},
-			expectedErr: `invalid argument "blabla" for "--i16s" flag: strconv.ParseInt: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--i16s" flag: element 1: strconv.ParseInt: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []int16{},
-			expectedErr: `invalid argument "1,5" for "--i16s" flag: strconv.ParseInt: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--i16s" flag: element 1: strconv.ParseInt: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
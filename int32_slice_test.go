@@ -28,22 +28,22 @@ func TestInt32Slice(t *testing.T) {
 			expectedValues: []int32{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []int32{},
-			expectedErr: `invalid argument "" for "--i32s" flag: strconv.ParseInt: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []int32{},
+			expectedValues: []int32{},
 		},
 		{
 			name:        "invalid int32",
 			input:       []string{"blabla"},
 			flagDefault: []int32{},
-			expectedErr: `invalid argument "blabla" for "--i32s" flag: strconv.ParseInt: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--i32s" flag: element 1: strconv.ParseInt: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []int32{},
-			expectedErr: `invalid argument "1,5" for "--i32s" flag: strconv.ParseInt: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--i32s" flag: element 1: strconv.ParseInt: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
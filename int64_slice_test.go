@@ -28,22 +28,22 @@ func TestInt64Slice(t *testing.T) {
 			expectedValues: []int64{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []int64{},
-			expectedErr: `invalid argument "" for "--i64s" flag: strconv.ParseInt: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []int64{},
+			expectedValues: []int64{},
 		},
 		{
 			name:        "invalid int64",
 			input:       []string{"blabla"},
 			flagDefault: []int64{},
-			expectedErr: `invalid argument "blabla" for "--i64s" flag: strconv.ParseInt: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--i64s" flag: element 1: strconv.ParseInt: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []int64{},
-			expectedErr: `invalid argument "1,5" for "--i64s" flag: strconv.ParseInt: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--i64s" flag: element 1: strconv.ParseInt: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
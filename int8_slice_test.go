@@ -28,22 +28,22 @@ func TestInt8Slice(t *testing.T) {
 			expectedValues: []int8{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []int8{},
-			expectedErr: `invalid argument "" for "--i8s" flag: strconv.ParseInt: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []int8{},
+			expectedValues: []int8{},
 		},
 		{
 			name:        "invalid int8",
 			input:       []string{"blabla"},
 			flagDefault: []int8{},
-			expectedErr: `invalid argument "blabla" for "--i8s" flag: strconv.ParseInt: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--i8s" flag: element 1: strconv.ParseInt: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []int8{},
-			expectedErr: `invalid argument "1,5" for "--i8s" flag: strconv.ParseInt: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--i8s" flag: element 1: strconv.ParseInt: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
@@ -30,7 +30,7 @@ func (s *intSliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	out, err := strconv.Atoi(val)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 
 	if !s.changed {
@@ -61,7 +61,7 @@ func (s *intSliceValue) String() string {
 func (s *intSliceValue) Append(val string) error {
 	i, err := strconv.Atoi(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -73,7 +73,7 @@ func (s *intSliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = strconv.Atoi(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
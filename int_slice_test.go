@@ -4,6 +4,7 @@
 package zflag_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"reflect"
 	"testing"
@@ -28,22 +29,22 @@ func TestIntSlice(t *testing.T) {
 			expectedValues: []int{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []int{},
-			expectedErr: `invalid argument "" for "--is" flag: strconv.Atoi: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []int{},
+			expectedValues: []int{},
 		},
 		{
 			name:        "invalid int",
 			input:       []string{"blabla"},
 			flagDefault: []int{},
-			expectedErr: `invalid argument "blabla" for "--is" flag: strconv.Atoi: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--is" flag: element 1: strconv.Atoi: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []int{},
-			expectedErr: `invalid argument "1,5" for "--is" flag: strconv.Atoi: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--is" flag: element 1: strconv.Atoi: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
@@ -125,3 +126,23 @@ func TestIntSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestIntSliceElementError(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.IntSlice("ports", []int{80}, "usage")
+
+	err := f.Parse([]string{"--ports=1", "--ports=2", "--ports=x"})
+	assertErrMsg(t, `invalid argument "x" for "--ports" flag: element 3: strconv.Atoi: parsing "x": invalid syntax`, err)
+
+	var elemErr zflag.ElementError
+	if !errors.As(err, &elemErr) {
+		t.Fatalf("expected an ElementError, got %T", err)
+	}
+	assertEqual(t, 3, elemErr.Position)
+
+	sv := f.Lookup("ports").Value.(zflag.SliceValue)
+	assertErrMsg(t, `element 2: strconv.Atoi: parsing "y": invalid syntax`, sv.Replace([]string{"1", "y"}))
+}
@@ -0,0 +1,93 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// -- netip.Addr value
+type ipAddrValue netip.Addr
+
+var _ Value = (*ipAddrValue)(nil)
+var _ Getter = (*ipAddrValue)(nil)
+var _ Typed = (*ipAddrValue)(nil)
+
+func newIPAddrValue(val netip.Addr, p *netip.Addr) *ipAddrValue {
+	*p = val
+	return (*ipAddrValue)(p)
+}
+
+func (v *ipAddrValue) String() string {
+	if !netip.Addr(*v).IsValid() {
+		return ""
+	}
+	return netip.Addr(*v).String()
+}
+
+func (v *ipAddrValue) Set(val string) error {
+	parsed, err := netip.ParseAddr(strings.TrimSpace(val))
+	if err != nil {
+		return fmt.Errorf("failed to parse IP address: %q", val)
+	}
+	*v = ipAddrValue(parsed)
+	return nil
+}
+
+func (v *ipAddrValue) Get() interface{} {
+	return netip.Addr(*v)
+}
+
+func (v *ipAddrValue) Type() string {
+	return "ipAddr"
+}
+
+// GetIPAddr return the netip.Addr value of a flag with the given name
+func (fs *FlagSet) GetIPAddr(name string) (netip.Addr, error) {
+	val, err := fs.getFlagValue(name, "ipAddr")
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return val.(netip.Addr), nil
+}
+
+// MustGetIPAddr is like GetIPAddr, but panics on error.
+func (fs *FlagSet) MustGetIPAddr(name string) netip.Addr {
+	val, err := fs.GetIPAddr(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IPAddrVar defines a netip.Addr flag with specified name, default value, and usage string.
+// The argument p points to a netip.Addr variable in which to store the value of the flag.
+func (fs *FlagSet) IPAddrVar(p *netip.Addr, name string, value netip.Addr, usage string, opts ...Opt) {
+	fs.Var(newIPAddrValue(value, p), name, usage, opts...)
+}
+
+// IPAddrVar defines a netip.Addr flag with specified name, default value, and usage string.
+// The argument p points to a netip.Addr variable in which to store the value of the flag.
+func IPAddrVar(p *netip.Addr, name string, value netip.Addr, usage string, opts ...Opt) {
+	CommandLine.IPAddrVar(p, name, value, usage, opts...)
+}
+
+// IPAddr defines a netip.Addr flag with specified name, default value, and usage string.
+// The return value is the address of a netip.Addr variable that stores the value of the flag.
+func (fs *FlagSet) IPAddr(name string, value netip.Addr, usage string, opts ...Opt) *netip.Addr {
+	var p netip.Addr
+	fs.IPAddrVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// IPAddr defines a netip.Addr flag with specified name, default value, and usage string.
+// The return value is the address of a netip.Addr variable that stores the value of the flag.
+func IPAddr(name string, value netip.Addr, usage string, opts ...Opt) *netip.Addr {
+	return CommandLine.IPAddr(name, value, usage, opts...)
+}
@@ -0,0 +1,153 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// -- ipAddrSlice Value
+type ipAddrSliceValue struct {
+	value   *[]netip.Addr
+	changed bool
+}
+
+var _ Value = (*ipAddrSliceValue)(nil)
+var _ Getter = (*ipAddrSliceValue)(nil)
+var _ SliceValue = (*ipAddrSliceValue)(nil)
+var _ Typed = (*ipAddrSliceValue)(nil)
+
+func newIPAddrSliceValue(val []netip.Addr, p *[]netip.Addr) *ipAddrSliceValue {
+	s := new(ipAddrSliceValue)
+	s.value = p
+	*s.value = val
+	return s
+}
+
+// Set converts, and assigns, the argument string representation as the []netip.Addr value of this flag.
+// If Set is called on a flag that already has a []netip.Addr assigned, the newly converted values will be appended.
+func (s *ipAddrSliceValue) Set(val string) error {
+	v, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []netip.Addr{}
+	}
+	*s.value = append(*s.value, v)
+
+	s.changed = true
+
+	return nil
+}
+
+func (s *ipAddrSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *ipAddrSliceValue) Type() string {
+	return "ipAddrSlice"
+}
+
+// String defines a "native" format for this netip.Addr slice flag value.
+func (s *ipAddrSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), " ") + "]"
+}
+
+func (s *ipAddrSliceValue) fromString(val string) (netip.Addr, error) {
+	v, err := netip.ParseAddr(strings.TrimSpace(val))
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("failed to parse IP address: %q", val)
+	}
+	return v, nil
+}
+
+func (s *ipAddrSliceValue) toString(val netip.Addr) string {
+	return val.String()
+}
+
+func (s *ipAddrSliceValue) Append(val string) error {
+	v, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, v)
+	return nil
+}
+
+func (s *ipAddrSliceValue) Replace(val []string) error {
+	out := make([]netip.Addr, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = s.fromString(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *ipAddrSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = s.toString(d)
+	}
+	return out
+}
+
+// GetIPAddrSlice returns the []netip.Addr value of a flag with the given name
+func (fs *FlagSet) GetIPAddrSlice(name string) ([]netip.Addr, error) {
+	val, err := fs.getFlagValue(name, "ipAddrSlice")
+	if err != nil {
+		return []netip.Addr{}, err
+	}
+	return val.([]netip.Addr), nil
+}
+
+// MustGetIPAddrSlice is like GetIPAddrSlice, but panics on error.
+func (fs *FlagSet) MustGetIPAddrSlice(name string) []netip.Addr {
+	val, err := fs.GetIPAddrSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IPAddrSliceVar defines a []netip.Addr flag with specified name, default value, and usage string.
+// The argument p points to a []netip.Addr variable in which to store the value of the flag.
+func (fs *FlagSet) IPAddrSliceVar(p *[]netip.Addr, name string, value []netip.Addr, usage string, opts ...Opt) {
+	fs.Var(newIPAddrSliceValue(value, p), name, usage, opts...)
+}
+
+// IPAddrSliceVar defines a []netip.Addr flag with specified name, default value, and usage string.
+// The argument p points to a []netip.Addr variable in which to store the value of the flag.
+func IPAddrSliceVar(p *[]netip.Addr, name string, value []netip.Addr, usage string, opts ...Opt) {
+	CommandLine.IPAddrSliceVar(p, name, value, usage, opts...)
+}
+
+// IPAddrSlice defines a []netip.Addr flag with specified name, default value, and usage string.
+// The return value is the address of a []netip.Addr variable that stores the value of the flag.
+func (fs *FlagSet) IPAddrSlice(name string, value []netip.Addr, usage string, opts ...Opt) *[]netip.Addr {
+	var p []netip.Addr
+	fs.IPAddrSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// IPAddrSlice defines a []netip.Addr flag with specified name, default value, and usage string.
+// The return value is the address of a []netip.Addr variable that stores the value of the flag.
+func IPAddrSlice(name string, value []netip.Addr, usage string, opts ...Opt) *[]netip.Addr {
+	return CommandLine.IPAddrSlice(name, value, usage, opts...)
+}
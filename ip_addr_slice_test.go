@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net/netip"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestIPAddrSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault []netip.Addr
+		input       []string
+		expectedErr string
+		expected    []netip.Addr
+		visitor     func(f *zflag.Flag)
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: nil,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"blabla"},
+			expectedErr: `invalid argument "blabla" for "--addr" flag: element 1: failed to parse IP address: "blabla"`,
+		},
+		{
+			name:        "no csv",
+			input:       []string{"192.168.1.1,fe80::1%eth0"},
+			expectedErr: `invalid argument "192.168.1.1,fe80::1%eth0" for "--addr" flag: element 1: failed to parse IP address: "192.168.1.1,fe80::1%eth0"`,
+		},
+		{
+			name:     "multiple values",
+			input:    []string{"192.168.1.1", " fe80::1%eth0 "},
+			expected: []netip.Addr{netip.MustParseAddr("192.168.1.1"), netip.MustParseAddr("fe80::1%eth0")},
+		},
+		{
+			name:        "overrides default values",
+			input:       []string{"fe80::1%eth0"},
+			flagDefault: []netip.Addr{netip.MustParseAddr("192.168.1.1")},
+			expected:    []netip.Addr{netip.MustParseAddr("fe80::1%eth0")},
+		},
+		{
+			name:  "as slice values",
+			input: []string{"192.168.1.1"},
+			visitor: func(f *zflag.Flag) {
+				sv := f.Value.(zflag.SliceValue)
+				_ = sv.Replace([]string{"fe80::1%eth0"})
+				_ = sv.Append("192.168.1.1")
+			},
+			expected: []netip.Addr{netip.MustParseAddr("fe80::1%eth0"), netip.MustParseAddr("192.168.1.1")},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v []netip.Addr
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.IPAddrSliceVar(&v, "addr", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--addr", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+			assertDeepEqual(t, test.expected, v)
+
+			got, err := f.GetIPAddrSlice("addr")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetIPAddrSlice("addr"))
+		})
+	}
+}
+
+func TestIPAddrSliceInvalidReplace(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.IPAddrSlice("addr", nil, "usage")
+	sv := f.Lookup("addr").Value.(zflag.SliceValue)
+	assertErrMsg(t, `element 1: failed to parse IP address: "blabla"`, sv.Replace([]string{"blabla"}))
+	assertErrMsg(t, `element 1: failed to parse IP address: "blabla"`, sv.Append("blabla"))
+}
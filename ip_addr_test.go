@@ -0,0 +1,92 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net/netip"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestIPAddr(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault netip.Addr
+		input       []string
+		expectedErr string
+		expected    netip.Addr
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: netip.Addr{},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--addr" flag: failed to parse IP address: ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"blabla"},
+			expectedErr: `invalid argument "blabla" for "--addr" flag: failed to parse IP address: "blabla"`,
+		},
+		{
+			name:     "ipv4",
+			input:    []string{"192.168.1.1"},
+			expected: netip.MustParseAddr("192.168.1.1"),
+		},
+		{
+			name:     "ipv6 with zone",
+			input:    []string{"fe80::1%eth0"},
+			expected: netip.MustParseAddr("fe80::1%eth0"),
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: netip.MustParseAddr("10.0.0.1"),
+			expected:    netip.MustParseAddr("10.0.0.1"),
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    ::1    "},
+			expected: netip.MustParseAddr("::1"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v netip.Addr
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.IPAddrVar(&v, "addr", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--addr", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			got, err := f.GetIPAddr("addr")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetIPAddr("addr"))
+
+			getter, err := f.Get("addr")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, getter)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// -- netip.Prefix value
+type ipPrefixValue netip.Prefix
+
+var _ Value = (*ipPrefixValue)(nil)
+var _ Getter = (*ipPrefixValue)(nil)
+var _ Typed = (*ipPrefixValue)(nil)
+
+func newIPPrefixValue(val netip.Prefix, p *netip.Prefix) *ipPrefixValue {
+	*p = val
+	return (*ipPrefixValue)(p)
+}
+
+func (v *ipPrefixValue) String() string {
+	if !netip.Prefix(*v).IsValid() {
+		return ""
+	}
+	return netip.Prefix(*v).String()
+}
+
+func (v *ipPrefixValue) Set(val string) error {
+	parsed, err := netip.ParsePrefix(strings.TrimSpace(val))
+	if err != nil {
+		return fmt.Errorf("failed to parse IP prefix: %q", val)
+	}
+	*v = ipPrefixValue(parsed)
+	return nil
+}
+
+func (v *ipPrefixValue) Get() interface{} {
+	return netip.Prefix(*v)
+}
+
+func (v *ipPrefixValue) Type() string {
+	return "ipPrefix"
+}
+
+// GetIPPrefix return the netip.Prefix value of a flag with the given name
+func (fs *FlagSet) GetIPPrefix(name string) (netip.Prefix, error) {
+	val, err := fs.getFlagValue(name, "ipPrefix")
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+	return val.(netip.Prefix), nil
+}
+
+// MustGetIPPrefix is like GetIPPrefix, but panics on error.
+func (fs *FlagSet) MustGetIPPrefix(name string) netip.Prefix {
+	val, err := fs.GetIPPrefix(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IPPrefixVar defines a netip.Prefix flag with specified name, default value, and usage string.
+// The argument p points to a netip.Prefix variable in which to store the value of the flag.
+func (fs *FlagSet) IPPrefixVar(p *netip.Prefix, name string, value netip.Prefix, usage string, opts ...Opt) {
+	fs.Var(newIPPrefixValue(value, p), name, usage, opts...)
+}
+
+// IPPrefixVar defines a netip.Prefix flag with specified name, default value, and usage string.
+// The argument p points to a netip.Prefix variable in which to store the value of the flag.
+func IPPrefixVar(p *netip.Prefix, name string, value netip.Prefix, usage string, opts ...Opt) {
+	CommandLine.IPPrefixVar(p, name, value, usage, opts...)
+}
+
+// IPPrefix defines a netip.Prefix flag with specified name, default value, and usage string.
+// The return value is the address of a netip.Prefix variable that stores the value of the flag.
+func (fs *FlagSet) IPPrefix(name string, value netip.Prefix, usage string, opts ...Opt) *netip.Prefix {
+	var p netip.Prefix
+	fs.IPPrefixVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// IPPrefix defines a netip.Prefix flag with specified name, default value, and usage string.
+// The return value is the address of a netip.Prefix variable that stores the value of the flag.
+func IPPrefix(name string, value netip.Prefix, usage string, opts ...Opt) *netip.Prefix {
+	return CommandLine.IPPrefix(name, value, usage, opts...)
+}
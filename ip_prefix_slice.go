@@ -0,0 +1,153 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// -- ipPrefixSlice Value
+type ipPrefixSliceValue struct {
+	value   *[]netip.Prefix
+	changed bool
+}
+
+var _ Value = (*ipPrefixSliceValue)(nil)
+var _ Getter = (*ipPrefixSliceValue)(nil)
+var _ SliceValue = (*ipPrefixSliceValue)(nil)
+var _ Typed = (*ipPrefixSliceValue)(nil)
+
+func newIPPrefixSliceValue(val []netip.Prefix, p *[]netip.Prefix) *ipPrefixSliceValue {
+	s := new(ipPrefixSliceValue)
+	s.value = p
+	*s.value = val
+	return s
+}
+
+// Set converts, and assigns, the argument string representation as the []netip.Prefix value of this flag.
+// If Set is called on a flag that already has a []netip.Prefix assigned, the newly converted values will be appended.
+func (s *ipPrefixSliceValue) Set(val string) error {
+	v, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []netip.Prefix{}
+	}
+	*s.value = append(*s.value, v)
+
+	s.changed = true
+
+	return nil
+}
+
+func (s *ipPrefixSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *ipPrefixSliceValue) Type() string {
+	return "ipPrefixSlice"
+}
+
+// String defines a "native" format for this netip.Prefix slice flag value.
+func (s *ipPrefixSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), " ") + "]"
+}
+
+func (s *ipPrefixSliceValue) fromString(val string) (netip.Prefix, error) {
+	v, err := netip.ParsePrefix(strings.TrimSpace(val))
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("failed to parse IP prefix: %q", val)
+	}
+	return v, nil
+}
+
+func (s *ipPrefixSliceValue) toString(val netip.Prefix) string {
+	return val.String()
+}
+
+func (s *ipPrefixSliceValue) Append(val string) error {
+	v, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, v)
+	return nil
+}
+
+func (s *ipPrefixSliceValue) Replace(val []string) error {
+	out := make([]netip.Prefix, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = s.fromString(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *ipPrefixSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = s.toString(d)
+	}
+	return out
+}
+
+// GetIPPrefixSlice returns the []netip.Prefix value of a flag with the given name
+func (fs *FlagSet) GetIPPrefixSlice(name string) ([]netip.Prefix, error) {
+	val, err := fs.getFlagValue(name, "ipPrefixSlice")
+	if err != nil {
+		return []netip.Prefix{}, err
+	}
+	return val.([]netip.Prefix), nil
+}
+
+// MustGetIPPrefixSlice is like GetIPPrefixSlice, but panics on error.
+func (fs *FlagSet) MustGetIPPrefixSlice(name string) []netip.Prefix {
+	val, err := fs.GetIPPrefixSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IPPrefixSliceVar defines a []netip.Prefix flag with specified name, default value, and usage string.
+// The argument p points to a []netip.Prefix variable in which to store the value of the flag.
+func (fs *FlagSet) IPPrefixSliceVar(p *[]netip.Prefix, name string, value []netip.Prefix, usage string, opts ...Opt) {
+	fs.Var(newIPPrefixSliceValue(value, p), name, usage, opts...)
+}
+
+// IPPrefixSliceVar defines a []netip.Prefix flag with specified name, default value, and usage string.
+// The argument p points to a []netip.Prefix variable in which to store the value of the flag.
+func IPPrefixSliceVar(p *[]netip.Prefix, name string, value []netip.Prefix, usage string, opts ...Opt) {
+	CommandLine.IPPrefixSliceVar(p, name, value, usage, opts...)
+}
+
+// IPPrefixSlice defines a []netip.Prefix flag with specified name, default value, and usage string.
+// The return value is the address of a []netip.Prefix variable that stores the value of the flag.
+func (fs *FlagSet) IPPrefixSlice(name string, value []netip.Prefix, usage string, opts ...Opt) *[]netip.Prefix {
+	var p []netip.Prefix
+	fs.IPPrefixSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// IPPrefixSlice defines a []netip.Prefix flag with specified name, default value, and usage string.
+// The return value is the address of a []netip.Prefix variable that stores the value of the flag.
+func IPPrefixSlice(name string, value []netip.Prefix, usage string, opts ...Opt) *[]netip.Prefix {
+	return CommandLine.IPPrefixSlice(name, value, usage, opts...)
+}
@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net/netip"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestIPPrefixSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault []netip.Prefix
+		input       []string
+		expectedErr string
+		expected    []netip.Prefix
+		visitor     func(f *zflag.Flag)
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: nil,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"blabla"},
+			expectedErr: `invalid argument "blabla" for "--prefix" flag: element 1: failed to parse IP prefix: "blabla"`,
+		},
+		{
+			name:        "no csv",
+			input:       []string{"10.0.0.0/8,2001:db8::/32"},
+			expectedErr: `invalid argument "10.0.0.0/8,2001:db8::/32" for "--prefix" flag: element 1: failed to parse IP prefix: "10.0.0.0/8,2001:db8::/32"`,
+		},
+		{
+			name:     "multiple values",
+			input:    []string{"10.0.0.0/8", " 2001:db8::/32 "},
+			expected: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8"), netip.MustParsePrefix("2001:db8::/32")},
+		},
+		{
+			name:        "overrides default values",
+			input:       []string{"2001:db8::/32"},
+			flagDefault: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+			expected:    []netip.Prefix{netip.MustParsePrefix("2001:db8::/32")},
+		},
+		{
+			name:  "as slice values",
+			input: []string{"10.0.0.0/8"},
+			visitor: func(f *zflag.Flag) {
+				sv := f.Value.(zflag.SliceValue)
+				_ = sv.Replace([]string{"2001:db8::/32"})
+				_ = sv.Append("10.0.0.0/8")
+			},
+			expected: []netip.Prefix{netip.MustParsePrefix("2001:db8::/32"), netip.MustParsePrefix("10.0.0.0/8")},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v []netip.Prefix
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.IPPrefixSliceVar(&v, "prefix", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--prefix", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+			assertDeepEqual(t, test.expected, v)
+
+			got, err := f.GetIPPrefixSlice("prefix")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetIPPrefixSlice("prefix"))
+		})
+	}
+}
+
+func TestIPPrefixSliceInvalidReplace(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.IPPrefixSlice("prefix", nil, "usage")
+	sv := f.Lookup("prefix").Value.(zflag.SliceValue)
+	assertErrMsg(t, `element 1: failed to parse IP prefix: "blabla"`, sv.Replace([]string{"blabla"}))
+	assertErrMsg(t, `element 1: failed to parse IP prefix: "blabla"`, sv.Append("blabla"))
+}
@@ -0,0 +1,92 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net/netip"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestIPPrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault netip.Prefix
+		input       []string
+		expectedErr string
+		expected    netip.Prefix
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: netip.Prefix{},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--prefix" flag: failed to parse IP prefix: ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"blabla"},
+			expectedErr: `invalid argument "blabla" for "--prefix" flag: failed to parse IP prefix: "blabla"`,
+		},
+		{
+			name:     "ipv4",
+			input:    []string{"192.168.0.0/16"},
+			expected: netip.MustParsePrefix("192.168.0.0/16"),
+		},
+		{
+			name:     "ipv6",
+			input:    []string{"2001:db8::/32"},
+			expected: netip.MustParsePrefix("2001:db8::/32"),
+		},
+		{
+			name:        "missing bits",
+			input:       []string{"10.0.0.0"},
+			expectedErr: `invalid argument "10.0.0.0" for "--prefix" flag: failed to parse IP prefix: "10.0.0.0"`,
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: netip.MustParsePrefix("10.0.0.0/8"),
+			expected:    netip.MustParsePrefix("10.0.0.0/8"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v netip.Prefix
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.IPPrefixVar(&v, "prefix", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--prefix", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			got, err := f.GetIPPrefix("prefix")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetIPPrefix("prefix"))
+
+			getter, err := f.Get("prefix")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, getter)
+		})
+	}
+}
@@ -34,7 +34,7 @@ func (s *ipSliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	ip := net.ParseIP(val)
 	if ip == nil {
-		return errors.New("invalid string being converted to IP address")
+		return NewElementError(elementPosition(s.changed, len(*s.value)), errors.New("invalid string being converted to IP address"))
 	}
 
 	if !s.changed {
@@ -30,22 +30,22 @@ func TestIPSlice(t *testing.T) {
 			expectedValues: []net.IP{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []net.IP{},
-			expectedErr: `invalid argument "" for "--ips" flag: invalid string being converted to IP address`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []net.IP{},
+			expectedValues: []net.IP{},
 		},
 		{
 			name:        "invalid ip",
 			input:       []string{"blabla"},
 			flagDefault: []net.IP{},
-			expectedErr: `invalid argument "blabla" for "--ips" flag: invalid string being converted to IP address`,
+			expectedErr: `invalid argument "blabla" for "--ips" flag: element 1: invalid string being converted to IP address`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"192.168.1.1,172.16.1.1"},
 			flagDefault: []net.IP{},
-			expectedErr: `invalid argument "192.168.1.1,172.16.1.1" for "--ips" flag: invalid string being converted to IP address`,
+			expectedErr: `invalid argument "192.168.1.1,172.16.1.1" for "--ips" flag: element 1: invalid string being converted to IP address`,
 		},
 		{
 			name:           "empty value passed",
@@ -0,0 +1,153 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// -- ipMaskSlice Value
+type ipMaskSliceValue struct {
+	value   *[]net.IPMask
+	changed bool
+}
+
+var _ Value = (*ipMaskSliceValue)(nil)
+var _ Getter = (*ipMaskSliceValue)(nil)
+var _ SliceValue = (*ipMaskSliceValue)(nil)
+var _ Typed = (*ipMaskSliceValue)(nil)
+
+func newIPMaskSliceValue(val []net.IPMask, p *[]net.IPMask) *ipMaskSliceValue {
+	imsv := new(ipMaskSliceValue)
+	imsv.value = p
+	*imsv.value = val
+	return imsv
+}
+
+func (s *ipMaskSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Set converts, and assigns, the IP mask argument string representation as the []net.IPMask value of this flag.
+// If Set is called on a flag that already has a []net.IPMask assigned, the newly converted values will be appended.
+func (s *ipMaskSliceValue) Set(val string) error {
+	mask, err := s.fromString(strings.TrimSpace(val))
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []net.IPMask{mask}
+	} else {
+		*s.value = append(*s.value, mask)
+	}
+
+	s.changed = true
+
+	return nil
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *ipMaskSliceValue) Type() string {
+	return "ipMaskSlice"
+}
+
+// String defines a "native" format for this net.IPMask slice flag value.
+func (s *ipMaskSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), ",") + "]"
+}
+
+func (s *ipMaskSliceValue) fromString(val string) (net.IPMask, error) {
+	mask := ParseIPv4Mask(val)
+	if mask == nil {
+		return nil, fmt.Errorf("failed to parse IP mask: %q", val)
+	}
+	return mask, nil
+}
+
+func (s *ipMaskSliceValue) toString(val net.IPMask) string {
+	return val.String()
+}
+
+func (s *ipMaskSliceValue) Append(val string) error {
+	i, err := s.fromString(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, i)
+	return nil
+}
+
+func (s *ipMaskSliceValue) Replace(val []string) error {
+	out := make([]net.IPMask, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = s.fromString(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *ipMaskSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = s.toString(d)
+	}
+	return out
+}
+
+// GetIPMaskSlice returns the []net.IPMask value of a flag with the given name
+func (fs *FlagSet) GetIPMaskSlice(name string) ([]net.IPMask, error) {
+	val, err := fs.getFlagValue(name, "ipMaskSlice")
+	if err != nil {
+		return []net.IPMask{}, err
+	}
+	return val.([]net.IPMask), nil
+}
+
+// MustGetIPMaskSlice is like GetIPMaskSlice, but panics on error.
+func (fs *FlagSet) MustGetIPMaskSlice(name string) []net.IPMask {
+	val, err := fs.GetIPMaskSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// IPMaskSliceVar defines a []net.IPMask flag with specified name, default value, and usage string.
+// The argument p points to a []net.IPMask variable in which to store the value of the flag.
+// Masks are parsed with ParseIPv4Mask.
+func (fs *FlagSet) IPMaskSliceVar(p *[]net.IPMask, name string, value []net.IPMask, usage string, opts ...Opt) {
+	fs.Var(newIPMaskSliceValue(value, p), name, usage, opts...)
+}
+
+// IPMaskSliceVar defines a []net.IPMask flag with specified name, default value, and usage string.
+// The argument p points to a []net.IPMask variable in which to store the value of the flag.
+// Masks are parsed with ParseIPv4Mask.
+func IPMaskSliceVar(p *[]net.IPMask, name string, value []net.IPMask, usage string, opts ...Opt) {
+	CommandLine.IPMaskSliceVar(p, name, value, usage, opts...)
+}
+
+// IPMaskSlice defines a []net.IPMask flag with specified name, default value, and usage string.
+// The return value is the address of a []net.IPMask variable that stores the value of the flag.
+func (fs *FlagSet) IPMaskSlice(name string, value []net.IPMask, usage string, opts ...Opt) *[]net.IPMask {
+	var p []net.IPMask
+	fs.IPMaskSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// IPMaskSlice defines a []net.IPMask flag with specified name, default value, and usage string.
+// The return value is the address of a []net.IPMask variable that stores the value of the flag.
+func IPMaskSlice(name string, value []net.IPMask, usage string, opts ...Opt) *[]net.IPMask {
+	return CommandLine.IPMaskSlice(name, value, usage, opts...)
+}
@@ -0,0 +1,106 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestIPMaskSlice(t *testing.T) {
+	tests := []struct {
+		name           string
+		flagDefault    []net.IPMask
+		input          []string
+		expectedErr    string
+		expectedValues []net.IPMask
+		expectedStr    string
+		visitor        func(f *zflag.Flag)
+	}{
+		{
+			name:           "no value passed",
+			input:          []string{},
+			flagDefault:    []net.IPMask{},
+			expectedValues: []net.IPMask{},
+			expectedStr:    "[]",
+		},
+		{
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []net.IPMask{},
+			expectedValues: []net.IPMask{},
+			expectedStr:    "[]",
+		},
+		{
+			name:        "invalid mask",
+			input:       []string{"255.255.255.0", "blabla"},
+			expectedErr: `invalid argument "blabla" for "--mask" flag: element 2: failed to parse IP mask: "blabla"`,
+		},
+		{
+			name:           "dotted and hex forms",
+			input:          []string{"255.255.255.0", "ffff0000"},
+			expectedValues: []net.IPMask{net.CIDRMask(24, 32), net.CIDRMask(16, 32)},
+			expectedStr:    "[ffffff00,ffff0000]",
+		},
+		{
+			name:           "overrides default values",
+			input:          []string{" 255.0.0.0 "},
+			flagDefault:    []net.IPMask{net.CIDRMask(24, 32)},
+			expectedValues: []net.IPMask{net.CIDRMask(8, 32)},
+			expectedStr:    "[ff000000]",
+		},
+		{
+			name:           "with default values",
+			input:          []string{},
+			flagDefault:    []net.IPMask{net.CIDRMask(24, 32)},
+			expectedValues: []net.IPMask{net.CIDRMask(24, 32)},
+			expectedStr:    "[ffffff00]",
+		},
+		{
+			name:  "sets values",
+			input: []string{"255.255.255.0"},
+			visitor: func(f *zflag.Flag) {
+				if val, ok := f.Value.(zflag.SliceValue); ok {
+					_ = val.Replace([]string{"255.255.0.0"})
+					_ = val.Append("255.0.0.0")
+				}
+			},
+			expectedValues: []net.IPMask{net.CIDRMask(16, 32), net.CIDRMask(8, 32)},
+			expectedStr:    "[ffff0000,ff000000]",
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var masks []net.IPMask
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.IPMaskSliceVar(&masks, "mask", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--mask", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+
+			assertDeepEqual(t, test.expectedValues, masks)
+			assertEqual(t, test.expectedStr, f.Lookup("mask").Value.String())
+
+			got, err := f.GetIPMaskSlice("mask")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, got)
+			assertDeepEqual(t, test.expectedValues, f.MustGetIPMaskSlice("mask"))
+		})
+	}
+}
@@ -37,7 +37,7 @@ func (s *ipNetSliceValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	_, n, err := net.ParseCIDR(val)
 	if err != nil {
-		return err
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
 	}
 	if n == nil {
 		return fmt.Errorf("invalid string being converted to CIDR: %s", val)
@@ -83,7 +83,7 @@ func (s *ipNetSliceValue) toString(val net.IPNet) string {
 func (s *ipNetSliceValue) Append(val string) error {
 	i, err := s.fromString(val)
 	if err != nil {
-		return err
+		return NewElementError(len(*s.value)+1, err)
 	}
 	*s.value = append(*s.value, i)
 	return nil
@@ -95,7 +95,7 @@ func (s *ipNetSliceValue) Replace(val []string) error {
 		var err error
 		out[i], err = s.fromString(d)
 		if err != nil {
-			return err
+			return NewElementError(i+1, err)
 		}
 	}
 	*s.value = out
@@ -0,0 +1,160 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// KeyValue is a single key=value pair, as used by KeyValueSliceVar.
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// String returns the pair in the form "key=value".
+func (kv KeyValue) String() string {
+	return kv.Key + "=" + kv.Value
+}
+
+func parseKeyValue(val string) (KeyValue, error) {
+	kv := strings.SplitN(val, "=", 2)
+	if len(kv) != 2 {
+		return KeyValue{}, fmt.Errorf("%q must be formatted as key=value", val)
+	}
+	return KeyValue{Key: kv[0], Value: kv[1]}, nil
+}
+
+// -- keyValueSlice Value
+type keyValueSliceValue struct {
+	value   *[]KeyValue
+	changed bool
+}
+
+var _ Value = (*keyValueSliceValue)(nil)
+var _ Getter = (*keyValueSliceValue)(nil)
+var _ SliceValue = (*keyValueSliceValue)(nil)
+var _ Typed = (*keyValueSliceValue)(nil)
+
+func newKeyValueSliceValue(val []KeyValue, p *[]KeyValue) *keyValueSliceValue {
+	kvsv := new(keyValueSliceValue)
+	kvsv.value = p
+	*kvsv.value = val
+	return kvsv
+}
+
+// Set parses, and appends, the key=value argument to the []KeyValue value of this flag,
+// keeping the order and duplicate keys of repeated arguments.
+func (s *keyValueSliceValue) Set(val string) error {
+	kv, err := parseKeyValue(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []KeyValue{}
+	}
+	*s.value = append(*s.value, kv)
+
+	s.changed = true
+
+	return nil
+}
+
+func (s *keyValueSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *keyValueSliceValue) Type() string {
+	return "keyValueSlice"
+}
+
+// String defines a "native" format for this KeyValue slice flag value.
+func (s *keyValueSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), " ") + "]"
+}
+
+func (s *keyValueSliceValue) Append(val string) error {
+	kv, err := parseKeyValue(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, kv)
+	return nil
+}
+
+func (s *keyValueSliceValue) Replace(val []string) error {
+	out := make([]KeyValue, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = parseKeyValue(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *keyValueSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = d.String()
+	}
+	return out
+}
+
+// GetKeyValueSlice returns the []KeyValue value of a flag with the given name
+func (fs *FlagSet) GetKeyValueSlice(name string) ([]KeyValue, error) {
+	val, err := fs.getFlagValue(name, "keyValueSlice")
+	if err != nil {
+		return []KeyValue{}, err
+	}
+	return val.([]KeyValue), nil
+}
+
+// MustGetKeyValueSlice is like GetKeyValueSlice, but panics on error.
+func (fs *FlagSet) MustGetKeyValueSlice(name string) []KeyValue {
+	val, err := fs.GetKeyValueSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// KeyValueSliceVar defines a []KeyValue flag with specified name, default value, and usage string.
+// The argument p points to a []KeyValue variable in which to store the value of the flag.
+// Unlike a map flag, the order and duplicates of repeated key=value arguments are kept.
+func (fs *FlagSet) KeyValueSliceVar(p *[]KeyValue, name string, value []KeyValue, usage string, opts ...Opt) {
+	fs.Var(newKeyValueSliceValue(value, p), name, usage, opts...)
+}
+
+// KeyValueSliceVar defines a []KeyValue flag with specified name, default value, and usage string.
+// The argument p points to a []KeyValue variable in which to store the value of the flag.
+// Unlike a map flag, the order and duplicates of repeated key=value arguments are kept.
+func KeyValueSliceVar(p *[]KeyValue, name string, value []KeyValue, usage string, opts ...Opt) {
+	CommandLine.KeyValueSliceVar(p, name, value, usage, opts...)
+}
+
+// KeyValueSlice defines a []KeyValue flag with specified name, default value, and usage string.
+// The return value is the address of a []KeyValue variable that stores the value of the flag.
+// Unlike a map flag, the order and duplicates of repeated key=value arguments are kept.
+func (fs *FlagSet) KeyValueSlice(name string, value []KeyValue, usage string, opts ...Opt) *[]KeyValue {
+	var p []KeyValue
+	fs.KeyValueSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// KeyValueSlice defines a []KeyValue flag with specified name, default value, and usage string.
+// The return value is the address of a []KeyValue variable that stores the value of the flag.
+// Unlike a map flag, the order and duplicates of repeated key=value arguments are kept.
+func KeyValueSlice(name string, value []KeyValue, usage string, opts ...Opt) *[]KeyValue {
+	return CommandLine.KeyValueSlice(name, value, usage, opts...)
+}
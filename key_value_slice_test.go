@@ -0,0 +1,103 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestKeyValueSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault []zflag.KeyValue
+		input       []string
+		expectedErr string
+		expected    []zflag.KeyValue
+		expectedStr string
+		visitor     func(f *zflag.Flag)
+	}{
+		{
+			name:        "no value passed",
+			input:       []string{},
+			expected:    nil,
+			expectedStr: "[]",
+		},
+		{
+			name:        "missing separator",
+			input:       []string{"a=1", "blabla"},
+			expectedErr: `invalid argument "blabla" for "--header" flag: element 2: "blabla" must be formatted as key=value`,
+		},
+		{
+			name:  "keeps order and duplicates",
+			input: []string{"Accept=text/html", "X-Forwarded-For=a", "X-Forwarded-For=b"},
+			expected: []zflag.KeyValue{
+				{Key: "Accept", Value: "text/html"},
+				{Key: "X-Forwarded-For", Value: "a"},
+				{Key: "X-Forwarded-For", Value: "b"},
+			},
+			expectedStr: "[Accept=text/html X-Forwarded-For=a X-Forwarded-For=b]",
+		},
+		{
+			name:        "value containing separator",
+			input:       []string{"query=a=b", "empty="},
+			expected:    []zflag.KeyValue{{Key: "query", Value: "a=b"}, {Key: "empty", Value: ""}},
+			expectedStr: "[query=a=b empty=]",
+		},
+		{
+			name:        "overrides default values",
+			input:       []string{"b=2"},
+			flagDefault: []zflag.KeyValue{{Key: "a", Value: "1"}},
+			expected:    []zflag.KeyValue{{Key: "b", Value: "2"}},
+			expectedStr: "[b=2]",
+		},
+		{
+			name:  "as slice values",
+			input: []string{"a=1"},
+			visitor: func(f *zflag.Flag) {
+				sv := f.Value.(zflag.SliceValue)
+				_ = sv.Replace([]string{"b=2"})
+				_ = sv.Append("b=3")
+			},
+			expected:    []zflag.KeyValue{{Key: "b", Value: "2"}, {Key: "b", Value: "3"}},
+			expectedStr: "[b=2 b=3]",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var headers []zflag.KeyValue
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.KeyValueSliceVar(&headers, "header", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--header", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+			assertDeepEqual(t, test.expected, headers)
+			assertEqual(t, test.expectedStr, f.Lookup("header").Value.String())
+
+			got, err := f.GetKeyValueSlice("header")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetKeyValueSlice("header"))
+
+			sv := f.Lookup("header").Value.(zflag.SliceValue)
+			assertErrMsg(t, `element 1: "bad" must be formatted as key=value`, sv.Replace([]string{"bad"}))
+		})
+	}
+}
@@ -0,0 +1,104 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package zflag
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+)
+
+// -- slog.Level value
+type logLevelValue slog.Level
+
+var _ Value = (*logLevelValue)(nil)
+var _ Getter = (*logLevelValue)(nil)
+var _ Typed = (*logLevelValue)(nil)
+var _ ChoicesValue = (*logLevelValue)(nil)
+
+func newLogLevelValue(val slog.Level, p *slog.Level) *logLevelValue {
+	*p = val
+	return (*logLevelValue)(p)
+}
+
+func (v *logLevelValue) String() string {
+	return strings.ToLower(slog.Level(*v).String())
+}
+
+// Set accepts the level names debug, info, warn and error, optionally followed
+// by an offset such as info+2, as well as numeric levels.
+func (v *logLevelValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	if n, err := strconv.Atoi(val); err == nil {
+		*v = logLevelValue(n)
+		return nil
+	}
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(val)); err != nil {
+		return fmt.Errorf("invalid log level %q, expected one of %s, optionally with an offset, or a number", val, strings.Join(v.Choices(), ", "))
+	}
+	*v = logLevelValue(level)
+	return nil
+}
+
+func (v *logLevelValue) Get() interface{} {
+	return slog.Level(*v)
+}
+
+func (v *logLevelValue) Type() string {
+	return "logLevel"
+}
+
+func (v *logLevelValue) Choices() []string {
+	return []string{"debug", "info", "warn", "error"}
+}
+
+// GetLogLevel return the slog.Level value of a flag with the given name
+func (fs *FlagSet) GetLogLevel(name string) (slog.Level, error) {
+	val, err := fs.getFlagValue(name, "logLevel")
+	if err != nil {
+		return 0, err
+	}
+	return val.(slog.Level), nil
+}
+
+// MustGetLogLevel is like GetLogLevel, but panics on error.
+func (fs *FlagSet) MustGetLogLevel(name string) slog.Level {
+	val, err := fs.GetLogLevel(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// LogLevelVar defines a slog.Level flag with specified name, default value, and usage string.
+// The argument p points to a slog.Level variable in which to store the value of the flag.
+func (fs *FlagSet) LogLevelVar(p *slog.Level, name string, value slog.Level, usage string, opts ...Opt) {
+	fs.Var(newLogLevelValue(value, p), name, usage, opts...)
+}
+
+// LogLevelVar defines a slog.Level flag with specified name, default value, and usage string.
+// The argument p points to a slog.Level variable in which to store the value of the flag.
+func LogLevelVar(p *slog.Level, name string, value slog.Level, usage string, opts ...Opt) {
+	CommandLine.LogLevelVar(p, name, value, usage, opts...)
+}
+
+// LogLevel defines a slog.Level flag with specified name, default value, and usage string.
+// The return value is the address of a slog.Level variable that stores the value of the flag.
+func (fs *FlagSet) LogLevel(name string, value slog.Level, usage string, opts ...Opt) *slog.Level {
+	var p slog.Level
+	fs.LogLevelVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// LogLevel defines a slog.Level flag with specified name, default value, and usage string.
+// The return value is the address of a slog.Level variable that stores the value of the flag.
+func LogLevel(name string, value slog.Level, usage string, opts ...Opt) *slog.Level {
+	return CommandLine.LogLevel(name, value, usage, opts...)
+}
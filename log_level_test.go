@@ -0,0 +1,130 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.21
+// +build go1.21
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestLogLevel(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault slog.Level
+		input       []string
+		expectedErr string
+		expected    slog.Level
+		expectedStr string
+	}{
+		{
+			name:        "no value passed",
+			input:       []string{},
+			expected:    slog.LevelInfo,
+			expectedStr: "info",
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--level" flag: invalid log level "", expected one of debug, info, warn, error, optionally with an offset, or a number`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"verbose"},
+			expectedErr: `invalid argument "verbose" for "--level" flag: invalid log level "verbose", expected one of debug, info, warn, error, optionally with an offset, or a number`,
+		},
+		{
+			name:        "name",
+			input:       []string{"debug"},
+			expected:    slog.LevelDebug,
+			expectedStr: "debug",
+		},
+		{
+			name:        "upper case name",
+			input:       []string{"WARN"},
+			expected:    slog.LevelWarn,
+			expectedStr: "warn",
+		},
+		{
+			name:        "name with offset",
+			input:       []string{"error+2"},
+			expected:    slog.LevelError + 2,
+			expectedStr: "error+2",
+		},
+		{
+			name:        "numeric",
+			input:       []string{"-8"},
+			expected:    slog.Level(-8),
+			expectedStr: "debug-4",
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: slog.LevelWarn,
+			expected:    slog.LevelWarn,
+			expectedStr: "warn",
+		},
+		{
+			name:        "trims input",
+			input:       []string{"    info    "},
+			expected:    slog.LevelInfo,
+			expectedStr: "info",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v slog.Level
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.LogLevelVar(&v, "level", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--level", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+			assertEqual(t, test.expectedStr, f.Lookup("level").Value.String())
+
+			got, err := f.GetLogLevel("level")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetLogLevel("level"))
+
+			getter, err := f.Get("level")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, getter)
+		})
+	}
+}
+
+func TestLogLevelChoices(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.LogLevel("level", slog.LevelInfo, "log level")
+	f.SetOutput(&buf)
+	f.PrintDefaults()
+	assertEqual(t, "      --level debug|info|warn|error   log level (default info)\n", buf.String())
+
+	buf.Reset()
+	assertNoErr(t, f.WriteHelpJSON(&buf))
+	if !strings.Contains(buf.String(), `"choices": [`) {
+		t.Fatalf("expected choices in help JSON, got %s", buf.String())
+	}
+}
@@ -0,0 +1,93 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
+// -- net.HardwareAddr value
+type macValue net.HardwareAddr
+
+var _ Value = (*macValue)(nil)
+var _ Getter = (*macValue)(nil)
+var _ Typed = (*macValue)(nil)
+
+func newMACValue(val net.HardwareAddr, p *net.HardwareAddr) *macValue {
+	*p = val
+	return (*macValue)(p)
+}
+
+func parseMAC(val string) (net.HardwareAddr, error) {
+	val = strings.TrimSpace(val)
+	mac, err := net.ParseMAC(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse MAC address: %q", val)
+	}
+	return mac, nil
+}
+
+func (m *macValue) String() string { return net.HardwareAddr(*m).String() }
+func (m *macValue) Set(val string) error {
+	mac, err := parseMAC(val)
+	if err != nil {
+		return err
+	}
+	*m = macValue(mac)
+	return nil
+}
+
+func (m *macValue) Get() interface{} {
+	return net.HardwareAddr(*m)
+}
+
+func (m *macValue) Type() string {
+	return "mac"
+}
+
+// GetMAC return the net.HardwareAddr value of a flag with the given name
+func (fs *FlagSet) GetMAC(name string) (net.HardwareAddr, error) {
+	val, err := fs.getFlagValue(name, "mac")
+	if err != nil {
+		return nil, err
+	}
+	return val.(net.HardwareAddr), nil
+}
+
+// MustGetMAC is like GetMAC, but panics on error.
+func (fs *FlagSet) MustGetMAC(name string) net.HardwareAddr {
+	val, err := fs.GetMAC(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// MACVar defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The argument p points to a net.HardwareAddr variable in which to store the value of the flag.
+func (fs *FlagSet) MACVar(p *net.HardwareAddr, name string, value net.HardwareAddr, usage string, opts ...Opt) {
+	fs.Var(newMACValue(value, p), name, usage, opts...)
+}
+
+// MACVar defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The argument p points to a net.HardwareAddr variable in which to store the value of the flag.
+func MACVar(p *net.HardwareAddr, name string, value net.HardwareAddr, usage string, opts ...Opt) {
+	CommandLine.MACVar(p, name, value, usage, opts...)
+}
+
+// MAC defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The return value is the address of a net.HardwareAddr variable that stores the value of the flag.
+func (fs *FlagSet) MAC(name string, value net.HardwareAddr, usage string, opts ...Opt) *net.HardwareAddr {
+	var p net.HardwareAddr
+	fs.MACVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// MAC defines a net.HardwareAddr flag with specified name, default value, and usage string.
+// The return value is the address of a net.HardwareAddr variable that stores the value of the flag.
+func MAC(name string, value net.HardwareAddr, usage string, opts ...Opt) *net.HardwareAddr {
+	return CommandLine.MAC(name, value, usage, opts...)
+}
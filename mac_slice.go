@@ -0,0 +1,137 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"net"
+)
+
+// -- macSlice Value
+type macSliceValue struct {
+	value   *[]net.HardwareAddr
+	changed bool
+}
+
+var _ Value = (*macSliceValue)(nil)
+var _ Getter = (*macSliceValue)(nil)
+var _ SliceValue = (*macSliceValue)(nil)
+var _ Typed = (*macSliceValue)(nil)
+
+func newMACSliceValue(val []net.HardwareAddr, p *[]net.HardwareAddr) *macSliceValue {
+	msv := new(macSliceValue)
+	msv.value = p
+	*msv.value = val
+	return msv
+}
+
+// Set converts, and assigns, the MAC argument string representation as the []net.HardwareAddr value of this flag.
+// If Set is called on a flag that already has a []net.HardwareAddr assigned, the newly converted values will be appended.
+func (s *macSliceValue) Set(val string) error {
+	mac, err := parseMAC(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []net.HardwareAddr{}
+	}
+	*s.value = append(*s.value, mac)
+
+	s.changed = true
+
+	return nil
+}
+
+func (s *macSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *macSliceValue) Type() string {
+	return "macSlice"
+}
+
+// String defines a "native" format for this net.HardwareAddr slice flag value.
+func (s *macSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return fmt.Sprintf("%s", *s.value)
+}
+
+func (s *macSliceValue) Append(val string) error {
+	mac, err := parseMAC(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, mac)
+	return nil
+}
+
+func (s *macSliceValue) Replace(val []string) error {
+	out := make([]net.HardwareAddr, len(val))
+	for i, d := range val {
+		mac, err := parseMAC(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+		out[i] = mac
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *macSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = d.String()
+	}
+	return out
+}
+
+// GetMACSlice returns the []net.HardwareAddr value of a flag with the given name
+func (fs *FlagSet) GetMACSlice(name string) ([]net.HardwareAddr, error) {
+	val, err := fs.getFlagValue(name, "macSlice")
+	if err != nil {
+		return []net.HardwareAddr{}, err
+	}
+	return val.([]net.HardwareAddr), nil
+}
+
+// MustGetMACSlice is like GetMACSlice, but panics on error.
+func (fs *FlagSet) MustGetMACSlice(name string) []net.HardwareAddr {
+	val, err := fs.GetMACSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// MACSliceVar defines a []net.HardwareAddr flag with specified name, default value, and usage string.
+// The argument p points to a []net.HardwareAddr variable in which to store the value of the flag.
+func (fs *FlagSet) MACSliceVar(p *[]net.HardwareAddr, name string, value []net.HardwareAddr, usage string, opts ...Opt) {
+	fs.Var(newMACSliceValue(value, p), name, usage, opts...)
+}
+
+// MACSliceVar defines a []net.HardwareAddr flag with specified name, default value, and usage string.
+// The argument p points to a []net.HardwareAddr variable in which to store the value of the flag.
+func MACSliceVar(p *[]net.HardwareAddr, name string, value []net.HardwareAddr, usage string, opts ...Opt) {
+	CommandLine.MACSliceVar(p, name, value, usage, opts...)
+}
+
+// MACSlice defines a []net.HardwareAddr flag with specified name, default value, and usage string.
+// The return value is the address of a []net.HardwareAddr variable that stores the value of the flag.
+func (fs *FlagSet) MACSlice(name string, value []net.HardwareAddr, usage string, opts ...Opt) *[]net.HardwareAddr {
+	var p []net.HardwareAddr
+	fs.MACSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// MACSlice defines a []net.HardwareAddr flag with specified name, default value, and usage string.
+// The return value is the address of a []net.HardwareAddr variable that stores the value of the flag.
+func MACSlice(name string, value []net.HardwareAddr, usage string, opts ...Opt) *[]net.HardwareAddr {
+	return CommandLine.MACSlice(name, value, usage, opts...)
+}
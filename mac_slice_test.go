@@ -0,0 +1,95 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestMACSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault []net.HardwareAddr
+		input       []string
+		expectedErr string
+		expected    []net.HardwareAddr
+		expectedStr string
+		visitor     func(f *zflag.Flag)
+	}{
+		{
+			name:        "no value passed",
+			input:       []string{},
+			expected:    nil,
+			expectedStr: "[]",
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"00:1a:2b:3c:4d:5e", "blabla"},
+			expectedErr: `invalid argument "blabla" for "--macs" flag: element 2: failed to parse MAC address: "blabla"`,
+		},
+		{
+			name:        "multiple values",
+			input:       []string{"00:1a:2b:3c:4d:5e", "02-00-00-00-00-01"},
+			expected:    []net.HardwareAddr{mustParseMAC("00:1a:2b:3c:4d:5e"), mustParseMAC("02:00:00:00:00:01")},
+			expectedStr: "[00:1a:2b:3c:4d:5e 02:00:00:00:00:01]",
+		},
+		{
+			name:        "overrides default values",
+			input:       []string{"00:1a:2b:3c:4d:5e"},
+			flagDefault: []net.HardwareAddr{mustParseMAC("02:00:00:00:00:01")},
+			expected:    []net.HardwareAddr{mustParseMAC("00:1a:2b:3c:4d:5e")},
+			expectedStr: "[00:1a:2b:3c:4d:5e]",
+		},
+		{
+			name:  "as slice values",
+			input: []string{"00:1a:2b:3c:4d:5e"},
+			visitor: func(f *zflag.Flag) {
+				sv := f.Value.(zflag.SliceValue)
+				_ = sv.Replace([]string{"02:00:00:00:00:01"})
+				_ = sv.Append("02:00:00:00:00:02")
+			},
+			expected:    []net.HardwareAddr{mustParseMAC("02:00:00:00:00:01"), mustParseMAC("02:00:00:00:00:02")},
+			expectedStr: "[02:00:00:00:00:01 02:00:00:00:00:02]",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var macs []net.HardwareAddr
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.MACSliceVar(&macs, "macs", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--macs", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+			assertDeepEqual(t, test.expected, macs)
+			assertEqual(t, test.expectedStr, f.Lookup("macs").Value.String())
+
+			got, err := f.GetMACSlice("macs")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetMACSlice("macs"))
+
+			sv := f.Lookup("macs").Value.(zflag.SliceValue)
+			assertErrMsg(t, `failed to parse MAC address: ""`, errors.Unwrap(sv.Append("")))
+		})
+	}
+}
@@ -0,0 +1,99 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func mustParseMAC(s string) net.HardwareAddr {
+	mac, err := net.ParseMAC(s)
+	if err != nil {
+		panic(err)
+	}
+	return mac
+}
+
+func TestMAC(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault net.HardwareAddr
+		input       []string
+		expectedErr string
+		expected    net.HardwareAddr
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: nil,
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--mac" flag: failed to parse MAC address: ""`,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"00:11:22"},
+			expectedErr: `invalid argument "00:11:22" for "--mac" flag: failed to parse MAC address: "00:11:22"`,
+		},
+		{
+			name:     "colon separated",
+			input:    []string{"00:1a:2b:3c:4d:5e"},
+			expected: mustParseMAC("00:1a:2b:3c:4d:5e"),
+		},
+		{
+			name:     "hyphen separated",
+			input:    []string{"00-1A-2B-3C-4D-5E"},
+			expected: mustParseMAC("00:1a:2b:3c:4d:5e"),
+		},
+		{
+			name:     "dot separated",
+			input:    []string{"001a.2b3c.4d5e"},
+			expected: mustParseMAC("00:1a:2b:3c:4d:5e"),
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: mustParseMAC("02:00:00:00:00:01"),
+			expected:    mustParseMAC("02:00:00:00:00:01"),
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    00:1a:2b:3c:4d:5e    "},
+			expected: mustParseMAC("00:1a:2b:3c:4d:5e"),
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v net.HardwareAddr
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.MACVar(&v, "mac", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--mac", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, v)
+
+			got, err := f.GetMAC("mac")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetMAC("mac"))
+			assertEqual(t, test.expected.String(), f.Lookup("mac").Value.String())
+		})
+	}
+}
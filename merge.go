@@ -0,0 +1,138 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FlagConflict describes a flag that is defined differently by two FlagSets
+// merged with AddFlagSetDedup.
+type FlagConflict struct {
+	Name     string
+	Existing *Flag
+	Other    *Flag
+}
+
+// FlagConflictsError is returned by AddFlagSetDedup when flags of the merged
+// FlagSets have conflicting definitions.
+type FlagConflictsError []FlagConflict
+
+var _ error = (*FlagConflictsError)(nil)
+
+func (e FlagConflictsError) Error() string {
+	conflicts := make([]string, 0, len(e))
+	for _, c := range e {
+		conflicts = append(conflicts, fmt.Sprintf("%q (%s)", getFlagWithDashes(c.Name), describeConflict(c.Existing, c.Other)))
+	}
+
+	return fmt.Sprintf("conflicting definitions of flag(s) %s", strings.Join(conflicts, ", "))
+}
+
+func describeConflict(existing, other *Flag) string {
+	switch {
+	case existing.Name != other.Name:
+		return fmt.Sprintf("shorthand %q is used by %q", other.Shorthand, getFlagWithDashes(existing.Name))
+	case flagTypeName(existing) != flagTypeName(other):
+		return fmt.Sprintf("type %s vs %s", flagTypeName(existing), flagTypeName(other))
+	case existing.DefValue != other.DefValue:
+		return fmt.Sprintf("default %q vs %q", existing.DefValue, other.DefValue)
+	}
+	return fmt.Sprintf("shorthand %q vs %q", existing.Shorthand, other.Shorthand)
+}
+
+// flagTypeName returns the type of the flag's value, as reported by Typed,
+// falling back to the Go type.
+func flagTypeName(flag *Flag) string {
+	if v, ok := flag.Value.(Typed); ok {
+		return v.Type()
+	}
+	return fmt.Sprintf("%T", flag.Value)
+}
+
+// sameDefinition reports whether both flags have the same type, default and
+// shorthand.
+func sameDefinition(a, b *Flag) bool {
+	return flagTypeName(a) == flagTypeName(b) && a.DefValue == b.DefValue && a.Shorthand == b.Shorthand
+}
+
+// AddFlagSetDedup adds the flags of other to the FlagSet, like AddFlagSet,
+// but tolerates flags defined by both sets, as emitted by code generators
+// producing overlapping sets. Definitions with the same name, type, default
+// and shorthand are unified silently, keeping the flag of the FlagSet. All
+// other duplicates are returned as a FlagConflictsError, after merging the
+// remaining flags. For those, the existing definition is kept if
+// preferExisting is set, and replaced by the one of other otherwise. A flag
+// whose shorthand is used by a different flag is never added.
+func (fs *FlagSet) AddFlagSetDedup(other *FlagSet, preferExisting bool) error {
+	if other == nil {
+		return nil
+	}
+
+	var conflicts FlagConflictsError
+	other.VisitAll(func(flag *Flag) {
+		name := fs.normalizeFlagName(flag.Name)
+		existing := fs.lookup(name)
+		if existing != nil && sameDefinition(existing, flag) {
+			return
+		}
+
+		if flag.Shorthand != 0 {
+			if used, ok := fs.shorthands[flag.Shorthand]; ok && used != existing {
+				conflicts = append(conflicts, FlagConflict{Name: flag.Name, Existing: used, Other: flag})
+				return
+			}
+		}
+
+		if existing == nil {
+			fs.AddFlag(flag)
+			return
+		}
+
+		conflicts = append(conflicts, FlagConflict{Name: existing.Name, Existing: existing, Other: flag})
+		if !preferExisting {
+			fs.replaceFlag(name, existing, flag)
+		}
+	})
+
+	if len(conflicts) > 0 {
+		return conflicts
+	}
+	return nil
+}
+
+// replaceFlag replaces the definition of the existing flag by flag, keeping
+// its position.
+func (fs *FlagSet) replaceFlag(name NormalizedName, existing, flag *Flag) {
+	flag.Name = string(name)
+	fs.formal[name] = flag
+	fs.InvalidateUsageCache()
+	for i, f := range fs.orderedFormal {
+		if f == existing {
+			fs.orderedFormal[i] = flag
+		}
+	}
+
+	if existing.Shorthand != 0 {
+		delete(fs.shorthands, existing.Shorthand)
+	}
+	if flag.Shorthand != 0 {
+		if fs.shorthands == nil {
+			fs.shorthands = make(map[rune]*Flag)
+		}
+		fs.shorthands[flag.Shorthand] = flag
+	}
+
+	if _, ok := fs.actual[name]; ok {
+		delete(fs.actual, name)
+		for i, f := range fs.orderedActual {
+			if f == existing {
+				fs.orderedActual = append(fs.orderedActual[:i], fs.orderedActual[i+1:]...)
+				break
+			}
+		}
+	}
+	fs.deriveEnvVar(flag)
+}
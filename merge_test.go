@@ -0,0 +1,99 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func newMergeFlagSets() (*zflag.FlagSet, *zflag.FlagSet) {
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("host", "localhost", "host", zflag.OptShorthand('H'))
+	fs.Int("port", 80, "port")
+	fs.Bool("verbose", false, "verbose", zflag.OptShorthand('v'))
+
+	other := zflag.NewFlagSet("other", zflag.ContinueOnError)
+	other.SetOutput(ioutil.Discard)
+	other.String("host", "localhost", "generated host", zflag.OptShorthand('H'))
+	other.Int("port", 8080, "port")
+	other.Bool("version", false, "version", zflag.OptShorthand('v'))
+	other.Duration("timeout", 0, "timeout")
+	return fs, other
+}
+
+func TestAddFlagSetDedup(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		preferExisting bool
+		expectedPort   string
+	}{
+		{name: "prefer existing", preferExisting: true, expectedPort: "80"},
+		{name: "prefer other", preferExisting: false, expectedPort: "8080"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs, other := newMergeFlagSets()
+			existingHost := fs.Lookup("host")
+
+			err := fs.AddFlagSetDedup(other, test.preferExisting)
+			assertErrMsg(t, `conflicting definitions of flag(s) "--port" (default "80" vs "8080"), "--version" (shorthand 'v' is used by "--verbose")`, err)
+
+			var conflicts zflag.FlagConflictsError
+			if !errors.As(err, &conflicts) {
+				t.Fatalf("expected a FlagConflictsError, got %T", err)
+			}
+			assertEqual(t, 2, len(conflicts))
+			assertEqual(t, "port", conflicts[0].Name)
+			assertEqual(t, fs.Lookup("verbose"), conflicts[1].Existing)
+			assertEqual(t, other.Lookup("version"), conflicts[1].Other)
+
+			assertEqual(t, existingHost, fs.Lookup("host"))
+			assertEqual(t, test.expectedPort, fs.Lookup("port").DefValue)
+			assertEqual(t, (*zflag.Flag)(nil), fs.Lookup("version"))
+			assertEqual(t, other.Lookup("timeout"), fs.Lookup("timeout"))
+			assertEqual(t, "verbose", fs.ShorthandLookup('v').Name)
+
+			assertNoErr(t, fs.Parse([]string{"-H", "example.com", "--port=9090", "--timeout=1s"}))
+			assertEqual(t, "example.com", fs.MustGetString("host"))
+			assertEqual(t, 9090, fs.MustGetInt("port"))
+		})
+	}
+}
+
+func TestAddFlagSetDedupIdentical(t *testing.T) {
+	t.Parallel()
+
+	fs, _ := newMergeFlagSets()
+	other := zflag.NewFlagSet("other", zflag.ContinueOnError)
+	other.String("host", "localhost", "host", zflag.OptShorthand('H'))
+	other.Int("port", 80, "port")
+
+	assertNoErr(t, fs.AddFlagSetDedup(other, false))
+	assertNoErr(t, fs.AddFlagSetDedup(nil, false))
+	assertEqual(t, 3, len(fs.GetAllFlags()))
+}
+
+func TestAddFlagSetDedupReplacedShorthand(t *testing.T) {
+	t.Parallel()
+
+	fs, _ := newMergeFlagSets()
+	other := zflag.NewFlagSet("other", zflag.ContinueOnError)
+	other.String("host", "localhost", "host", zflag.OptShorthand('a'))
+
+	err := fs.AddFlagSetDedup(other, false)
+	assertErrMsg(t, `conflicting definitions of flag(s) "--host" (shorthand 'H' vs 'a')`, err)
+	assertEqual(t, (*zflag.Flag)(nil), fs.ShorthandLookup('H'))
+	assertEqual(t, other.Lookup("host"), fs.ShorthandLookup('a'))
+}
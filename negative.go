@@ -0,0 +1,90 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// negativeForm is the value of the hidden flag registered for the negated form
+// of a flag, see RegisterNegativeFlags. Setting it sets the original flag to
+// the negated value.
+type negativeForm struct {
+	fs   *FlagSet
+	flag *Flag
+}
+
+var _ Value = (*negativeForm)(nil)
+
+func (v *negativeForm) Set(val string) error {
+	if negatable, ok := v.flag.Value.(NegatableValue); ok {
+		negated, err := negatable.Negate(val)
+		if err != nil {
+			return err
+		}
+		return v.fs.Set(v.flag.Name, negated)
+	}
+
+	enabled := true
+	if val != "" {
+		var err error
+		if enabled, err = strconv.ParseBool(val); err != nil {
+			return err
+		}
+	}
+	return v.fs.Set(v.flag.Name, strconv.FormatBool(!enabled))
+}
+
+func (v *negativeForm) String() string {
+	return ""
+}
+
+func (v *negativeForm) Type() string {
+	if typed, ok := v.flag.Value.(Typed); ok {
+		return typed.Type()
+	}
+	return ""
+}
+
+// registerNegativeFlag registers the negated form of flag as a hidden flag,
+// see RegisterNegativeFlags.
+func (fs *FlagSet) registerNegativeFlag(flag *Flag) {
+	flag.negative = &Flag{
+		Name:    fs.negativePrefix() + flag.Name,
+		Usage:   flag.Usage,
+		Value:   &negativeForm{fs: fs, flag: flag},
+		Hidden:  true,
+		Group:   flag.Group,
+		negates: flag,
+	}
+	fs.AddFlag(flag.negative)
+}
+
+// addNegativeShorthand registers the shorthand of the negated form of flag,
+// see OptNegativeShorthand.
+func (fs *FlagSet) addNegativeShorthand(flag *Flag) {
+	if flag.NegativeShorthand == 0 {
+		return
+	}
+	if !isNegatable(flag) {
+		msg := fmt.Sprintf("unable to add negative shorthand %q to %q flag: the flag is not negatable", flag.NegativeShorthand, flag.Name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+
+	used, alreadyThere := fs.shorthands[flag.NegativeShorthand]
+	if !alreadyThere {
+		used, alreadyThere = fs.negShorthands[flag.NegativeShorthand]
+	}
+	if alreadyThere {
+		msg := fmt.Sprintf("unable to redefine %q shorthand in %q flagset: it's already used for %q flag", flag.NegativeShorthand, fs.name, used.Name)
+		fmt.Fprintln(fs.Output(), msg)
+		panic(msg)
+	}
+	if fs.negShorthands == nil {
+		fs.negShorthands = make(map[rune]*Flag)
+	}
+	fs.negShorthands[flag.NegativeShorthand] = flag
+}
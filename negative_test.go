@@ -0,0 +1,277 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestNegativePrefix(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		register    bool
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "negated bool",
+			input: []string{"--disable-color"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+				assertEqual(t, true, fs.Changed("color"))
+			},
+		},
+		{
+			name:  "negated slice element",
+			input: []string{"--feature=a", "--disable-feature=b"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertDeepEqual(t, []zflag.Toggle{{Name: "a", Enabled: true}, {Name: "b"}}, fs.MustGetToggleSlice("feature"))
+			},
+		},
+		{
+			name:        "default prefix is replaced",
+			input:       []string{"--no-color"},
+			expectedErr: "unknown flag: --no-color",
+		},
+		{
+			name:        "negated bool with value",
+			input:       []string{"--disable-color=true"},
+			expectedErr: "flag cannot have a value: --disable-color=true",
+		},
+		{
+			name:     "registered negated bool",
+			register: true,
+			input:    []string{"--disable-color"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+				assertEqual(t, true, fs.Changed("color"))
+				assertEqual(t, false, fs.Changed("disable-color"))
+
+				negative := fs.Lookup("disable-color")
+				assertEqual(t, true, negative.Hidden)
+				assertEqual(t, "bool", negative.Value.(zflag.Typed).Type())
+			},
+		},
+		{
+			name:     "registered negated slice element",
+			register: true,
+			input:    []string{"--feature=a", "--disable-feature", "b"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertDeepEqual(t, []zflag.Toggle{{Name: "a", Enabled: true}, {Name: "b"}}, fs.MustGetToggleSlice("feature"))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.DisableSuggestions = true
+			fs.NegativePrefix = "disable-"
+			fs.RegisterNegativeFlags = test.register
+			fs.Bool("color", true, "usage", zflag.OptAddNegative())
+			fs.ToggleSlice("feature", nil, "usage")
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestNegativePrefixUsage(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithNegativePrefix("without-"), zflag.WithRegisterNegativeFlags())
+	fs.Bool("color", true, "use colors", zflag.OptAddNegative())
+
+	assertEqual(t, "      --[without-]color   use colors (default true)\n", fs.FlagUsages())
+
+	var out bytes.Buffer
+	assertNoErr(t, fs.WriteHelpJSON(&out))
+	proxy, err := zflag.ReadHelpJSON(&out)
+	assertNoErr(t, err)
+	assertEqual(t, "without-", proxy.NegativePrefix)
+	assertNoErr(t, proxy.Parse([]string{"--without-color"}))
+	assertDeepEqual(t, []string{"--color=false"}, proxy.ToArgs())
+}
+
+func TestRegisterNegativeFlags(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithOutput(ioutil.Discard), zflag.WithRegisterNegativeFlags())
+	fs.Bool("color", true, "usage", zflag.OptAddNegative())
+
+	assertNoErr(t, fs.Set("no-color", ""))
+	assertEqual(t, false, fs.MustGetBool("color"))
+
+	fs.RemoveFlag("color")
+	if fs.Lookup("no-color") != nil {
+		t.Fatal("expected the negated form to be removed with the flag")
+	}
+
+	defer assertPanic(t)()
+	fs.Bool("no-verbose", false, "usage")
+	fs.Bool("verbose", false, "usage", zflag.OptAddNegative())
+}
+
+func TestNegatedFlagNameIsNotNegated(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test", zflag.WithOutput(ioutil.Discard))
+	fs.Bool("no-cache", false, "usage")
+
+	assertNoErr(t, fs.Parse([]string{"--no-cache"}))
+	assertEqual(t, true, fs.MustGetBool("no-cache"))
+	if !strings.Contains(fs.FlagUsages(), "--no-cache") {
+		t.Fatalf("expected usage to contain --no-cache, got %q", fs.FlagUsages())
+	}
+}
+
+func TestNegativeShorthand(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, fs *zflag.FlagSet)
+	}{
+		{
+			name:  "negated bool",
+			input: []string{"-N"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, false, fs.MustGetBool("color"))
+				assertEqual(t, true, fs.Changed("color"))
+			},
+		},
+		{
+			name:  "cluster",
+			input: []string{"-vNc"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertEqual(t, true, fs.MustGetBool("verbose"))
+				assertEqual(t, true, fs.MustGetBool("color"))
+			},
+		},
+		{
+			name:  "negated slice element",
+			input: []string{"-f", "a", "-F", "b", "-Fd"},
+			expected: func(t *testing.T, fs *zflag.FlagSet) {
+				assertDeepEqual(t, []zflag.Toggle{{Name: "a", Enabled: true}, {Name: "b"}, {Name: "d"}}, fs.MustGetToggleSlice("feature"))
+			},
+		},
+		{
+			name:        "negated bool with value",
+			input:       []string{"-N=true"},
+			expectedErr: `flag cannot have a value: 'N' in -N=true`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+			fs.Bool("color", false, "usage", zflag.OptShorthand('c'), zflag.OptAddNegative(), zflag.OptNegativeShorthand('N'))
+			fs.ToggleSlice("feature", nil, "usage", zflag.OptShorthand('f'), zflag.OptNegativeShorthand('F'))
+
+			err := fs.Parse(test.input)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			test.expected(t, fs)
+		})
+	}
+}
+
+func TestNegativeShorthandUsage(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.New("test")
+	fs.Bool("color", true, "use colors", zflag.OptShorthand('c'), zflag.OptAddNegative(), zflag.OptNegativeShorthand('C'))
+	fs.Bool("progress", true, "show progress", zflag.OptAddNegative(), zflag.OptNegativeShorthand('P'))
+	fs.Bool("quiet", false, "be quiet")
+
+	expected := `  -c, -C, --[no-]color   use colors (default true)
+  -P, --[no-]progress    show progress (default true)
+      --quiet            be quiet
+`
+	assertEqualf(t, expected, fs.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, fs.FlagUsages())
+
+	var out bytes.Buffer
+	assertNoErr(t, fs.WriteHelpJSON(&out))
+	proxy, err := zflag.ReadHelpJSON(&out)
+	assertNoErr(t, err)
+	assertNoErr(t, proxy.Parse([]string{"-P"}))
+	assertDeepEqual(t, []string{"--progress=false"}, proxy.ToArgs())
+}
+
+func TestNegativeShorthandInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		define func(fs *zflag.FlagSet)
+	}{
+		{
+			name: "not negatable",
+			define: func(fs *zflag.FlagSet) {
+				fs.Bool("color", false, "usage", zflag.OptNegativeShorthand('C'))
+			},
+		},
+		{
+			name: "used by a shorthand",
+			define: func(fs *zflag.FlagSet) {
+				fs.Bool("color", false, "usage", zflag.OptShorthand('c'))
+				fs.Bool("cache", false, "usage", zflag.OptAddNegative(), zflag.OptNegativeShorthand('c'))
+			},
+		},
+		{
+			name: "used by a negative shorthand",
+			define: func(fs *zflag.FlagSet) {
+				fs.Bool("color", false, "usage", zflag.OptAddNegative(), zflag.OptNegativeShorthand('C'))
+				fs.Bool("cache", false, "usage", zflag.OptShorthand('C'))
+			},
+		},
+		{
+			name: "same as the shorthand",
+			define: func(fs *zflag.FlagSet) {
+				fs.Bool("color", false, "usage", zflag.OptShorthand('c'), zflag.OptAddNegative(), zflag.OptNegativeShorthand('c'))
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			fs.SetOutput(ioutil.Discard)
+
+			defer assertPanic(t)()
+			test.define(fs)
+		})
+	}
+}
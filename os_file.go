@@ -0,0 +1,184 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// stdioPath is the path that refers to stdin, or stdout for files opened for
+// writing.
+const stdioPath = "-"
+
+func fileValueOf(f *Flag) (*fileValue, error) {
+	if v, ok := f.Value.(*fileValue); ok {
+		return v, nil
+	}
+	return nil, fmt.Errorf("flag %s is not a file flag", f.Name)
+}
+
+// OptMustExist requires the path of file flags to exist, which is useful
+// together with OptCreate or OptOpenMode to fail on missing files instead of
+// creating them.
+func OptMustExist() Opt {
+	return func(f *Flag) error {
+		v, err := fileValueOf(f)
+		if err != nil {
+			return err
+		}
+		v.mustExist = true
+		return nil
+	}
+}
+
+// OptCreate opens file flags for writing, creating or truncating the file,
+// with "-" referring to stdout.
+func OptCreate() Opt {
+	return OptOpenMode(os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o666)
+}
+
+// OptOpenMode sets the flag and permissions passed to os.OpenFile for file
+// flags. If flag opens the file for writing, "-" refers to stdout.
+func OptOpenMode(flag int, perm os.FileMode) Opt {
+	return func(f *Flag) error {
+		v, err := fileValueOf(f)
+		if err != nil {
+			return err
+		}
+		v.flag = flag
+		v.perm = perm
+		return nil
+	}
+}
+
+// -- *os.File Value
+type fileValue struct {
+	value     **os.File
+	path      string
+	flag      int
+	perm      os.FileMode
+	mustExist bool
+}
+
+var _ Value = (*fileValue)(nil)
+var _ Getter = (*fileValue)(nil)
+var _ Typed = (*fileValue)(nil)
+
+func newFileValue(val string, p **os.File) *fileValue {
+	*p = nil
+	return &fileValue{value: p, path: val, flag: os.O_RDONLY}
+}
+
+func (f *fileValue) Set(val string) error {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return errors.New("file path must not be empty")
+	}
+
+	if f.mustExist && val != stdioPath {
+		if _, err := os.Stat(val); errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("file %q does not exist", val)
+		}
+	}
+
+	file, err := f.open(val)
+	if err != nil {
+		return err
+	}
+
+	f.close()
+	*f.value = file
+	f.path = val
+	return nil
+}
+
+func (f *fileValue) open(path string) (*os.File, error) {
+	if path != stdioPath {
+		return os.OpenFile(path, f.flag, f.perm)
+	}
+	if f.flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		return os.Stdout, nil
+	}
+	return os.Stdin, nil
+}
+
+// close closes the file opened previously, as it is replaced by a new one.
+func (f *fileValue) close() {
+	if file := *f.value; file != nil && file != os.Stdin && file != os.Stdout {
+		_ = file.Close()
+	}
+}
+
+func (f *fileValue) Get() interface{} {
+	return *f.value
+}
+
+func (f *fileValue) Type() string {
+	return "file"
+}
+
+func (f *fileValue) String() string { return f.path }
+
+// applyDefault opens the default path if the flag was not set, as it
+// cannot be opened before all options of the flag are applied.
+func (f *fileValue) applyDefault() error {
+	if f.path == "" || *f.value != nil {
+		return nil
+	}
+	return f.Set(f.path)
+}
+
+// GetFile return the *os.File value of a flag with the given name
+func (fs *FlagSet) GetFile(name string) (*os.File, error) {
+	val, err := fs.getFlagValue(name, "file")
+	if err != nil {
+		return nil, err
+	}
+	return val.(*os.File), nil
+}
+
+// MustGetFile is like GetFile, but panics on error.
+func (fs *FlagSet) MustGetFile(name string) *os.File {
+	val, err := fs.GetFile(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// FileVar defines a file flag with specified name, default path, and usage string.
+// The argument p points to an *os.File variable in which to store the file
+// opened by the flag, which is opened for reading unless OptCreate or
+// OptOpenMode is used. The path "-" refers to stdin, or stdout when writing.
+// The default path, if not empty, is opened when the flags are parsed and the
+// flag was not set.
+func (fs *FlagSet) FileVar(p **os.File, name string, value string, usage string, opts ...Opt) {
+	fs.Var(newFileValue(value, p), name, usage, opts...)
+}
+
+// FileVar defines a file flag with specified name, default path, and usage string.
+// The argument p points to an *os.File variable in which to store the file
+// opened by the flag. See FlagSet.FileVar for more information.
+func FileVar(p **os.File, name string, value string, usage string, opts ...Opt) {
+	CommandLine.FileVar(p, name, value, usage, opts...)
+}
+
+// File defines a file flag with specified name, default path, and usage string.
+// The return value is the address of an *os.File variable that stores the file
+// opened by the flag. See FlagSet.FileVar for more information.
+func (fs *FlagSet) File(name string, value string, usage string, opts ...Opt) **os.File {
+	var p *os.File
+	fs.FileVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// File defines a file flag with specified name, default path, and usage string.
+// The return value is the address of an *os.File variable that stores the file
+// opened by the flag. See FlagSet.FileVar for more information.
+func File(name string, value string, usage string, opts ...Opt) **os.File {
+	return CommandLine.File(name, value, usage, opts...)
+}
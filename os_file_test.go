@@ -0,0 +1,153 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "existing.txt")
+	assertNoErr(t, ioutil.WriteFile(existing, []byte("contents"), 0o600))
+	missing := filepath.Join(dir, "missing.txt")
+
+	tests := []struct {
+		name        string
+		flagDefault string
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    func(t *testing.T, file *os.File)
+	}{
+		{
+			name:  "no value passed",
+			input: []string{},
+			expected: func(t *testing.T, file *os.File) {
+				assertEqual(t, (*os.File)(nil), file)
+			},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--file" flag: file path must not be empty`,
+		},
+		{
+			name:  "reads existing file",
+			input: []string{existing},
+			expected: func(t *testing.T, file *os.File) {
+				contents, err := ioutil.ReadAll(file)
+				assertNoErr(t, err)
+				assertEqual(t, "contents", string(contents))
+			},
+		},
+		{
+			name:  "stdin",
+			input: []string{"-"},
+			expected: func(t *testing.T, file *os.File) {
+				assertEqual(t, os.Stdin, file)
+			},
+		},
+		{
+			name:  "stdout",
+			opts:  []zflag.Opt{zflag.OptCreate()},
+			input: []string{"-"},
+			expected: func(t *testing.T, file *os.File) {
+				assertEqual(t, os.Stdout, file)
+			},
+		},
+		{
+			name:        "must exist",
+			opts:        []zflag.Opt{zflag.OptCreate(), zflag.OptMustExist()},
+			input:       []string{missing},
+			expectedErr: fmt.Sprintf(`invalid argument %q for "--file" flag: file %q does not exist`, missing, missing),
+		},
+		{
+			name:        "default path",
+			flagDefault: "-",
+			input:       []string{},
+			expected: func(t *testing.T, file *os.File) {
+				assertEqual(t, os.Stdin, file)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v *os.File
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.FileVar(&v, "file", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--file", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			if v != nil && v != os.Stdin && v != os.Stdout {
+				defer v.Close()
+			}
+			test.expected(t, v)
+
+			got, err := f.GetFile("file")
+			assertNoErr(t, err)
+			assertEqual(t, v, got)
+			assertEqual(t, v, f.MustGetFile("file"))
+		})
+	}
+}
+
+func TestFileMissing(t *testing.T) {
+	t.Parallel()
+
+	missing := filepath.Join(t.TempDir(), "missing.txt")
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.File("file", missing, "usage")
+
+	err := f.Parse(nil)
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("expected a not exist error, got %v", err)
+	}
+}
+
+func TestFileCreate(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "out.txt")
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	out := f.File("out", "", "usage", zflag.OptCreate())
+	assertNoErr(t, f.Parse([]string{"--out", path}))
+
+	_, err := (*out).WriteString("written")
+	assertNoErr(t, err)
+	assertNoErr(t, (*out).Close())
+
+	contents, err := ioutil.ReadFile(path)
+	assertNoErr(t, err)
+	assertEqual(t, "written", string(contents))
+	assertEqual(t, path, f.Lookup("out").Value.String())
+}
+
+func TestOptMustExistInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.String("file", "", "usage", zflag.OptMustExist())
+}
@@ -0,0 +1,64 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package zflag
+
+import (
+	"errors"
+	"iter"
+)
+
+// ParseIter parses the arguments like Parse, but yields each flag and
+// positional argument in order instead of setting the flags, so the caller
+// decides which flags to apply with ParsedItem.Apply, and in which order.
+// Parsing stops when the caller stops iterating.
+//
+// Once all arguments are yielded, the environment variables, implications
+// and defaults are applied to the flags that were not set, and the flags are
+// validated. Errors are yielded with an empty ParsedItem and end the
+// iteration; the error handling of the flag set is not applied, so ParseIter
+// never exits or panics on errors. ErrHelp and ErrVersion are yielded like
+// other errors.
+func (fs *FlagSet) ParseIter(arguments []string) iter.Seq2[ParsedItem, error] {
+	return func(yield func(ParsedItem, error) bool) {
+		arguments, err := fs.prepareParse(arguments)
+		if err != nil {
+			yield(ParsedItem{}, err)
+			return
+		}
+		fs.args = make([]string, 0, len(arguments))
+
+		yielded := 0
+		yieldPositional := func() bool {
+			for ; yielded < len(fs.args); yielded++ {
+				if !yield(ParsedItem{Value: fs.args[yielded], fs: fs}, nil) {
+					return false
+				}
+			}
+			return true
+		}
+
+		complete, err := fs.scanArgs(arguments, func(flag *Flag, value string) error {
+			if !yieldPositional() || !yield(ParsedItem{Flag: flag, Value: value, fs: fs}, nil) {
+				return errStopIteration
+			}
+			return nil
+		})
+		switch {
+		case errors.Is(err, errStopIteration):
+			return
+		case err != nil:
+			yield(ParsedItem{}, err)
+			return
+		case !yieldPositional() || !complete:
+			return
+		}
+
+		if err := fs.finishParse(fs.setArg); err != nil {
+			yield(ParsedItem{}, err)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.23
+// +build go1.23
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestParseIter(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("name", "", "usage", zflag.OptShorthand('n'))
+	fs.Int("count", 1, "usage")
+	fs.Bool("verbose", false, "usage", zflag.OptShorthand('v'))
+
+	var got []string
+	for item, err := range fs.ParseIter([]string{"a", "--name", "x", "-v", "b", "--count=3", "--", "-c"}) {
+		assertNoErr(t, err)
+		if item.Positional() {
+			got = append(got, "pos:"+item.Value)
+			continue
+		}
+		got = append(got, item.Flag.Name+"="+item.Value)
+		if item.Flag.Name != "count" {
+			assertNoErr(t, item.Apply())
+		}
+	}
+
+	assertDeepEqual(t, []string{"pos:a", "name=x", "verbose=", "pos:b", "count=3", "pos:-c"}, got)
+	assertEqual(t, "x", fs.MustGetString("name"))
+	assertEqual(t, true, fs.MustGetBool("verbose"))
+	assertEqual(t, 1, fs.MustGetInt("count"))
+	assertEqual(t, false, fs.Changed("count"))
+	assertDeepEqual(t, []string{"a", "b", "-c"}, fs.Args())
+}
+
+func TestParseIterStop(t *testing.T) {
+	t.Parallel()
+
+	fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	fs.String("name", "", "usage")
+	fs.String("other", "", "usage", zflag.OptRequired())
+
+	var got []string
+	for item, err := range fs.ParseIter([]string{"--name", "x", "--other", "y"}) {
+		assertNoErr(t, err)
+		got = append(got, item.Flag.Name)
+		break
+	}
+	assertDeepEqual(t, []string{"name"}, got)
+	assertEqual(t, false, fs.Changed("name"))
+}
+
+func TestParseIterErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       []string
+		apply       bool
+		expectedErr string
+	}{
+		{
+			name:        "unknown flag",
+			input:       []string{"--unknown"},
+			expectedErr: "unknown flag: --unknown",
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"--count", "x"},
+			apply:       true,
+			expectedErr: `invalid argument "x" for "--count" flag: strconv.ParseInt: parsing "x": invalid syntax`,
+		},
+		{
+			name:        "required flag not applied",
+			input:       []string{"--count", "2"},
+			expectedErr: `required flag(s) "--count" not set`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			fs := zflag.NewFlagSet("test", zflag.PanicOnError)
+			fs.SetOutput(ioutil.Discard)
+			fs.Int("count", 1, "usage", zflag.OptRequired())
+
+			var lastErr error
+			for item, err := range fs.ParseIter(test.input) {
+				if err != nil {
+					lastErr = err
+					continue
+				}
+				if test.apply {
+					if lastErr = item.Apply(); lastErr != nil {
+						break
+					}
+				}
+			}
+			assertErrMsg(t, test.expectedErr, lastErr)
+		})
+	}
+}
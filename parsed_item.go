@@ -0,0 +1,39 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import "errors"
+
+// errStopIteration is returned by the parse function of ParseIter to stop
+// parsing when the consumer stops iterating.
+var errStopIteration = errors.New("zflag: iteration stopped")
+
+// ParsedItem is a flag or positional argument yielded by ParseIter, in the
+// order it appeared on the command line.
+type ParsedItem struct {
+	// Flag is the parsed flag, or nil for a positional argument.
+	Flag *Flag
+	// Value is the value of the flag, or the positional argument itself. It
+	// is empty for bool and optional flags given without a value.
+	Value string
+
+	fs *FlagSet
+}
+
+// Positional reports whether the item is a positional argument.
+func (item ParsedItem) Positional() bool {
+	return item.Flag == nil
+}
+
+// Apply sets the flag to the parsed value, the way Parse would have. It does
+// nothing for positional arguments, which are available from Args anyway.
+func (item ParsedItem) Apply() error {
+	if item.Flag == nil {
+		return nil
+	}
+	if err := item.fs.setArg(item.Flag, item.Value); err != nil {
+		return item.fs.failf("%w", err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// OptPath treats the value of the flag as a file path. Both / and \ are
+// accepted as separators and converted to the separator of the operating
+// system, and the path is cleaned. Relative paths are resolved against the
+// base directory set with SetPathBase, if any. The values "" and "-", which
+// commonly refers to stdin or stdout, are passed on unmodified.
+func OptPath() Opt {
+	return func(f *Flag) error {
+		f.Path = true
+		return nil
+	}
+}
+
+// SetPathBase sets the directory relative values of flags defined with
+// OptPath are resolved against. This is typically set to the directory of a
+// config file while its values are applied, so paths in the config file are
+// relative to the config file rather than the working directory. An empty dir
+// leaves relative paths unmodified.
+func (fs *FlagSet) SetPathBase(dir string) {
+	fs.pathBase = dir
+}
+
+// SetPathBase sets the base directory for relative paths of the command-line
+// flags. See FlagSet.SetPathBase for more information.
+func SetPathBase(dir string) {
+	CommandLine.SetPathBase(dir)
+}
+
+// PathBase returns the directory relative paths are resolved against, as
+// set by SetPathBase.
+func (fs *FlagSet) PathBase() string {
+	return fs.pathBase
+}
+
+// normalizePath normalizes the value of a flag defined with OptPath.
+func (fs *FlagSet) normalizePath(value string) string {
+	if value == "" || value == "-" {
+		return value
+	}
+
+	path := filepath.Clean(filepath.FromSlash(strings.ReplaceAll(value, `\`, "/")))
+	if fs.pathBase != "" && !filepath.IsAbs(path) && filepath.VolumeName(path) == "" {
+		path = filepath.Join(fs.pathBase, path)
+	}
+	return path
+}
@@ -0,0 +1,77 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptPath(t *testing.T) {
+	t.Parallel()
+
+	base := t.TempDir()
+	abs := filepath.Join(base, "abs", "file.txt")
+
+	tests := []struct {
+		name     string
+		base     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "mixed separators",
+			input:    `dir\sub/file.txt`,
+			expected: filepath.Join("dir", "sub", "file.txt"),
+		},
+		{
+			name:     "cleaned",
+			input:    "dir/../other/./file.txt",
+			expected: filepath.Join("other", "file.txt"),
+		},
+		{
+			name:     "relative to base",
+			base:     base,
+			input:    "conf/file.txt",
+			expected: filepath.Join(base, "conf", "file.txt"),
+		},
+		{
+			name:     "absolute ignores base",
+			base:     filepath.Join(base, "other"),
+			input:    abs,
+			expected: abs,
+		},
+		{
+			name:     "stdin",
+			base:     base,
+			input:    "-",
+			expected: "-",
+		},
+		{
+			name:     "empty",
+			base:     base,
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			path := f.String("path", "default", "usage", zflag.OptPath())
+			f.SetPathBase(test.base)
+			assertEqual(t, test.base, f.PathBase())
+
+			assertNoErr(t, f.Parse([]string{"--path=" + test.input}))
+			assertEqualf(t, test.expected, *path, "expected %q, got %q", test.expected, *path)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// portConstraints are the optional checks of port flags, set with
+// OptPortAllowZero.
+type portConstraints struct {
+	allowZero bool
+}
+
+func (c *portConstraints) parse(val string) (uint16, error) {
+	val = strings.TrimSpace(val)
+	port, err := strconv.ParseUint(val, 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid port %q, expected a number between 1 and 65535", val)
+	}
+	if port == 0 && !c.allowZero {
+		return 0, fmt.Errorf("invalid port %q, expected a number between 1 and 65535", val)
+	}
+	return uint16(port), nil
+}
+
+func portConstraintsOf(f *Flag) (*portConstraints, error) {
+	switch v := f.Value.(type) {
+	case *portValue:
+		return &v.constraints, nil
+	case *hostPortValue:
+		return &v.constraints, nil
+	}
+	return nil, fmt.Errorf("flag %s is not a port flag", f.Name)
+}
+
+// OptPortAllowZero allows port flags to be set to 0, which usually means a
+// port is picked automatically
+func OptPortAllowZero() Opt {
+	return func(f *Flag) error {
+		c, err := portConstraintsOf(f)
+		if err != nil {
+			return err
+		}
+		c.allowZero = true
+		return nil
+	}
+}
+
+// -- port Value
+type portValue struct {
+	value       *uint16
+	constraints portConstraints
+}
+
+var _ Value = (*portValue)(nil)
+var _ Getter = (*portValue)(nil)
+var _ Typed = (*portValue)(nil)
+
+func newPortValue(val uint16, p *uint16) *portValue {
+	*p = val
+	return &portValue{value: p}
+}
+
+func (p *portValue) Set(val string) error {
+	port, err := p.constraints.parse(val)
+	if err != nil {
+		return err
+	}
+	*p.value = port
+	return nil
+}
+
+func (p *portValue) Get() interface{} {
+	return *p.value
+}
+
+func (p *portValue) Type() string {
+	return "port"
+}
+
+func (p *portValue) String() string { return strconv.FormatUint(uint64(*p.value), 10) }
+
+// GetPort return the port value of a flag with the given name
+func (fs *FlagSet) GetPort(name string) (uint16, error) {
+	val, err := fs.getFlagValue(name, "port")
+	if err != nil {
+		return 0, err
+	}
+	return val.(uint16), nil
+}
+
+// MustGetPort is like GetPort, but panics on error.
+func (fs *FlagSet) MustGetPort(name string) uint16 {
+	val, err := fs.GetPort(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// PortVar defines a port flag with specified name, default value, and usage string.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+// Ports must be between 1 and 65535, or 0 as well with OptPortAllowZero.
+func (fs *FlagSet) PortVar(p *uint16, name string, value uint16, usage string, opts ...Opt) {
+	fs.Var(newPortValue(value, p), name, usage, opts...)
+}
+
+// PortVar defines a port flag with specified name, default value, and usage string.
+// The argument p points to a uint16 variable in which to store the value of the flag.
+// Ports must be between 1 and 65535, or 0 as well with OptPortAllowZero.
+func PortVar(p *uint16, name string, value uint16, usage string, opts ...Opt) {
+	CommandLine.PortVar(p, name, value, usage, opts...)
+}
+
+// Port defines a port flag with specified name, default value, and usage string.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+func (fs *FlagSet) Port(name string, value uint16, usage string, opts ...Opt) *uint16 {
+	var p uint16
+	fs.PortVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Port defines a port flag with specified name, default value, and usage string.
+// The return value is the address of a uint16 variable that stores the value of the flag.
+func Port(name string, value uint16, usage string, opts ...Opt) *uint16 {
+	return CommandLine.Port(name, value, usage, opts...)
+}
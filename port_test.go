@@ -0,0 +1,100 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestPort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault uint16
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    uint16
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: 0,
+		},
+		{
+			name:        "invalid value",
+			input:       []string{"http"},
+			expectedErr: `invalid argument "http" for "--port" flag: invalid port "http", expected a number between 1 and 65535`,
+		},
+		{
+			name:        "out of range",
+			input:       []string{"65536"},
+			expectedErr: `invalid argument "65536" for "--port" flag: invalid port "65536", expected a number between 1 and 65535`,
+		},
+		{
+			name:        "zero not allowed",
+			input:       []string{"0"},
+			expectedErr: `invalid argument "0" for "--port" flag: invalid port "0", expected a number between 1 and 65535`,
+		},
+		{
+			name:     "zero allowed",
+			opts:     []zflag.Opt{zflag.OptPortAllowZero()},
+			input:    []string{"0"},
+			expected: 0,
+		},
+		{
+			name:     "valid port",
+			input:    []string{"8080"},
+			expected: 8080,
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: 443,
+			expected:    443,
+		},
+		{
+			name:     "trims input",
+			input:    []string{"    65535    "},
+			expected: 65535,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v uint16
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.PortVar(&v, "port", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--port", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			got, err := f.GetPort("port")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetPort("port"))
+		})
+	}
+}
+
+func TestOptPortAllowZeroInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.Uint16("port", 0, "usage", zflag.OptPortAllowZero())
+}
@@ -82,3 +82,186 @@ func TestPrintUsage_2(t *testing.T) {
 		t.Errorf("Expected \n%q \nActual \n%q", expectedOutput2, res)
 	}
 }
+
+const expectedOutputUnicode = "      --name string       the name\n" +
+	"      --名前 string       名前を指定します\n" +
+	"      --emoji-🚀 string   launch target\n" +
+	"      --u\u0308mlaut string     with combining u\u0308\n"
+
+func TestPrintUsage_Unicode(t *testing.T) {
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ExitOnError)
+	f.SortFlags = false
+	f.String("name", "", "the name")
+	f.String("名前", "", "名前を指定します")
+	f.String("emoji-🚀", "", "launch target")
+	f.String("u\u0308mlaut", "", "with combining u\u0308")
+	f.SetOutput(&buf)
+
+	res := f.FlagUsages()
+	if res != expectedOutputUnicode {
+		t.Errorf("Expected \n%s \nActual \n%s", expectedOutputUnicode, res)
+	}
+}
+
+func TestPrintUsage_UnicodeWrapped(t *testing.T) {
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ExitOnError)
+	f.String("名前", "", "名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前 名前")
+	f.SetOutput(&buf)
+
+	expected := `      --名前 string   名前 名前 名前 名前 名前 名前 名前 名前 名前 名前
+                      名前 名前 名前 名前 名前 名前 名前 名前
+`
+	res := f.FlagUsagesWrapped(80)
+	if res != expected {
+		t.Errorf("Expected \n%s \nActual \n%s", expected, res)
+	}
+}
+
+func TestPrintUsage_ShowDeprecated(t *testing.T) {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("name", "", "the name", zflag.OptRequired())
+	f.Bool("old", false, "old flag", zflag.OptDeprecated("use --new instead"))
+	f.Bool("new", false, "new flag", zflag.OptShorthand('n'), zflag.OptShorthandDeprecated("use --new instead"))
+	f.Bool("secret", false, "secret flag", zflag.OptHidden())
+
+	expected := `      --name string   the name (required)
+      --new           new flag
+`
+	if res := f.FlagUsages(); res != expected {
+		t.Errorf("Expected \n%s \nActual \n%s", expected, res)
+	}
+
+	f.ShowDeprecated = true
+	expected = `      --name string   the name (required)
+      --new           new flag (DEPRECATED shorthand -n: use --new instead)
+      --old           old flag (DEPRECATED: use --new instead)
+`
+	if res := f.FlagUsages(); res != expected {
+		t.Errorf("Expected \n%s \nActual \n%s", expected, res)
+	}
+}
+
+func TestPrintUsage_VisibleWhen(t *testing.T) {
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	mode := f.String("mode", "client", "mode to run in")
+	f.String("listen", ":8080", "address to listen on", zflag.OptGroup("Server"), zflag.OptVisibleWhen(func(fs *zflag.FlagSet) bool {
+		return *mode == "server"
+	}))
+	f.String("server", "", "server to connect to", zflag.OptVisibleWhen(func(fs *zflag.FlagSet) bool {
+		return *mode == "client"
+	}))
+
+	err := f.Parse([]string{"--mode=server", "--help"})
+	assertEqual(t, zflag.ErrHelp, err)
+	expected := `Usage of test:
+      --mode string     mode to run in (default "client")
+
+Server:
+      --listen string   address to listen on (default ":8080")
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+
+	buf.Reset()
+	err = f.Parse([]string{"--mode=client", "--help"})
+	assertEqual(t, zflag.ErrHelp, err)
+	expected = `Usage of test:
+      --mode string     mode to run in (default "client")
+      --server string   server to connect to
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+// optionalValue is a Value whose value may be omitted.
+type optionalValue string
+
+func (v *optionalValue) String() string { return string(*v) }
+
+func (v *optionalValue) Set(val string) error {
+	if val == "" {
+		val = "default"
+	}
+	*v = optionalValue(val)
+	return nil
+}
+
+func (v *optionalValue) Type() string { return "string" }
+
+func (v *optionalValue) IsOptional() bool { return true }
+
+func TestPrintUsage_OptionalValue(t *testing.T) {
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	var profile, short optionalValue
+	f.Var(&profile, "profile", "profile `name` to use", zflag.OptShorthand('p'))
+	f.Var(&short, "short", "shorthand only", zflag.OptShorthand('s'), zflag.OptShorthandOnly())
+	f.String("required", "", "value required", zflag.OptShorthand('r'), zflag.OptShorthandOnly())
+	f.Count("verbose", "verbosity")
+	f.PrintDefaults()
+
+	expected := `  -p, --profile[=name]    profile name to use
+  -r string               value required
+  -s[string]              shorthand only
+      --verbose[=count]   verbosity
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+func TestPrintUsage_DefaultText(t *testing.T) {
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Int("workers", 8, "number of workers", zflag.OptDefaultText("number of CPUs"))
+	f.String("token", "", "api token", zflag.OptSecret(), zflag.OptDefaultText("read from keyring"))
+	f.Int("hidden-default", 3, "usage", zflag.OptDefaultText("three"), zflag.OptDisablePrintDefault())
+	f.PrintDefaults()
+
+	expected := `      --hidden-default int   usage
+      --token string         api token (default read from keyring)
+      --workers int          number of workers (default number of CPUs)
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+	assertEqual(t, "8", f.Lookup("workers").DefValue)
+}
+
+func TestPrintUsage_Cache(t *testing.T) {
+	f := zflag.New("test", zflag.WithUsageCache())
+	mode := f.String("mode", "client", "mode to run in")
+	f.String("server", "", "server to connect to", zflag.OptVisibleWhen(func(fs *zflag.FlagSet) bool {
+		return *mode == "client"
+	}))
+	f.Int("port", 80, "port")
+
+	expected := `      --mode string     mode to run in (default "client")
+      --port int        port (default 80)
+      --server string   server to connect to
+`
+	assertEqualf(t, expected, f.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, f.FlagUsages())
+
+	// definition changes that are not tracked require an invalidation
+	f.Lookup("port").Usage = "listen port"
+	assertEqual(t, expected, f.FlagUsages())
+	f.InvalidateUsageCache()
+	expected = `      --mode string     mode to run in (default "client")
+      --port int        listen port (default 80)
+      --server string   server to connect to
+`
+	assertEqualf(t, expected, f.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, f.FlagUsages())
+
+	*mode = "server"
+	expected = `      --mode string   mode to run in (default "client")
+      --port int      listen port (default 80)
+`
+	assertEqualf(t, expected, f.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, f.FlagUsages())
+
+	f.Bool("verbose", false, "verbose output")
+	expected = `      --mode string   mode to run in (default "client")
+      --port int      listen port (default 80)
+      --verbose       verbose output
+`
+	assertEqualf(t, expected, f.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, f.FlagUsages())
+}
@@ -0,0 +1,157 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// -- proxy Value
+// proxyValue stores the values of a flag read by ReadHelpJSON as strings,
+// validating them against the choices of the original flag.
+type proxyValue struct {
+	typeName  string
+	choices   []string
+	delimiter string
+	values    []string
+	changed   bool
+	repeated  bool
+}
+
+var _ Value = (*proxyValue)(nil)
+var _ Getter = (*proxyValue)(nil)
+var _ ChoicesValue = (*proxyValue)(nil)
+
+func newProxyValue(f helpJSONFlag) *proxyValue {
+	pv := &proxyValue{
+		typeName:  f.Type,
+		choices:   f.Choices,
+		delimiter: f.Delimiter,
+		repeated:  f.Repeatable,
+	}
+	if f.Default != "" && !f.Repeatable {
+		pv.values = []string{f.Default}
+	}
+	return pv
+}
+
+func (p *proxyValue) Set(val string) error {
+	if err := p.validate(val); err != nil {
+		return err
+	}
+
+	if !p.changed || !p.repeated {
+		p.values = nil
+	}
+	p.values = append(p.values, val)
+	p.changed = true
+	return nil
+}
+
+func (p *proxyValue) validate(val string) error {
+	if len(p.choices) == 0 || val == "" {
+		return nil
+	}
+
+	elements := []string{val}
+	if p.delimiter != "" {
+		elements = strings.Split(val, p.delimiter)
+	}
+	for _, element := range elements {
+		if !containsString(p.choices, element) {
+			return fmt.Errorf("must be one of %s", strings.Join(p.choices, ", "))
+		}
+	}
+	return nil
+}
+
+func (p *proxyValue) Get() interface{} {
+	return p.String()
+}
+
+func (p *proxyValue) Type() string {
+	return p.typeName
+}
+
+func (p *proxyValue) String() string {
+	if len(p.values) == 0 {
+		return ""
+	}
+	return p.values[len(p.values)-1]
+}
+
+func (p *proxyValue) Choices() []string {
+	return p.choices
+}
+
+// -- proxyBool Value
+// proxyBoolValue is a proxyValue for flags that may be passed without value.
+type proxyBoolValue struct {
+	*proxyValue
+}
+
+var _ BoolFlag = (*proxyBoolValue)(nil)
+
+func (p *proxyBoolValue) IsBoolFlag() bool { return true }
+
+// -- proxyOptional Value
+// proxyOptionalValue is a proxyValue for flags whose value may be omitted.
+type proxyOptionalValue struct {
+	*proxyValue
+}
+
+var _ OptionalValue = (*proxyOptionalValue)(nil)
+
+func (p *proxyOptionalValue) IsOptional() bool { return true }
+
+// -- proxySlice Value
+// proxySliceValue is a proxyValue for flags that may be repeated, keeping
+// every value passed.
+type proxySliceValue struct {
+	*proxyValue
+}
+
+var _ SliceValue = (*proxySliceValue)(nil)
+
+func (p *proxySliceValue) Get() interface{} {
+	return p.GetSlice()
+}
+
+func (p *proxySliceValue) String() string {
+	return "[" + strings.Join(p.values, ",") + "]"
+}
+
+func (p *proxySliceValue) Append(val string) error {
+	if err := p.validate(val); err != nil {
+		return err
+	}
+	p.values = append(p.values, val)
+	return nil
+}
+
+func (p *proxySliceValue) Replace(val []string) error {
+	for _, v := range val {
+		if err := p.validate(v); err != nil {
+			return err
+		}
+	}
+	p.values = append([]string(nil), val...)
+	return nil
+}
+
+func (p *proxySliceValue) GetSlice() []string {
+	return append([]string{}, p.values...)
+}
+
+// -- proxyOptionalSlice Value
+// proxyOptionalSliceValue is a proxySliceValue for flags whose value may be
+// omitted, such as count flags.
+type proxyOptionalSliceValue struct {
+	*proxySliceValue
+}
+
+var _ OptionalValue = (*proxyOptionalSliceValue)(nil)
+
+func (p *proxyOptionalSliceValue) IsOptional() bool { return true }
@@ -0,0 +1,41 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ParseReader parses flags from r, which contains one name=value pair per
+// line, e.g. to let scripts pass many values on stdin instead of a long
+// command line:
+//
+//	# comments and empty lines are ignored
+//	name=value
+//	tags=a
+//	tags=b
+//	verbose
+//
+// Boolean flags can be given without a value. Each line is parsed as if it was
+// passed as --name=value to Parse, so the values are validated and errors are
+// handled the same way, and the source of the flags is SourceArgs.
+func (fs *FlagSet) ParseReader(r io.Reader) error {
+	var args []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		args = append(args, "--"+line)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read flags: %w", err)
+	}
+
+	return fs.Parse(args)
+}
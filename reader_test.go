@@ -0,0 +1,84 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) { return 0, errors.New("read failed") }
+
+func TestParseReader(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	name := f.String("name", "", "usage")
+	tags := f.StringSlice("tags", nil, "usage")
+	verbose := f.Bool("verbose", false, "usage")
+	port := f.Int("port", 0, "usage")
+
+	input := `
+# the name of the service
+name=with spaces = and equals
+  tags=a
+tags=b
+
+verbose
+`
+	assertNoErr(t, f.ParseReader(strings.NewReader(input)))
+	assertEqual(t, "with spaces = and equals", *name)
+	assertDeepEqual(t, []string{"a", "b"}, *tags)
+	assertEqual(t, true, *verbose)
+	assertEqual(t, 0, *port)
+	assertEqual(t, zflag.SourceArgs, f.Lookup("name").Source)
+}
+
+func TestParseReaderErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		input       string
+		expectedErr string
+	}{
+		{
+			name:        "unknown flag",
+			input:       "unknown=value\n",
+			expectedErr: "unknown flag: --unknown",
+		},
+		{
+			name:        "missing value",
+			input:       "port\n",
+			expectedErr: "flag needs an argument: --port",
+		},
+		{
+			name:        "invalid value",
+			input:       "port=http\n",
+			expectedErr: `invalid argument "http" for "--port" flag: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.Int("port", 0, "usage")
+			assertErrMsg(t, test.expectedErr, f.ParseReader(strings.NewReader(test.input)))
+		})
+	}
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	assertErrMsg(t, "failed to read flags: read failed", f.ParseReader(errReader{}))
+}
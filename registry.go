@@ -0,0 +1,265 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ParseFunc converts the textual representation of a flag value into a value
+// of a registered type.
+type ParseFunc func(string) (interface{}, error)
+
+// FormatFunc converts a value of a registered type into its textual
+// representation.
+type FormatFunc func(interface{}) string
+
+type registeredType struct {
+	name   string
+	parse  ParseFunc
+	format FormatFunc
+}
+
+var (
+	typeRegistryMu sync.RWMutex
+	typeRegistry   = make(map[reflect.Type]*registeredType)
+)
+
+func newRegisteredType(sample interface{}, typeName string, parse ParseFunc, format FormatFunc) (reflect.Type, *registeredType) {
+	t := reflect.TypeOf(sample)
+	if t == nil {
+		panic("zflag: cannot register a type for a nil sample")
+	}
+	if parse == nil {
+		panic(fmt.Sprintf("zflag: parse function for type %s must be set", t))
+	}
+	if typeName == "" {
+		typeName = t.String()
+	}
+	if format == nil {
+		format = func(v interface{}) string { return fmt.Sprint(v) }
+	}
+
+	return t, &registeredType{name: typeName, parse: parse, format: format}
+}
+
+// RegisterType registers parse and format functions for the type of sample,
+// so that flags for the type can be constructed by ValueFor, without the
+// type implementing Value itself. This is meant to be called by libraries
+// from an init function. typeName is shown in usage and returned by Type,
+// and defaults to the Go type name. If format is nil, fmt.Sprint is used.
+func RegisterType(sample interface{}, typeName string, parse ParseFunc, format FormatFunc) {
+	t, rt := newRegisteredType(sample, typeName, parse, format)
+
+	typeRegistryMu.Lock()
+	defer typeRegistryMu.Unlock()
+	typeRegistry[t] = rt
+}
+
+// RegisterType is like the global RegisterType, but the type is only
+// available to this FlagSet. Types registered on a FlagSet take precedence
+// over globally registered types.
+func (fs *FlagSet) RegisterType(sample interface{}, typeName string, parse ParseFunc, format FormatFunc) {
+	t, rt := newRegisteredType(sample, typeName, parse, format)
+
+	if fs.typeRegistry == nil {
+		fs.typeRegistry = make(map[reflect.Type]*registeredType)
+	}
+	fs.typeRegistry[t] = rt
+}
+
+func (fs *FlagSet) lookupType(t reflect.Type) *registeredType {
+	if rt, ok := fs.typeRegistry[t]; ok {
+		return rt
+	}
+
+	typeRegistryMu.RLock()
+	defer typeRegistryMu.RUnlock()
+	return typeRegistry[t]
+}
+
+// ValueFor returns a Value storing its value in the variable p points to.
+// Types registered with RegisterType are looked up first, followed by the
+// types natively supported by zflag. The current value of the variable is
+// kept as is.
+func (fs *FlagSet) ValueFor(p interface{}) (Value, error) {
+	ptr := reflect.ValueOf(p)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() {
+		return nil, fmt.Errorf("expected a non-nil pointer, got %T", p)
+	}
+
+	if rt := fs.lookupType(ptr.Type().Elem()); rt != nil {
+		return &registeredValueWrapper{ptr: ptr.Elem(), registeredType: rt}, nil
+	}
+
+	if v := builtinValueFor(p); v != nil {
+		return v, nil
+	}
+
+	return nil, fmt.Errorf("unsupported flag type %s, use RegisterType to register it", ptr.Type().Elem())
+}
+
+//nolint:funlen,gocyclo
+func builtinValueFor(p interface{}) Value {
+	switch p := p.(type) {
+	case *bool:
+		return newBoolValue(*p, p)
+	case *[]bool:
+		return newBoolSliceValue(*p, p)
+	case *complex64:
+		return newComplex64Value(*p, p)
+	case *[]complex64:
+		return newComplex64SliceValue(*p, p)
+	case *complex128:
+		return newComplex128Value(*p, p)
+	case *[]complex128:
+		return newComplex128SliceValue(*p, p)
+	case *time.Duration:
+		return newDurationValue(*p, p)
+	case *[]time.Duration:
+		return newDurationSliceValue(*p, p)
+	case *float32:
+		return newFloat32Value(*p, p)
+	case *[]float32:
+		return newFloat32SliceValue(*p, p)
+	case *float64:
+		return newFloat64Value(*p, p)
+	case *[]float64:
+		return newFloat64SliceValue(*p, p)
+	case *int:
+		return newIntValue(*p, p)
+	case *[]int:
+		return newIntSliceValue(*p, p)
+	case *int8:
+		return newInt8Value(*p, p)
+	case *[]int8:
+		return newInt8SliceValue(*p, p)
+	case *int16:
+		return newInt16Value(*p, p)
+	case *[]int16:
+		return newInt16SliceValue(*p, p)
+	case *int32:
+		return newInt32Value(*p, p)
+	case *[]int32:
+		return newInt32SliceValue(*p, p)
+	case *int64:
+		return newInt64Value(*p, p)
+	case *[]int64:
+		return newInt64SliceValue(*p, p)
+	case *net.IP:
+		return newIPValue(*p, p)
+	case *[]net.IP:
+		return newIPSliceValue(*p, p)
+	case *net.IPMask:
+		return newIPMaskValue(*p, p)
+	case *[]net.IPMask:
+		return newIPMaskSliceValue(*p, p)
+	case *net.HardwareAddr:
+		return newMACValue(*p, p)
+	case *[]net.HardwareAddr:
+		return newMACSliceValue(*p, p)
+	case *net.IPNet:
+		return newIPNetValue(*p, p)
+	case *[]net.IPNet:
+		return newIPNetSliceValue(*p, p)
+	case *string:
+		return newStringValue(*p, p)
+	case *[]string:
+		return newStringSliceValue(*p, p)
+	case *map[string]int:
+		return newStringToIntValue(*p, p)
+	case *map[string]int64:
+		return newStringToInt64Value(*p, p)
+	case *map[string]float64:
+		return newStringToFloat64Value(*p, p)
+	case *map[string]string:
+		return newStringToStringValue(*p, p)
+	case *HostAndPort:
+		return newHostPortValue(*p, p)
+	case *[]Toggle:
+		return newToggleSliceValue(*p, p)
+	case *[]KeyValue:
+		return newKeyValueSliceValue(*p, p)
+	case *SemanticVersion:
+		return newSemVerValue(*p, p)
+	case *ScheduleSpec:
+		return newScheduleValue(*p, p)
+	case *big.Int:
+		return newBigIntValue(p, p)
+	case *big.Float:
+		return newBigFloatValue(p, p)
+	case *time.Time:
+		return newTimeValue(*p, p, []string{time.RFC3339Nano})
+	case **url.URL:
+		return newURLValue(*p, p)
+	case *[]*url.URL:
+		return newURLSliceValue(*p, p)
+	case *uint:
+		return newUintValue(*p, p)
+	case *[]uint:
+		return newUintSliceValue(*p, p)
+	case *uint8:
+		return newUint8Value(*p, p)
+	case *[]uint8:
+		return newUint8SliceValue(*p, p)
+	case *uint16:
+		return newUint16Value(*p, p)
+	case *[]uint16:
+		return newUint16SliceValue(*p, p)
+	case *uint32:
+		return newUint32Value(*p, p)
+	case *[]uint32:
+		return newUint32SliceValue(*p, p)
+	case *uint64:
+		return newUint64Value(*p, p)
+	case *[]uint64:
+		return newUint64SliceValue(*p, p)
+	}
+
+	return nil
+}
+
+// registeredValueWrapper implements Value for types registered with
+// RegisterType, storing the parsed value through reflection.
+type registeredValueWrapper struct {
+	ptr            reflect.Value
+	registeredType *registeredType
+}
+
+var _ Value = (*registeredValueWrapper)(nil)
+var _ Getter = (*registeredValueWrapper)(nil)
+var _ Typed = (*registeredValueWrapper)(nil)
+
+func (v *registeredValueWrapper) Set(s string) error {
+	parsed, err := v.registeredType.parse(s)
+	if err != nil {
+		return err
+	}
+
+	pv := reflect.ValueOf(parsed)
+	if !pv.IsValid() || !pv.Type().AssignableTo(v.ptr.Type()) {
+		return fmt.Errorf("parse function returned %T instead of %s", parsed, v.ptr.Type())
+	}
+
+	v.ptr.Set(pv)
+	return nil
+}
+
+func (v *registeredValueWrapper) Get() interface{} {
+	return v.ptr.Interface()
+}
+
+func (v *registeredValueWrapper) Type() string {
+	return v.registeredType.name
+}
+
+func (v *registeredValueWrapper) String() string {
+	return v.registeredType.format(v.ptr.Interface())
+}
@@ -0,0 +1,117 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type point struct{ X, Y int }
+
+type color string
+
+func init() {
+	zflag.RegisterType(point{}, "point", func(s string) (interface{}, error) {
+		var p point
+		if _, err := fmt.Sscanf(s, "%d,%d", &p.X, &p.Y); err != nil {
+			return nil, fmt.Errorf("invalid point %q", s)
+		}
+		return p, nil
+	}, func(v interface{}) string {
+		p := v.(point)
+		return fmt.Sprintf("%d,%d", p.X, p.Y)
+	})
+}
+
+func TestRegisterType(t *testing.T) {
+	t.Parallel()
+
+	p := point{1, 2}
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	v, err := f.ValueFor(&p)
+	assertNoErr(t, err)
+	f.Var(v, "point", "usage")
+
+	assertEqual(t, "1,2", f.Lookup("point").DefValue)
+	assertEqual(t, "      --point point   usage (default 1,2)\n", f.FlagUsages())
+
+	assertNoErr(t, f.Parse([]string{"--point=3,4"}))
+	assertEqual(t, point{3, 4}, p)
+
+	got, err := f.Get("point")
+	assertNoErr(t, err)
+	assertEqual(t, point{3, 4}, got)
+
+	err = f.Parse([]string{"--point=x"})
+	assertErrMsg(t, `invalid argument "x" for "--point" flag: invalid point "x"`, err)
+}
+
+func TestRegisterTypeOnFlagSet(t *testing.T) {
+	t.Parallel()
+
+	var c color
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+
+	_, err := f.ValueFor(&c)
+	assertErrMsg(t, "unsupported flag type zflag_test.color, use RegisterType to register it", err)
+
+	f.RegisterType(color(""), "", func(s string) (interface{}, error) {
+		return color(strings.ToLower(s)), nil
+	}, nil)
+	v, err := f.ValueFor(&c)
+	assertNoErr(t, err)
+	f.Var(v, "color", "usage")
+	assertEqual(t, "zflag_test.color", v.(zflag.Typed).Type())
+
+	assertNoErr(t, f.Parse([]string{"--color=RED"}))
+	assertEqual(t, color("red"), c)
+
+	other := zflag.NewFlagSet("other", zflag.ContinueOnError)
+	_, err = other.ValueFor(&c)
+	assertErr(t, err)
+}
+
+func TestRegisterTypeWrongReturnType(t *testing.T) {
+	t.Parallel()
+
+	var c color
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.RegisterType(color(""), "color", func(s string) (interface{}, error) {
+		return s, nil
+	}, nil)
+	v, err := f.ValueFor(&c)
+	assertNoErr(t, err)
+
+	assertErrMsg(t, "parse function returned string instead of zflag_test.color", v.Set("red"))
+}
+
+func TestValueForBuiltin(t *testing.T) {
+	t.Parallel()
+
+	i := 5
+	var ss []string
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	iv, err := f.ValueFor(&i)
+	assertNoErr(t, err)
+	f.Var(iv, "int", "usage")
+	ssv, err := f.ValueFor(&ss)
+	assertNoErr(t, err)
+	f.Var(ssv, "strings", "usage")
+
+	assertEqual(t, "5", f.Lookup("int").DefValue)
+	assertNoErr(t, f.Parse([]string{"--int=7", "--strings=a", "--strings=b"}))
+	assertEqual(t, 7, f.MustGetInt("int"))
+	assertDeepEqual(t, []string{"a", "b"}, ss)
+
+	_, err = f.ValueFor(i)
+	assertErrMsg(t, "expected a non-nil pointer, got int", err)
+}
@@ -0,0 +1,86 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+)
+
+// ReloadEnv reads the environment variables of all dynamic flags again, and
+// applies their values. Flags whose value was set from any other source than
+// the environment, e.g. the command line, are left untouched, as are flags
+// whose environment variable is no longer set. Slice and map flags are
+// replaced by the value of their environment variable, not appended to, so
+// reloading them is idempotent. The flags whose value changed are returned,
+// even if an error occurred for a later flag.
+func (fs *FlagSet) ReloadEnv() ([]*Flag, error) {
+	var changed []*Flag
+	var err error
+	fs.VisitAll(func(flag *Flag) {
+		if err != nil || !flag.Dynamic || flag.EnvVar == "" {
+			return
+		}
+		if flag.Source != "" && flag.Source != SourceEnv {
+			return
+		}
+
+		value, ok := os.LookupEnv(flag.EnvVar)
+		if !ok {
+			return
+		}
+
+		old := flag.Value.String()
+		restore := clearValue(flag)
+		if setErr := fs.Set(flag.Name, value); setErr != nil {
+			restore()
+			err = fmt.Errorf("%w (from environment variable %s)", setErr, flag.EnvVar)
+			return
+		}
+		flag.Source = SourceEnv
+
+		if flag.Value.String() != old {
+			changed = append(changed, flag)
+		}
+	})
+	return changed, err
+}
+
+// ReloadOnSignal calls ReloadEnv whenever one of sigs is received, or SIGHUP
+// if no signals are given, until ctx is done. After every reload, onReload is
+// called with its result, if not nil. On platforms without SIGHUP, such as
+// js and wasip1, nothing is listened to unless sigs are given, and only ctx
+// ends the wait. ReloadOnSignal blocks, so it is usually run in its own
+// goroutine. As flags are then updated concurrently, the program is
+// responsible for reading the values of dynamic flags in a way that is safe
+// for concurrent use.
+func (fs *FlagSet) ReloadOnSignal(ctx context.Context, onReload func(changed []*Flag, err error), sigs ...os.Signal) {
+	if len(sigs) == 0 {
+		sigs = defaultReloadSignals
+	}
+
+	ch := make(chan os.Signal, 1)
+	if len(sigs) > 0 {
+		signal.Notify(ch, sigs...)
+		defer signal.Stop(ch)
+	}
+
+	fs.reloadOn(ctx, ch, onReload)
+}
+
+func (fs *FlagSet) reloadOn(ctx context.Context, ch <-chan os.Signal, onReload func(changed []*Flag, err error)) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ch:
+			changed, err := fs.ReloadEnv()
+			if onReload != nil {
+				onReload(changed, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,16 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build !js && !wasip1 && !plan9
+// +build !js,!wasip1,!plan9
+
+package zflag
+
+import (
+	"os"
+	"syscall"
+)
+
+// defaultReloadSignals are the signals ReloadOnSignal listens to when none
+// are given.
+var defaultReloadSignals = []os.Signal{syscall.SIGHUP}
@@ -0,0 +1,14 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build js || wasip1 || plan9
+// +build js wasip1 plan9
+
+package zflag
+
+import (
+	"os"
+)
+
+// defaultReloadSignals is empty, as there is no SIGHUP on these platforms.
+var defaultReloadSignals []os.Signal
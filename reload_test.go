@@ -0,0 +1,114 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func newReloadFlagSet(t *testing.T) *zflag.FlagSet {
+	t.Helper()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("level", "info", "usage", zflag.OptEnv("ZFLAG_TEST_RELOAD_LEVEL"), zflag.OptDynamic())
+	f.Int("workers", 1, "usage", zflag.OptEnv("ZFLAG_TEST_RELOAD_WORKERS"), zflag.OptDynamic())
+	f.String("static", "", "usage", zflag.OptEnv("ZFLAG_TEST_RELOAD_STATIC"))
+	return f
+}
+
+func TestReloadEnv(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_RELOAD_LEVEL", "info")
+	setEnv(t, "ZFLAG_TEST_RELOAD_WORKERS", "2")
+	setEnv(t, "ZFLAG_TEST_RELOAD_STATIC", "a")
+
+	f := newReloadFlagSet(t)
+	assertNoErr(t, f.Parse([]string{"--workers=4"}))
+
+	setEnv(t, "ZFLAG_TEST_RELOAD_LEVEL", "debug")
+	setEnv(t, "ZFLAG_TEST_RELOAD_WORKERS", "8")
+	setEnv(t, "ZFLAG_TEST_RELOAD_STATIC", "b")
+
+	changed, err := f.ReloadEnv()
+	assertNoErr(t, err)
+	assertEqual(t, 1, len(changed))
+	assertEqual(t, "level", changed[0].Name)
+	assertEqual(t, "debug", f.MustGetString("level"))
+	assertEqual(t, 4, f.MustGetInt("workers"))
+	assertEqual(t, "a", f.MustGetString("static"))
+
+	changed, err = f.ReloadEnv()
+	assertNoErr(t, err)
+	assertEqual(t, 0, len(changed))
+}
+
+func TestReloadEnvError(t *testing.T) {
+	f := newReloadFlagSet(t)
+	assertNoErr(t, f.Parse(nil))
+
+	setEnv(t, "ZFLAG_TEST_RELOAD_WORKERS", "many")
+	_, err := f.ReloadEnv()
+	assertErrMsg(t, `invalid argument "many" for "--workers" flag: strconv.ParseInt: parsing "many": invalid syntax (from environment variable ZFLAG_TEST_RELOAD_WORKERS)`, err)
+}
+
+func TestReloadEnvSlice(t *testing.T) {
+	setEnv(t, "ZFLAG_TEST_RELOAD_TAGS", "a")
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringSlice("tags", []string{"x"}, "usage", zflag.OptEnv("ZFLAG_TEST_RELOAD_TAGS"), zflag.OptDynamic())
+	f.StringToString("labels", nil, "usage", zflag.OptEnv("ZFLAG_TEST_RELOAD_LABELS"), zflag.OptDynamic())
+	assertNoErr(t, f.Parse(nil))
+	assertDeepEqual(t, []string{"a"}, f.MustGetStringSlice("tags"))
+
+	for i := 0; i < 2; i++ {
+		changed, err := f.ReloadEnv()
+		assertNoErr(t, err)
+		assertEqual(t, 0, len(changed))
+		assertDeepEqual(t, []string{"a"}, f.MustGetStringSlice("tags"))
+	}
+
+	setEnv(t, "ZFLAG_TEST_RELOAD_TAGS", "b")
+	setEnv(t, "ZFLAG_TEST_RELOAD_LABELS", "k=v")
+	for i := 0; i < 2; i++ {
+		changed, err := f.ReloadEnv()
+		assertNoErr(t, err)
+		assertEqual(t, 2-2*i, len(changed))
+		assertDeepEqual(t, []string{"b"}, f.MustGetStringSlice("tags"))
+		assertDeepEqual(t, map[string]string{"k": "v"}, f.MustGetStringToString("labels"))
+	}
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	f := newReloadFlagSet(t)
+	assertNoErr(t, f.Parse(nil))
+	setEnv(t, "ZFLAG_TEST_RELOAD_LEVEL", "warn")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan os.Signal)
+	done := make(chan struct{})
+	reloaded := make(chan []*zflag.Flag)
+	errs := make(chan error, 1)
+	go func() {
+		zflag.ReloadOn(f, ctx, ch, func(changed []*zflag.Flag, err error) {
+			errs <- err
+			reloaded <- changed
+		})
+		close(done)
+	}()
+
+	ch <- os.Interrupt
+	assertNoErr(t, <-errs)
+	changed := <-reloaded
+	assertEqual(t, 1, len(changed))
+	assertEqual(t, "warn", f.MustGetString("level"))
+
+	cancel()
+	<-done
+}
@@ -0,0 +1,80 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// Alias adds alias as an additional long name of the flag named existing,
+// like OptAlias, e.g. for frameworks adding aliases to flags they did not
+// define. It panics if existing is not defined or alias is already used.
+func (fs *FlagSet) Alias(existing, alias string) {
+	flag := fs.Lookup(existing)
+	if flag == nil {
+		panic(NewUnknownFlagError(existing))
+	}
+	if alias == "" {
+		panic(fmt.Sprintf("alias for flag %q must not be empty", flag.Name))
+	}
+
+	aliases := flag.Aliases
+	flag.Aliases = []string{alias}
+	fs.addAliases(flag)
+	flag.Aliases = append(aliases, alias)
+}
+
+// Alias adds alias as an additional long name of the command-line flag named
+// existing. See FlagSet.Alias for more information.
+func Alias(existing, alias string) {
+	CommandLine.Alias(existing, alias)
+}
+
+// Rename renames the flag named old to new, keeping old as a deprecated
+// alias, so flags can be renamed over releases without breaking existing
+// command lines. Using the old name prints a deprecation notice pointing to
+// the new name. It panics if old is not defined or new is already used.
+func (fs *FlagSet) Rename(old, new string) {
+	flag := fs.formal[fs.normalizeFlagName(old)]
+	if flag == nil {
+		panic(NewUnknownFlagError(old))
+	}
+	newName := fs.normalizeFlagName(new)
+	if fs.lookup(newName) != nil {
+		fs.panicRedefined(new)
+	}
+
+	oldName := NormalizedName(flag.Name)
+	delete(fs.formal, oldName)
+	fs.formal[newName] = flag
+	if _, set := fs.actual[oldName]; set {
+		delete(fs.actual, oldName)
+		fs.actual[newName] = flag
+	}
+	fs.sortedFormal = fs.sortedFormal[:0]
+	fs.sortedActual = fs.sortedActual[:0]
+	flag.Name = string(newName)
+	fs.deriveEnvVar(flag)
+	fs.InvalidateUsageCache()
+
+	fs.Alias(flag.Name, string(oldName))
+	if fs.deprecatedAliases == nil {
+		fs.deprecatedAliases = make(map[NormalizedName]string)
+	}
+	fs.deprecatedAliases[oldName] = fmt.Sprintf("use --%s instead", flag.Name)
+}
+
+// Rename renames the command-line flag named old to new. See FlagSet.Rename
+// for more information.
+func Rename(old, new string) {
+	CommandLine.Rename(old, new)
+}
+
+// warnDeprecatedAlias prints a deprecation notice if name is a deprecated
+// alias, see Rename.
+func (fs *FlagSet) warnDeprecatedAlias(name string) {
+	if msg, ok := fs.deprecatedAliases[fs.normalizeFlagName(name)]; ok {
+		fs.warnf("Flag --%s has been deprecated, %s\n", name, msg)
+	}
+}
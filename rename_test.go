@@ -0,0 +1,91 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestAlias(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	color := f.String("color", "", "color", zflag.OptAlias("colr"))
+	f.Alias("color", "colour")
+
+	assertDeepEqual(t, []string{"colr", "colour"}, f.Lookup("color").Aliases)
+	assertNoErr(t, f.Parse([]string{"--colour=red"}))
+	assertEqual(t, "red", *color)
+}
+
+func TestAliasPanics(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		existing string
+		alias    string
+	}{
+		{name: "unknown flag", existing: "unknown", alias: "x"},
+		{name: "alias in use", existing: "color", alias: "size"},
+		{name: "empty alias", existing: "color", alias: ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.String("color", "", "color")
+			f.Int("size", 0, "size")
+
+			defer assertPanic(t)()
+			f.Alias(test.existing, test.alias)
+		})
+	}
+}
+
+func TestRename(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.SetEnvPrefix("app")
+	timeout := f.Int("timeout", 30, "timeout in seconds")
+	f.Bool("color", true, "color output", zflag.OptAddNegative())
+	f.Rename("timeout", "timeout-seconds")
+	f.Rename("color", "colour")
+
+	flag := f.Lookup("timeout-seconds")
+	assertEqual(t, "timeout-seconds", flag.Name)
+	assertEqual(t, "APP_TIMEOUT_SECONDS", flag.EnvVar)
+	assertEqual(t, flag, f.Lookup("timeout"))
+	assertDeepEqual(t, []string{"timeout"}, flag.Aliases)
+	expected := `      --[no-]colour           color output (default true) (env: APP_COLOUR)
+      --timeout-seconds int   timeout in seconds (default 30) (env: APP_TIMEOUT_SECONDS)
+`
+	assertEqualf(t, expected, f.FlagUsages(), "expected:\n%s\ngot:\n%s", expected, f.FlagUsages())
+
+	assertNoErr(t, f.Parse([]string{"--timeout=10", "--no-color"}))
+	assertEqual(t, 10, *timeout)
+	assertEqual(t, false, f.MustGetBool("colour"))
+	assertEqual(t, true, f.Changed("timeout-seconds"))
+	assertEqual(t, "Flag --timeout has been deprecated, use --timeout-seconds instead\nFlag --color has been deprecated, use --colour instead\n", buf.String())
+
+	buf.Reset()
+	assertNoErr(t, f.Parse([]string{"--timeout-seconds=20"}))
+	assertEqual(t, 20, *timeout)
+	assertEqual(t, "", buf.String())
+
+	defer assertPanic(t)()
+	f.Rename("timeout-seconds", "colour")
+}
@@ -0,0 +1,29 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// ArgRewriter rewrites the arguments passed to Parse before they are parsed,
+// e.g. to expand aliases or translate a legacy syntax. It must not modify
+// the slice it is passed, but return a new one instead.
+type ArgRewriter func(args []string) []string
+
+// UseArgRewriter appends rewriters to the chain of argument rewriters run at
+// the start of every Parse. The rewriters are run in the order they were
+// added, each receiving the arguments returned by the previous one.
+func (fs *FlagSet) UseArgRewriter(rewriters ...ArgRewriter) {
+	fs.argRewriters = append(fs.argRewriters, rewriters...)
+}
+
+// UseArgRewriter appends rewriters to the chain of argument rewriters of the
+// command-line flags. See FlagSet.UseArgRewriter for more information.
+func UseArgRewriter(rewriters ...ArgRewriter) {
+	CommandLine.UseArgRewriter(rewriters...)
+}
+
+func (fs *FlagSet) rewriteArgs(args []string) []string {
+	for _, rewriter := range fs.argRewriters {
+		args = rewriter(args)
+	}
+	return args
+}
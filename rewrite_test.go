@@ -0,0 +1,104 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func expandAlias(alias string, expansion ...string) zflag.ArgRewriter {
+	return func(args []string) []string {
+		out := make([]string, 0, len(args))
+		for _, arg := range args {
+			if arg == alias {
+				out = append(out, expansion...)
+				continue
+			}
+			out = append(out, arg)
+		}
+		return out
+	}
+}
+
+func translateLegacy(args []string) []string {
+	out := make([]string, 0, len(args))
+	for _, arg := range args {
+		// -long-flag style arguments of the standard library
+		if strings.HasPrefix(arg, "-") && !strings.HasPrefix(arg, "--") && len(arg) > 2 {
+			arg = "-" + arg
+		}
+		out = append(out, arg)
+	}
+	return out
+}
+
+func TestUseArgRewriter(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		rewriters []zflag.ArgRewriter
+		args      []string
+		verbose   bool
+		level     int
+		remaining []string
+	}{
+		{
+			name:      "no rewriters",
+			args:      []string{"--verbose", "arg"},
+			verbose:   true,
+			remaining: []string{"arg"},
+		},
+		{
+			name:      "alias",
+			rewriters: []zflag.ArgRewriter{expandAlias("--debug", "--verbose", "--level=3")},
+			args:      []string{"--debug", "arg"},
+			verbose:   true,
+			level:     3,
+			remaining: []string{"arg"},
+		},
+		{
+			name:      "legacy syntax",
+			rewriters: []zflag.ArgRewriter{translateLegacy},
+			args:      []string{"-verbose", "-level", "2"},
+			verbose:   true,
+			level:     2,
+			remaining: []string{},
+		},
+		{
+			name: "chained in order",
+			rewriters: []zflag.ArgRewriter{
+				expandAlias("-debug", "-verbose", "-level=3"),
+				translateLegacy,
+			},
+			args:      []string{"-debug"},
+			verbose:   true,
+			level:     3,
+			remaining: []string{},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			verbose := f.Bool("verbose", false, "verbose")
+			level := f.Int("level", 0, "level")
+			f.UseArgRewriter(test.rewriters...)
+
+			err := f.Parse(test.args)
+			assertNoErr(t, err)
+			assertEqual(t, test.verbose, *verbose)
+			assertEqual(t, test.level, *level)
+			assertDeepEqual(t, test.remaining, f.Args())
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// CheckRoundTrip asserts that the flags of a FlagSet survive a round trip
+// through their textual representation. It is meant to be used in tests, to
+// validate custom Value implementations.
+//
+// newFS must return a freshly defined FlagSet every time it is called, which
+// should use ContinueOnError. The first FlagSet is parsed with args, converted
+// with ToArgs and parsed into a second FlagSet, which must end up in an
+// identical state. In addition, for every flag of a third FlagSet, Set(String())
+// must be idempotent (or Replace(GetSlice()) for slice flags).
+func CheckRoundTrip(newFS func() *FlagSet, args ...string) error {
+	orig := newFS()
+	if err := orig.Parse(args); err != nil {
+		return fmt.Errorf("unable to parse original arguments: %w", err)
+	}
+
+	toArgs := orig.ToArgs()
+	copied := newFS()
+	if err := copied.Parse(toArgs); err != nil {
+		return fmt.Errorf("unable to parse arguments %q returned by ToArgs: %w", toArgs, err)
+	}
+
+	var err error
+	orig.VisitAll(func(flag *Flag) {
+		if err != nil {
+			return
+		}
+
+		if _, isFunc := flag.Value.(*funcValue); isFunc {
+			return
+		}
+
+		copiedFlag := copied.Lookup(flag.Name)
+		if copiedFlag == nil {
+			err = fmt.Errorf("flag %q is missing from the new FlagSet", flag.Name)
+			return
+		}
+		if flag.Changed != copiedFlag.Changed {
+			err = fmt.Errorf("flag %q changed state %t does not match %t after ToArgs", flag.Name, copiedFlag.Changed, flag.Changed)
+			return
+		}
+		if !equalValues(flag.Value, copiedFlag.Value) {
+			err = fmt.Errorf("flag %q value %q does not match %q after ToArgs", flag.Name, copiedFlag.Value, flag.Value)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	idempotent := newFS()
+	orig.VisitAll(func(flag *Flag) {
+		if err != nil {
+			return
+		}
+
+		if fErr := checkIdempotentSet(flag, idempotent.Lookup(flag.Name)); fErr != nil {
+			err = fmt.Errorf("flag %q is not idempotent: %w", flag.Name, fErr)
+		}
+	})
+
+	return err
+}
+
+func equalValues(a, b Value) bool {
+	ga, okA := a.(Getter)
+	gb, okB := b.(Getter)
+	if okA && okB {
+		return reflect.DeepEqual(ga.Get(), gb.Get())
+	}
+	return a.String() == b.String()
+}
+
+func checkIdempotentSet(orig, flag *Flag) error {
+	if flag == nil {
+		return fmt.Errorf("flag is missing from the new FlagSet")
+	}
+
+	if _, isFunc := flag.Value.(*funcValue); isFunc {
+		return nil
+	}
+
+	if sv, ok := flag.Value.(SliceValue); ok {
+		items := orig.Value.(SliceValue).GetSlice()
+		for i := 0; i < 2; i++ {
+			if err := sv.Replace(items); err != nil {
+				return err
+			}
+			if got := sv.GetSlice(); !reflect.DeepEqual(items, got) {
+				return fmt.Errorf("Replace(%q) resulted in %q", items, got)
+			}
+		}
+		return nil
+	}
+
+	if getter, ok := flag.Value.(Getter); ok && reflect.ValueOf(getter.Get()).Kind() == reflect.Map {
+		// map values have no single textual form to set, they are covered
+		// by the ToArgs check
+		return nil
+	}
+
+	s := orig.Value.String()
+	for i := 0; i < 2; i++ {
+		if err := flag.Value.Set(s); err != nil {
+			return err
+		}
+		if got := flag.Value.String(); got != s {
+			return fmt.Errorf("Set(%q) resulted in %q", s, got)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func newRoundTripFlagSet() *zflag.FlagSet {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.Bool("bool", false, "usage", zflag.OptShorthand('b'))
+	f.BoolSlice("bools", nil, "usage")
+	f.BytesHex("hex", nil, "usage")
+	f.BytesBase64("b64", nil, "usage")
+	f.Complex128("c128", 0, "usage")
+	f.Complex128Slice("c128s", nil, "usage")
+	f.Count("count", "usage", zflag.OptShorthand('v'))
+	f.Duration("duration", 0, "usage")
+	f.DurationSlice("durations", nil, "usage")
+	f.Float32("f32", 0, "usage")
+	f.Float64Slice("f64s", nil, "usage")
+	f.Int("int", 0, "usage", zflag.OptShorthand('i'), zflag.OptShorthandOnly())
+	f.Int8Slice("i8s", nil, "usage")
+	f.Uint64("u64", 0, "usage")
+	f.IP("ip", nil, "usage")
+	f.IPSlice("ips", nil, "usage")
+	f.IPMask("mask", nil, "usage")
+	f.IPNet("net", net.IPNet{}, "usage")
+	f.IPNetSlice("nets", nil, "usage")
+	f.String("string", "", "usage")
+	f.StringSlice("strings", nil, "usage")
+	f.StringToString("s2s", nil, "usage")
+	f.StringToInt("s2i", nil, "usage")
+	f.StringToInt64("s2i64", nil, "usage")
+	f.Time("time", time.Time{}, []string{time.RFC3339Nano}, "usage")
+	f.Func("func", "usage", func(string) error { return nil })
+	return f
+}
+
+func TestToArgs(t *testing.T) {
+	t.Parallel()
+
+	f := newRoundTripFlagSet()
+	err := f.Parse([]string{"-b", "--strings=a", "--strings=b c", "-i=5", "--s2i=b=2", "--s2i=a=1", "-vv"})
+	assertNoErr(t, err)
+
+	expected := []string{"--bool=true", "--count=2", "-i=5", "--s2i=a=1", "--s2i=b=2", "--strings=a", "--strings=b c"}
+	assertDeepEqual(t, expected, f.ToArgs())
+}
+
+func TestCheckRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	args := []string{
+		"-b", "--bools=true", "--bools=false", "--hex=0aff", "--b64=aGVsbG8=", "--c128=1+2i",
+		"--c128s=3+4i", "-vvv", "--duration=1m30s", "--durations=1s", "--f32=1.5", "--f64s=2.25",
+		"-i", "7", "--i8s=-3", "--u64=18446744073709551615", "--ip=10.0.0.1", "--ips=::1",
+		"--mask=255.255.0.0", "--net=10.0.0.0/8", "--nets=192.168.0.0/16", "--string=with spaces",
+		"--strings=a", "--strings=b", "--s2s=k=v=w", "--s2i=a=1", "--s2i64=b=2",
+		"--time=2022-01-02T03:04:05.000000006Z", "--func=x",
+	}
+
+	assertNoErr(t, zflag.CheckRoundTrip(newRoundTripFlagSet, args...))
+}
+
+func TestToArgsEmptySlice(t *testing.T) {
+	t.Parallel()
+
+	f := newRoundTripFlagSet()
+	assertNoErr(t, f.Parse([]string{"--strings=", "--ips=::1"}))
+	assertNoErr(t, f.Lookup("ips").Value.(zflag.SliceValue).Replace(nil))
+	assertDeepEqual(t, []string{"--ips=", "--strings="}, f.ToArgs())
+
+	copied := newRoundTripFlagSet()
+	assertNoErr(t, copied.Parse(f.ToArgs()))
+	assertEqual(t, true, copied.Changed("ips"))
+	assertDeepEqual(t, []net.IP{}, copied.MustGetIPSlice("ips"))
+	assertDeepEqual(t, []string{}, copied.MustGetStringSlice("strings"))
+
+	newFS := func() *zflag.FlagSet {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.StringSlice("strings", []string{"x"}, "usage")
+		f.Int8Slice("i8s", []int8{1}, "usage")
+		f.BoolSlice("bools", nil, "usage")
+		return f
+	}
+	assertNoErr(t, zflag.CheckRoundTrip(newFS, "--strings=a", "--strings=", "--i8s=", "--bools="))
+}
+
+type lossyValue string
+
+func (v *lossyValue) String() string { return strings.ToUpper(string(*v)) }
+func (v *lossyValue) Set(s string) error {
+	*v = lossyValue(s + "!")
+	return nil
+}
+
+func TestCheckRoundTripFailure(t *testing.T) {
+	t.Parallel()
+
+	newFS := func() *zflag.FlagSet {
+		var v lossyValue
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.Var(&v, "lossy", "usage")
+		return f
+	}
+
+	err := zflag.CheckRoundTrip(newFS, "--lossy=abc")
+	assertErrMsg(t, `flag "lossy" value "ABC!!" does not match "ABC!" after ToArgs`, err)
+}
+
+func TestQuoteWindowsArg(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		arg      string
+		expected string
+	}{
+		{arg: "", expected: `""`},
+		{arg: "--plain=value", expected: `--plain=value`},
+		{arg: `C:\path\to\dir\`, expected: `C:\path\to\dir\`},
+		{arg: "--string=with spaces", expected: `"--string=with spaces"`},
+		{arg: `--string=say "hi"`, expected: `"--string=say \"hi\""`},
+		{arg: `--dir=C:\with space\`, expected: `"--dir=C:\with space\\"`},
+		{arg: `--quote=a\"b c`, expected: `"--quote=a\\\"b c"`},
+		{arg: "--tab=a\tb", expected: "\"--tab=a\tb\""},
+	}
+
+	for _, test := range tests {
+		assertEqual(t, test.expected, zflag.QuoteWindowsArg(test.arg))
+	}
+}
+
+func TestJoinWindowsArgs(t *testing.T) {
+	t.Parallel()
+
+	f := newRoundTripFlagSet()
+	err := f.Parse([]string{"--string=with spaces", "-i=5"})
+	assertNoErr(t, err)
+
+	assertEqual(t, `-i=5 "--string=with spaces"`, zflag.JoinWindowsArgs(f.ToArgs()))
+}
@@ -0,0 +1,113 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// -- rune Value
+type runeValue rune
+
+var _ Value = (*runeValue)(nil)
+var _ Getter = (*runeValue)(nil)
+var _ Typed = (*runeValue)(nil)
+
+func newRuneValue(val rune, p *rune) *runeValue {
+	*p = val
+	return (*runeValue)(p)
+}
+
+// Set accepts exactly one UTF-8 character, or a single escape sequence as
+// used in Go rune literals, e.g. \t or \u00e9.
+func (r *runeValue) Set(val string) error {
+	v, err := parseRune(val)
+	if err != nil {
+		return err
+	}
+	*r = runeValue(v)
+	return nil
+}
+
+func parseRune(val string) (rune, error) {
+	switch {
+	case val == "":
+		return 0, errors.New("expected a single UTF-8 character")
+	case utf8.RuneCountInString(val) == 1:
+		r, _ := utf8.DecodeRuneInString(val)
+		if r == utf8.RuneError {
+			return 0, fmt.Errorf("%q is not a valid UTF-8 character", val)
+		}
+		return r, nil
+	case strings.HasPrefix(val, `\`):
+		r, _, tail, err := strconv.UnquoteChar(val, '\'')
+		if err == nil && tail == "" {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("cannot convert %q with more than one UTF-8 character", val)
+}
+
+func (r *runeValue) Get() interface{} {
+	return rune(*r)
+}
+
+func (r *runeValue) Type() string {
+	return "rune"
+}
+
+func (r *runeValue) String() string {
+	if *r == 0 {
+		return ""
+	}
+	return string(rune(*r))
+}
+
+// GetRune return the rune value of a flag with the given name
+func (fs *FlagSet) GetRune(name string) (rune, error) {
+	val, err := fs.getFlagValue(name, "rune")
+	if err != nil {
+		return 0, err
+	}
+	return val.(rune), nil
+}
+
+// MustGetRune is like GetRune, but panics on error.
+func (fs *FlagSet) MustGetRune(name string) rune {
+	val, err := fs.GetRune(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// RuneVar defines a rune flag with specified name, default value, and usage string.
+// The argument p points to a rune variable in which to store the value of the flag.
+func (fs *FlagSet) RuneVar(p *rune, name string, value rune, usage string, opts ...Opt) {
+	fs.Var(newRuneValue(value, p), name, usage, opts...)
+}
+
+// RuneVar defines a rune flag with specified name, default value, and usage string.
+// The argument p points to a rune variable in which to store the value of the flag.
+func RuneVar(p *rune, name string, value rune, usage string, opts ...Opt) {
+	CommandLine.RuneVar(p, name, value, usage, opts...)
+}
+
+// Rune defines a rune flag with specified name, default value, and usage string.
+// The return value is the address of a rune variable that stores the value of the flag.
+func (fs *FlagSet) Rune(name string, value rune, usage string, opts ...Opt) *rune {
+	var p rune
+	fs.RuneVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Rune defines a rune flag with specified name, default value, and usage string.
+// The return value is the address of a rune variable that stores the value of the flag.
+func Rune(name string, value rune, usage string, opts ...Opt) *rune {
+	return CommandLine.Rune(name, value, usage, opts...)
+}
@@ -0,0 +1,130 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestRune(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault rune
+		input       []string
+		expectedErr string
+		expected    rune
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: 0,
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--sep" flag: expected a single UTF-8 character`,
+		},
+		{
+			name:        "multiple characters",
+			input:       []string{"ab"},
+			expectedErr: `invalid argument "ab" for "--sep" flag: cannot convert "ab" with more than one UTF-8 character`,
+		},
+		{
+			name:        "invalid escape",
+			input:       []string{`\q`},
+			expectedErr: `invalid argument "\\q" for "--sep" flag: cannot convert "\\q" with more than one UTF-8 character`,
+		},
+		{
+			name:        "invalid UTF-8",
+			input:       []string{"\xff"},
+			expectedErr: `invalid argument "\xff" for "--sep" flag: "\xff" is not a valid UTF-8 character`,
+		},
+		{
+			name:     "single character",
+			input:    []string{","},
+			expected: ',',
+		},
+		{
+			name:     "multi-byte character",
+			input:    []string{"é"},
+			expected: 'é',
+		},
+		{
+			name:     "whitespace is kept",
+			input:    []string{" "},
+			expected: ' ',
+		},
+		{
+			name:     "backslash",
+			input:    []string{`\`},
+			expected: '\\',
+		},
+		{
+			name:     "escaped tab",
+			input:    []string{`\t`},
+			expected: '\t',
+		},
+		{
+			name:     "escaped unicode",
+			input:    []string{`\u00e9`},
+			expected: 'é',
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: ';',
+			expected:    ';',
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v rune
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.RuneVar(&v, "sep", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--sep", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+
+			got, err := f.GetRune("sep")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetRune("sep"))
+
+			getter, err := f.Get("sep")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, getter)
+		})
+	}
+}
+
+func TestRuneUsage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.Rune("sep", '\t', "field separator")
+	f.Rune("quote", 0, "quote character")
+	f.PrintDefaults()
+
+	expected := `      --quote rune   quote character
+      --sep rune     field separator (default "\t")
+`
+	assertEqual(t, expected, buf.String())
+}
@@ -0,0 +1,287 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduleSpec is a recurring schedule, either a standard cron expression with
+// the five fields minute, hour, day of month, month and day of week, one of
+// the descriptors @yearly, @annually, @monthly, @weekly, @daily, @midnight
+// and @hourly, or an interval of the form "@every <duration>", as used by
+// ScheduleVar.
+type ScheduleSpec struct {
+	// Spec is the schedule as it was parsed.
+	Spec string
+	// Every is the interval of "@every" schedules, and zero for cron schedules.
+	Every time.Duration
+
+	minute, hour, dom, month, dow uint64
+	domStar, dowStar              bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+	names    []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 7, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// ParseSchedule parses a cron expression or "@every <duration>" interval, see
+// ScheduleSpec.
+func ParseSchedule(spec string) (ScheduleSpec, error) {
+	spec = strings.TrimSpace(spec)
+	s := ScheduleSpec{Spec: spec}
+
+	if strings.HasPrefix(spec, "@every ") {
+		d, err := time.ParseDuration(strings.TrimSpace(spec[len("@every "):]))
+		if err != nil {
+			return ScheduleSpec{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+		if d <= 0 {
+			return ScheduleSpec{}, fmt.Errorf("invalid schedule %q: interval must be positive", spec)
+		}
+		s.Every = d
+		return s, nil
+	}
+
+	expr := spec
+	if strings.HasPrefix(spec, "@") {
+		var ok bool
+		if expr, ok = cronDescriptors[strings.ToLower(spec)]; !ok {
+			return ScheduleSpec{}, fmt.Errorf("invalid schedule %q: unknown descriptor", spec)
+		}
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return ScheduleSpec{}, fmt.Errorf("invalid schedule %q: expected %d fields, got %d", spec, len(cronFields), len(fields))
+	}
+
+	bits := make([]uint64, len(fields))
+	for i, field := range fields {
+		var err error
+		if bits[i], err = cronFields[i].parse(field); err != nil {
+			return ScheduleSpec{}, fmt.Errorf("invalid schedule %q: %w", spec, err)
+		}
+	}
+
+	s.minute, s.hour, s.dom, s.month, s.dow = bits[0], bits[1], bits[2], bits[3], bits[4]
+	// both 0 and 7 are Sunday
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	s.domStar = strings.HasPrefix(fields[2], "*")
+	s.dowStar = strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// parse returns the bitmask of the values matched by a field, which is a
+// comma separated list of *, a value or a range a-b, each optionally with a
+// step /n.
+func (f cronField) parse(field string) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		rng, step := item, 1
+		if i := strings.IndexByte(item, '/'); i >= 0 {
+			var err error
+			rng = item[:i]
+			if step, err = strconv.Atoi(item[i+1:]); err != nil || step <= 0 {
+				return 0, fmt.Errorf("invalid step in %s field %q", f.name, item)
+			}
+		}
+
+		lo, hi := f.min, f.max
+		switch {
+		case rng == "*":
+		case strings.Contains(rng, "-"):
+			parts := strings.SplitN(rng, "-", 2)
+			var err error
+			if lo, err = f.value(parts[0]); err != nil {
+				return 0, err
+			}
+			if hi, err = f.value(parts[1]); err != nil {
+				return 0, err
+			}
+			if lo > hi {
+				return 0, fmt.Errorf("invalid range in %s field %q", f.name, item)
+			}
+		default:
+			var err error
+			if lo, err = f.value(rng); err != nil {
+				return 0, err
+			}
+			if step == 1 {
+				hi = lo
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			if f.min == 1 {
+				return i + 1, nil
+			}
+			return i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field, expected %d-%d", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Next returns the first time after t at which the schedule is due, or the
+// zero time if the schedule is never due, e.g. on the 30th of February.
+func (s ScheduleSpec) Next(t time.Time) time.Time {
+	if s.Every > 0 {
+		return t.Add(s.Every)
+	}
+	if s.minute == 0 {
+		return time.Time{}
+	}
+
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	// every valid schedule is due within a leap year cycle
+	limit := t.AddDate(8, 0, 0)
+	for t.Before(limit) {
+		switch {
+		case s.month&(1<<uint(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+		case s.hour&(1<<uint(t.Hour())) == 0:
+			t = t.Truncate(time.Hour).Add(time.Hour)
+		case s.minute&(1<<uint(t.Minute())) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// dayMatches follows the cron convention that if both the day of month and
+// the day of week are restricted, either of them has to match.
+func (s ScheduleSpec) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<uint(t.Day())) != 0
+	dow := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// String returns the schedule as it was parsed.
+func (s ScheduleSpec) String() string {
+	return s.Spec
+}
+
+// -- schedule Value
+type scheduleValue ScheduleSpec
+
+var _ Value = (*scheduleValue)(nil)
+var _ Getter = (*scheduleValue)(nil)
+var _ Typed = (*scheduleValue)(nil)
+
+func newScheduleValue(val ScheduleSpec, p *ScheduleSpec) *scheduleValue {
+	*p = val
+	return (*scheduleValue)(p)
+}
+
+func (s *scheduleValue) Set(val string) error {
+	v, err := ParseSchedule(val)
+	if err != nil {
+		return err
+	}
+	*s = scheduleValue(v)
+	return nil
+}
+
+func (s *scheduleValue) Get() interface{} {
+	return ScheduleSpec(*s)
+}
+
+func (s *scheduleValue) Type() string {
+	return "schedule"
+}
+
+func (s *scheduleValue) String() string { return s.Spec }
+
+// GetSchedule return the ScheduleSpec value of a flag with the given name
+func (fs *FlagSet) GetSchedule(name string) (ScheduleSpec, error) {
+	val, err := fs.getFlagValue(name, "schedule")
+	if err != nil {
+		return ScheduleSpec{}, err
+	}
+	return val.(ScheduleSpec), nil
+}
+
+// MustGetSchedule is like GetSchedule, but panics on error.
+func (fs *FlagSet) MustGetSchedule(name string) ScheduleSpec {
+	val, err := fs.GetSchedule(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// ScheduleVar defines a ScheduleSpec flag with specified name, default value, and usage string.
+// The argument p points to a ScheduleSpec variable in which to store the value of the flag.
+// The value is either a cron expression or an interval, see ScheduleSpec.
+func (fs *FlagSet) ScheduleVar(p *ScheduleSpec, name string, value ScheduleSpec, usage string, opts ...Opt) {
+	fs.Var(newScheduleValue(value, p), name, usage, opts...)
+}
+
+// ScheduleVar defines a ScheduleSpec flag with specified name, default value, and usage string.
+// The argument p points to a ScheduleSpec variable in which to store the value of the flag.
+// The value is either a cron expression or an interval, see ScheduleSpec.
+func ScheduleVar(p *ScheduleSpec, name string, value ScheduleSpec, usage string, opts ...Opt) {
+	CommandLine.ScheduleVar(p, name, value, usage, opts...)
+}
+
+// Schedule defines a ScheduleSpec flag with specified name, default value, and usage string.
+// The return value is the address of a ScheduleSpec variable that stores the value of the flag.
+// The value is either a cron expression or an interval, see ScheduleSpec.
+func (fs *FlagSet) Schedule(name string, value ScheduleSpec, usage string, opts ...Opt) *ScheduleSpec {
+	var p ScheduleSpec
+	fs.ScheduleVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// Schedule defines a ScheduleSpec flag with specified name, default value, and usage string.
+// The return value is the address of a ScheduleSpec variable that stores the value of the flag.
+// The value is either a cron expression or an interval, see ScheduleSpec.
+func Schedule(name string, value ScheduleSpec, usage string, opts ...Opt) *ScheduleSpec {
+	return CommandLine.Schedule(name, value, usage, opts...)
+}
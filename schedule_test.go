@@ -0,0 +1,138 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSchedule(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name          string
+		input         []string
+		expectedErr   string
+		expectedStr   string
+		expectedEvery time.Duration
+	}{
+		{
+			name:  "no value passed",
+			input: []string{},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--schedule" flag: invalid schedule "": expected 5 fields, got 0`,
+		},
+		{
+			name:        "cron expression",
+			input:       []string{"*/15 9-17 * * mon-fri"},
+			expectedStr: "*/15 9-17 * * mon-fri",
+		},
+		{
+			name:        "descriptor",
+			input:       []string{"@daily"},
+			expectedStr: "@daily",
+		},
+		{
+			name:          "interval",
+			input:         []string{"@every 1h30m"},
+			expectedStr:   "@every 1h30m",
+			expectedEvery: 90 * time.Minute,
+		},
+		{
+			name:        "too many fields",
+			input:       []string{"* * * * * *"},
+			expectedErr: `invalid argument "* * * * * *" for "--schedule" flag: invalid schedule "* * * * * *": expected 5 fields, got 6`,
+		},
+		{
+			name:        "out of range",
+			input:       []string{"60 * * * *"},
+			expectedErr: `invalid argument "60 * * * *" for "--schedule" flag: invalid schedule "60 * * * *": invalid value "60" in minute field, expected 0-59`,
+		},
+		{
+			name:        "invalid step",
+			input:       []string{"*/0 * * * *"},
+			expectedErr: `invalid argument "*/0 * * * *" for "--schedule" flag: invalid schedule "*/0 * * * *": invalid step in minute field "*/0"`,
+		},
+		{
+			name:        "reversed range",
+			input:       []string{"* 5-1 * * *"},
+			expectedErr: `invalid argument "* 5-1 * * *" for "--schedule" flag: invalid schedule "* 5-1 * * *": invalid range in hour field "5-1"`,
+		},
+		{
+			name:        "unknown descriptor",
+			input:       []string{"@often"},
+			expectedErr: `invalid argument "@often" for "--schedule" flag: invalid schedule "@often": unknown descriptor`,
+		},
+		{
+			name:        "invalid interval",
+			input:       []string{"@every soon"},
+			expectedErr: `invalid argument "@every soon" for "--schedule" flag: invalid schedule "@every soon": time: invalid duration "soon"`,
+		},
+		{
+			name:        "negative interval",
+			input:       []string{"@every -1m"},
+			expectedErr: `invalid argument "@every -1m" for "--schedule" flag: invalid schedule "@every -1m": interval must be positive`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var s zflag.ScheduleSpec
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.ScheduleVar(&s, "schedule", zflag.ScheduleSpec{}, "usage")
+			err := f.Parse(repeatFlag("--schedule", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expectedStr, s.String())
+			assertEqual(t, test.expectedEvery, s.Every)
+			assertEqual(t, test.expectedStr, f.Lookup("schedule").Value.String())
+
+			got, err := f.GetSchedule("schedule")
+			assertNoErr(t, err)
+			assertEqual(t, s, got)
+			assertEqual(t, s, f.MustGetSchedule("schedule"))
+		})
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2024, time.January, 31, 10, 7, 30, 0, time.UTC)
+	tests := []struct {
+		spec     string
+		expected time.Time
+	}{
+		{spec: "* * * * *", expected: time.Date(2024, time.January, 31, 10, 8, 0, 0, time.UTC)},
+		{spec: "*/15 * * * *", expected: time.Date(2024, time.January, 31, 10, 15, 0, 0, time.UTC)},
+		{spec: "@hourly", expected: time.Date(2024, time.January, 31, 11, 0, 0, 0, time.UTC)},
+		{spec: "@daily", expected: time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 9 * * sat", expected: time.Date(2024, time.February, 3, 9, 0, 0, 0, time.UTC)},
+		{spec: "0 9 * * 7", expected: time.Date(2024, time.February, 4, 9, 0, 0, 0, time.UTC)},
+		{spec: "0 0 29 feb *", expected: time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 13 * fri", expected: time.Date(2024, time.February, 2, 0, 0, 0, 0, time.UTC)},
+		{spec: "0 0 30 2 *", expected: time.Time{}},
+		{spec: "@every 90s", expected: from.Add(90 * time.Second)},
+	}
+
+	for _, test := range tests {
+		s, err := zflag.ParseSchedule(test.spec)
+		assertNoErr(t, err)
+		assertEqualf(t, test.expected, s.Next(from), "unexpected next time for %q", test.spec)
+	}
+}
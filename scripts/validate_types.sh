@@ -71,6 +71,12 @@ validate_funcs() {
       if [[ $fn_type == Ip* ]]; then
         fn_type="IP${fn_type:2}"
       fi
+      if [[ $fn_type == Url* ]]; then
+        fn_type="URL${fn_type:3}"
+      fi
+      if [[ $fn_type == Mac* ]]; then
+        fn_type="MAC${fn_type:3}"
+      fi
 
       for req_fn in "${fs_funcs[@]}"; do
         expected_fn="${req_fn//\|/$fn_type}"
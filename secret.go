@@ -0,0 +1,29 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+// secretMask replaces the value of secret flags in output.
+const secretMask = "********"
+
+// redactedError hides the message of the wrapped error, which commonly
+// includes the value, or part of it, of a secret flag. The wrapped error is
+// still available to errors.Is and errors.As.
+type redactedError struct {
+	err error
+}
+
+func redactError(err error) error {
+	if err == nil {
+		return err
+	}
+	return redactedError{err: err}
+}
+
+func (e redactedError) Error() string {
+	return "invalid value"
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
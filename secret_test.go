@@ -0,0 +1,78 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptSecret(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("password", "hunter2", "the password", zflag.OptSecret())
+	f.Int("pin", 1234, "the pin", zflag.OptSecret())
+
+	usage := f.FlagUsages()
+	assertEqualf(t, false, strings.Contains(usage, "hunter2"), "usage contains the secret default:\n%s", usage)
+	assertEqualf(t, false, strings.Contains(usage, "1234"), "usage contains the secret default:\n%s", usage)
+
+	err := f.Parse([]string{"--pin=s3cr3t"})
+	assertErr(t, err)
+	assertEqualf(t, false, strings.Contains(err.Error(), "s3cr3t"), "error contains the secret: %s", err)
+	assertErrMsg(t, `invalid argument "********" for "--pin" flag: invalid value`, err)
+
+	err = f.Parse([]string{"--password=swordfish"})
+	assertNoErr(t, err)
+
+	var buf bytes.Buffer
+	err = f.WriteConfig(&buf, zflag.ConfigFormatTOML)
+	assertNoErr(t, err)
+	expected := `# the password
+# default: ********
+password = "********"
+
+# the pin
+# pin = "********"
+`
+	assertEqualf(t, expected, buf.String(), "expected:\n%s\ngot:\n%s", expected, buf.String())
+}
+
+func TestOptSecretElements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "map value", input: "--limits=cpu=hunter2"},
+		{name: "slice element", input: "--ids=1,hunter2"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.StringToInt("limits", nil, "the limits", zflag.OptSecret())
+			f.IntSlice("ids", nil, "the ids", zflag.OptSecret())
+
+			err := f.Parse([]string{test.input})
+			assertErr(t, err)
+			assertEqualf(t, false, strings.Contains(err.Error(), "hunter2"), "error contains the secret: %s", err)
+
+			var elementErr zflag.ElementError
+			assertEqualf(t, true, errors.As(err, &elementErr), "expected an ElementError, got %T", err)
+		})
+	}
+}
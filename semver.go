@@ -0,0 +1,327 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SemanticVersion is a semantic version as defined by https://semver.org, as
+// used by SemVerVar.
+type SemanticVersion struct {
+	Major      uint64
+	Minor      uint64
+	Patch      uint64
+	Prerelease string
+	Build      string
+}
+
+// ParseSemVer parses a semantic version of the form
+// MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD], optionally prefixed with "v".
+func ParseSemVer(s string) (SemanticVersion, error) {
+	var v SemanticVersion
+	invalid := fmt.Errorf("invalid semantic version %q", s)
+
+	rest := strings.TrimPrefix(s, "v")
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		if !validSemVerIdentifiers(v.Build, false) {
+			return SemanticVersion{}, invalid
+		}
+		rest = rest[:i]
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		v.Prerelease = rest[i+1:]
+		if !validSemVerIdentifiers(v.Prerelease, true) {
+			return SemanticVersion{}, invalid
+		}
+		rest = rest[:i]
+	}
+
+	parts := strings.Split(rest, ".")
+	if len(parts) != 3 {
+		return SemanticVersion{}, invalid
+	}
+	nums := make([]uint64, 3)
+	for i, part := range parts {
+		if !isSemVerNumber(part) {
+			return SemanticVersion{}, invalid
+		}
+		n, err := strconv.ParseUint(part, 10, 64)
+		if err != nil {
+			return SemanticVersion{}, invalid
+		}
+		nums[i] = n
+	}
+	v.Major, v.Minor, v.Patch = nums[0], nums[1], nums[2]
+	return v, nil
+}
+
+// isSemVerNumber reports whether s is a numeric identifier without leading zeros.
+func isSemVerNumber(s string) bool {
+	if s == "" || (len(s) > 1 && s[0] == '0') {
+		return false
+	}
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func validSemVerIdentifiers(s string, prerelease bool) bool {
+	for _, id := range strings.Split(s, ".") {
+		if id == "" {
+			return false
+		}
+		numeric := true
+		for _, c := range id {
+			switch {
+			case c >= '0' && c <= '9':
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '-':
+				numeric = false
+			default:
+				return false
+			}
+		}
+		// numeric prerelease identifiers must not have leading zeros
+		if prerelease && numeric && !isSemVerNumber(id) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the version in the form MAJOR.MINOR.PATCH[-PRERELEASE][+BUILD],
+// or an empty string for the zero value.
+func (v SemanticVersion) String() string {
+	if v == (SemanticVersion{}) {
+		return ""
+	}
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Compare returns -1, 0 or +1 depending on whether v has a lower, equal or
+// higher precedence than other. The build metadata is ignored.
+func (v SemanticVersion) Compare(other SemanticVersion) int {
+	if c := compareUint(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareUint(v.Patch, other.Patch); c != 0 {
+		return c
+	}
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+func compareUint(a, b uint64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func comparePrerelease(a, b string) int {
+	// a version without prerelease has a higher precedence
+	switch {
+	case a == b:
+		return 0
+	case a == "":
+		return 1
+	case b == "":
+		return -1
+	}
+
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) && i < len(bs); i++ {
+		if c := comparePrereleaseIdentifier(as[i], bs[i]); c != 0 {
+			return c
+		}
+	}
+	return compareUint(uint64(len(as)), uint64(len(bs)))
+}
+
+func comparePrereleaseIdentifier(a, b string) int {
+	an, aErr := strconv.ParseUint(a, 10, 64)
+	bn, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareUint(an, bn)
+	case aErr == nil:
+		// numeric identifiers have a lower precedence
+		return -1
+	case bErr == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// semVerConstraint is a single comparison, e.g. >=1.2.0.
+type semVerConstraint struct {
+	op      string
+	version SemanticVersion
+}
+
+var semVerOps = []string{">=", "<=", "!=", ">", "<", "="}
+
+func (c semVerConstraint) check(v SemanticVersion) bool {
+	cmp := v.Compare(c.version)
+	switch c.op {
+	case ">=":
+		return cmp >= 0
+	case "<=":
+		return cmp <= 0
+	case "!=":
+		return cmp != 0
+	case ">":
+		return cmp > 0
+	case "<":
+		return cmp < 0
+	}
+	return cmp == 0
+}
+
+func parseSemVerConstraints(expr string) ([]semVerConstraint, error) {
+	fields := strings.FieldsFunc(expr, func(r rune) bool { return r == ',' || r == ' ' })
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid version constraint %q", expr)
+	}
+
+	constraints := make([]semVerConstraint, 0, len(fields))
+	for _, field := range fields {
+		c := semVerConstraint{op: "="}
+		for _, op := range semVerOps {
+			if strings.HasPrefix(field, op) {
+				c.op = op
+				field = field[len(op):]
+				break
+			}
+		}
+		v, err := ParseSemVer(field)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version constraint %q: %w", expr, err)
+		}
+		c.version = v
+		constraints = append(constraints, c)
+	}
+	return constraints, nil
+}
+
+// OptConstraint restricts semantic version flags to versions satisfying all
+// comparisons in expr, separated by commas or spaces, e.g. ">=1.2.0, <2.0.0".
+// The supported operators are =, !=, >, >=, < and <=.
+func OptConstraint(expr string) Opt {
+	return func(f *Flag) error {
+		v, ok := f.Value.(*semVerValue)
+		if !ok {
+			return fmt.Errorf("flag %s is not a semantic version flag", f.Name)
+		}
+		constraints, err := parseSemVerConstraints(expr)
+		if err != nil {
+			return err
+		}
+		v.constraints = append(v.constraints, constraints...)
+		v.constraintExprs = append(v.constraintExprs, expr)
+		return nil
+	}
+}
+
+// -- semVer Value
+type semVerValue struct {
+	value           *SemanticVersion
+	constraints     []semVerConstraint
+	constraintExprs []string
+}
+
+var _ Value = (*semVerValue)(nil)
+var _ Getter = (*semVerValue)(nil)
+var _ Typed = (*semVerValue)(nil)
+
+func newSemVerValue(val SemanticVersion, p *SemanticVersion) *semVerValue {
+	*p = val
+	return &semVerValue{value: p}
+}
+
+func (s *semVerValue) Set(val string) error {
+	v, err := ParseSemVer(strings.TrimSpace(val))
+	if err != nil {
+		return err
+	}
+	for _, c := range s.constraints {
+		if !c.check(v) {
+			return fmt.Errorf("version %s does not satisfy %s", v, strings.Join(s.constraintExprs, ", "))
+		}
+	}
+	*s.value = v
+	return nil
+}
+
+func (s *semVerValue) Get() interface{} {
+	return *s.value
+}
+
+func (s *semVerValue) Type() string {
+	return "semVer"
+}
+
+func (s *semVerValue) String() string { return s.value.String() }
+
+// GetSemVer return the SemanticVersion value of a flag with the given name
+func (fs *FlagSet) GetSemVer(name string) (SemanticVersion, error) {
+	val, err := fs.getFlagValue(name, "semVer")
+	if err != nil {
+		return SemanticVersion{}, err
+	}
+	return val.(SemanticVersion), nil
+}
+
+// MustGetSemVer is like GetSemVer, but panics on error.
+func (fs *FlagSet) MustGetSemVer(name string) SemanticVersion {
+	val, err := fs.GetSemVer(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// SemVerVar defines a SemanticVersion flag with specified name, default value, and usage string.
+// The argument p points to a SemanticVersion variable in which to store the value of the flag.
+func (fs *FlagSet) SemVerVar(p *SemanticVersion, name string, value SemanticVersion, usage string, opts ...Opt) {
+	fs.Var(newSemVerValue(value, p), name, usage, opts...)
+}
+
+// SemVerVar defines a SemanticVersion flag with specified name, default value, and usage string.
+// The argument p points to a SemanticVersion variable in which to store the value of the flag.
+func SemVerVar(p *SemanticVersion, name string, value SemanticVersion, usage string, opts ...Opt) {
+	CommandLine.SemVerVar(p, name, value, usage, opts...)
+}
+
+// SemVer defines a SemanticVersion flag with specified name, default value, and usage string.
+// The return value is the address of a SemanticVersion variable that stores the value of the flag.
+func (fs *FlagSet) SemVer(name string, value SemanticVersion, usage string, opts ...Opt) *SemanticVersion {
+	var p SemanticVersion
+	fs.SemVerVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// SemVer defines a SemanticVersion flag with specified name, default value, and usage string.
+// The return value is the address of a SemanticVersion variable that stores the value of the flag.
+func SemVer(name string, value SemanticVersion, usage string, opts ...Opt) *SemanticVersion {
+	return CommandLine.SemVer(name, value, usage, opts...)
+}
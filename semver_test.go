@@ -0,0 +1,172 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func mustParseSemVer(s string) zflag.SemanticVersion {
+	v, err := zflag.ParseSemVer(s)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+func TestSemVer(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault zflag.SemanticVersion
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    zflag.SemanticVersion
+		expectedStr string
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: zflag.SemanticVersion{},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--version" flag: invalid semantic version ""`,
+		},
+		{
+			name:        "missing patch",
+			input:       []string{"1.2"},
+			expectedErr: `invalid argument "1.2" for "--version" flag: invalid semantic version "1.2"`,
+		},
+		{
+			name:        "leading zero",
+			input:       []string{"01.2.3"},
+			expectedErr: `invalid argument "01.2.3" for "--version" flag: invalid semantic version "01.2.3"`,
+		},
+		{
+			name:        "invalid prerelease",
+			input:       []string{"1.2.3-rc..1"},
+			expectedErr: `invalid argument "1.2.3-rc..1" for "--version" flag: invalid semantic version "1.2.3-rc..1"`,
+		},
+		{
+			name:        "version",
+			input:       []string{"1.2.3"},
+			expected:    zflag.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
+			expectedStr: "1.2.3",
+		},
+		{
+			name:        "v prefix",
+			input:       []string{"v1.2.3"},
+			expected:    zflag.SemanticVersion{Major: 1, Minor: 2, Patch: 3},
+			expectedStr: "1.2.3",
+		},
+		{
+			name:        "prerelease and build",
+			input:       []string{"1.0.0-rc.1+build-5"},
+			expected:    zflag.SemanticVersion{Major: 1, Prerelease: "rc.1", Build: "build-5"},
+			expectedStr: "1.0.0-rc.1+build-5",
+		},
+		{
+			name:        "satisfies constraint",
+			opts:        []zflag.Opt{zflag.OptConstraint(">=1.2.0, <2.0.0")},
+			input:       []string{"1.9.0"},
+			expected:    zflag.SemanticVersion{Major: 1, Minor: 9},
+			expectedStr: "1.9.0",
+		},
+		{
+			name:        "violates constraint",
+			opts:        []zflag.Opt{zflag.OptConstraint(">=1.2.0, <2.0.0")},
+			input:       []string{"2.0.0"},
+			expectedErr: `invalid argument "2.0.0" for "--version" flag: version 2.0.0 does not satisfy >=1.2.0, <2.0.0`,
+		},
+		{
+			name:        "prerelease is lower than release",
+			opts:        []zflag.Opt{zflag.OptConstraint(">=1.2.0")},
+			input:       []string{"1.2.0-rc.1"},
+			expectedErr: `invalid argument "1.2.0-rc.1" for "--version" flag: version 1.2.0-rc.1 does not satisfy >=1.2.0`,
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: mustParseSemVer("0.1.0"),
+			expected:    zflag.SemanticVersion{Minor: 1},
+			expectedStr: "0.1.0",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var v zflag.SemanticVersion
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.SemVerVar(&v, "version", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--version", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, v)
+			assertEqual(t, test.expectedStr, f.Lookup("version").Value.String())
+
+			got, err := f.GetSemVer("version")
+			assertNoErr(t, err)
+			assertEqual(t, test.expected, got)
+			assertEqual(t, test.expected, f.MustGetSemVer("version"))
+		})
+	}
+}
+
+func TestSemanticVersionCompare(t *testing.T) {
+	t.Parallel()
+
+	// in order of increasing precedence, as listed by the specification
+	ordered := []string{
+		"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2",
+		"1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.1.0", "2.0.0",
+	}
+	for i := range ordered {
+		for j := range ordered {
+			expected := 0
+			switch {
+			case i < j:
+				expected = -1
+			case i > j:
+				expected = 1
+			}
+			assertEqualf(t, expected, mustParseSemVer(ordered[i]).Compare(mustParseSemVer(ordered[j])), "comparing %s to %s", ordered[i], ordered[j])
+		}
+	}
+
+	assertEqual(t, 0, mustParseSemVer("1.0.0+a").Compare(mustParseSemVer("1.0.0+b")))
+}
+
+func TestOptConstraintInvalid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid expression", func(t *testing.T) {
+		t.Parallel()
+
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		defer assertPanic(t)()
+		f.SemVer("version", zflag.SemanticVersion{}, "usage", zflag.OptConstraint(">=1.x"))
+	})
+
+	t.Run("invalid flag", func(t *testing.T) {
+		t.Parallel()
+
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		defer assertPanic(t)()
+		f.String("version", "", "usage", zflag.OptConstraint(">=1.0.0"))
+	})
+}
@@ -0,0 +1,79 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"unicode"
+)
+
+// AutoShorthands assigns a shorthand to every flag without one, using the
+// first letter of its name that is not used as a shorthand yet, in either
+// case. Flags are handled in the order they were defined, so the result is
+// deterministic. Hidden and deprecated flags are skipped, as are the
+// shorthands of the built-in help and version flags, negative shorthands,
+// the letters of atomic shorthands and those in exclude. Flags for which no
+// letter is available are left without a shorthand.
+func (fs *FlagSet) AutoShorthands(exclude ...rune) {
+	reserved := make(map[rune]bool, len(exclude)+len(fs.negShorthands)+2)
+	for _, r := range exclude {
+		reserved[r] = true
+	}
+	for r := range fs.negShorthands {
+		reserved[r] = true
+	}
+	for shorthand := range fs.atomicShorthands {
+		// clusters of these letters would be taken for the atomic shorthand
+		for _, r := range shorthand {
+			reserved[r] = true
+		}
+	}
+	if !fs.DisableBuiltinHelp {
+		reserved['h'] = true
+	}
+	if fs.versionFunc != nil {
+		reserved['V'] = true
+	}
+
+	assigned := false
+	for _, flag := range fs.orderedFormal {
+		if flag.Shorthand != 0 || flag.Hidden || flag.Deprecated != "" {
+			continue
+		}
+		if r, ok := fs.freeShorthand(flag.Name, reserved); ok {
+			if fs.shorthands == nil {
+				fs.shorthands = make(map[rune]*Flag)
+			}
+			flag.Shorthand = r
+			fs.shorthands[r] = flag
+			assigned = true
+		}
+	}
+	if assigned {
+		fs.InvalidateUsageCache()
+	}
+}
+
+// AutoShorthands assigns shorthands to the command-line flags without one.
+// See FlagSet.AutoShorthands for more information.
+func AutoShorthands(exclude ...rune) {
+	CommandLine.AutoShorthands(exclude...)
+}
+
+func (fs *FlagSet) freeShorthand(name string, reserved map[rune]bool) (rune, bool) {
+	for _, r := range name {
+		if !unicode.IsLetter(r) {
+			continue
+		}
+		other := unicode.ToUpper(r)
+		if unicode.IsUpper(r) {
+			other = unicode.ToLower(r)
+		}
+		for _, candidate := range []rune{r, other} {
+			if _, used := fs.shorthands[candidate]; !used && !reserved[candidate] {
+				return candidate, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,108 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestAutoShorthands(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		define   func(f *zflag.FlagSet)
+		exclude  []rune
+		expected map[string]string
+	}{
+		{
+			name: "first free letter",
+			define: func(f *zflag.FlagSet) {
+				f.String("name", "", "usage")
+				f.String("number", "", "usage")
+				f.String("nice", "", "usage")
+			},
+			expected: map[string]string{"name": "n", "number": "N", "nice": "i"},
+		},
+		{
+			name: "existing shorthands are kept",
+			define: func(f *zflag.FlagSet) {
+				f.String("name", "", "usage")
+				f.String("output", "", "usage", zflag.OptShorthand('n'))
+			},
+			expected: map[string]string{"name": "N", "output": "n"},
+		},
+		{
+			name: "help, hidden, deprecated and excluded are skipped",
+			define: func(f *zflag.FlagSet) {
+				f.String("host", "", "usage")
+				f.String("secret", "", "usage", zflag.OptHidden())
+				f.String("old", "", "usage", zflag.OptDeprecated("use new"))
+				f.String("port", "", "usage")
+			},
+			exclude:  []rune{'p', 'P'},
+			expected: map[string]string{"host": "H", "secret": "", "old": "", "port": "o"},
+		},
+		{
+			name: "negative shorthands are reserved",
+			define: func(f *zflag.FlagSet) {
+				f.Bool("color", false, "usage", zflag.OptAddNegative(), zflag.OptNegativeShorthand('c'))
+				f.String("config", "", "usage")
+			},
+			expected: map[string]string{"color": "C", "config": "o"},
+		},
+		{
+			name: "letters of atomic shorthands are reserved",
+			define: func(f *zflag.FlagSet) {
+				f.Bool("recursive-force", false, "usage", zflag.OptAtomicShorthand("rf"))
+				f.Bool("force", false, "usage")
+			},
+			expected: map[string]string{"recursive-force": "R", "force": "F"},
+		},
+		{
+			name: "no letter available",
+			define: func(f *zflag.FlagSet) {
+				f.String("a", "", "usage")
+				f.String("aa", "", "usage")
+				f.String("aaa", "", "usage")
+			},
+			expected: map[string]string{"a": "a", "aa": "A", "aaa": ""},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			test.define(f)
+			f.AutoShorthands(test.exclude...)
+
+			got := make(map[string]string, len(test.expected))
+			for name := range test.expected {
+				got[name] = ""
+				if r := f.Lookup(name).Shorthand; r != 0 {
+					got[name] = string(r)
+				}
+			}
+			assertDeepEqual(t, test.expected, got)
+		})
+	}
+}
+
+func TestAutoShorthandsInvalidatesUsageCache(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.New("test", zflag.WithUsageCache())
+	f.String("name", "", "usage")
+	assertEqual(t, "      --name string   usage\n", f.FlagUsages())
+
+	f.AutoShorthands()
+	assertEqual(t, "  -n, --name string   usage\n", f.FlagUsages())
+}
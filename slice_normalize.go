@@ -0,0 +1,196 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ErrDuplicateElement is the error wrapped when a value adds an element that
+// is already present to a flag defined with OptUnique(UniqueReject).
+var ErrDuplicateElement = errors.New("duplicate element")
+
+// UniqueMode configures how OptUnique handles duplicate elements.
+type UniqueMode int
+
+const (
+	// UniqueDrop silently drops duplicate elements, keeping the first.
+	UniqueDrop UniqueMode = iota
+	// UniqueReject rejects values adding an element that is already present.
+	UniqueReject
+)
+
+// OptUnique removes duplicate elements from slice flags each time a value is
+// set, or rejects the value with ErrDuplicateElement, depending on mode.
+// Elements are compared by their textual representation. A rejected value
+// leaves the elements of the flag as they were before.
+func OptUnique(mode UniqueMode) Opt {
+	return func(f *Flag) error {
+		n, ok := normalizerFor(f)
+		if !ok {
+			return fmt.Errorf("value of type %T cannot have unique elements", f.Value)
+		}
+		n.unique, n.mode = true, mode
+		return nil
+	}
+}
+
+// OptSorted sorts the elements of slice flags each time a value is set.
+// Numbers are sorted numerically, other elements by their textual
+// representation.
+func OptSorted() Opt {
+	return func(f *Flag) error {
+		n, ok := normalizerFor(f)
+		if !ok {
+			return fmt.Errorf("value of type %T cannot be sorted", f.Value)
+		}
+		n.sorted = true
+		return nil
+	}
+}
+
+// sliceNormalizer keeps the elements of a slice flag unique and sorted, see
+// OptUnique and OptSorted. Duplicates are always handled before sorting,
+// regardless of the order of the options.
+type sliceNormalizer struct {
+	sv     SliceValue
+	value  Value
+	unique bool
+	mode   UniqueMode
+	sorted bool
+
+	before   []string // before are the elements before the value was set.
+	appended bool     // appended is set if Set appends to the elements in before, rather than replacing them.
+}
+
+// normalizerFor returns the normalizer of the flag, adding one if the flag
+// has none yet. It reports false if the flag is not a slice flag.
+func normalizerFor(f *Flag) (*sliceNormalizer, bool) {
+	if f.normalizer != nil {
+		return f.normalizer, true
+	}
+	sv, ok := f.Value.(SliceValue)
+	if !ok {
+		return nil, false
+	}
+
+	f.normalizer = &sliceNormalizer{sv: sv, value: f.Value}
+	prependSetHook(f, f.normalizer.normalize)
+	return f.normalizer, true
+}
+
+// prependSetHook adds a hook normalizing the value of the flag, which runs
+// before the hooks binding the value, so they observe the normalized value.
+func prependSetHook(f *Flag, hook func() error) {
+	f.setHooks = append([]func() error{hook}, f.setHooks...)
+}
+
+// snapshot records the elements before a value is set, changed reporting
+// whether the flag was set before, as Set replaces the default otherwise.
+func (n *sliceNormalizer) snapshot(changed bool) {
+	n.before = append([]string(nil), n.sv.GetSlice()...)
+	n.appended = changed
+}
+
+func (n *sliceNormalizer) normalize() error {
+	if n.unique {
+		if err := n.uniqueElements(); err != nil {
+			_ = n.sv.Replace(n.before)
+			return err
+		}
+	}
+	if n.sorted {
+		return sortElements(n.sv, n.value)
+	}
+	return nil
+}
+
+// uniqueElements removes the duplicate elements, or rejects the value if it
+// added one in UniqueReject mode. The position reported is that of the
+// duplicate within the value.
+func (n *sliceNormalizer) uniqueElements() error {
+	elems := n.sv.GetSlice()
+	offset := 0
+	if n.appended && hasPrefix(elems, n.before) {
+		offset = len(n.before)
+	}
+
+	unique := make([]string, 0, len(elems))
+	seen := make(map[string]bool, len(elems))
+	for i, elem := range elems {
+		if !seen[elem] {
+			seen[elem] = true
+			unique = append(unique, elem)
+			continue
+		}
+		if n.mode == UniqueReject && i >= offset {
+			return NewElementError(i-offset+1, ErrDuplicateElement)
+		}
+	}
+
+	if len(unique) == len(elems) {
+		return nil
+	}
+	return n.sv.Replace(unique)
+}
+
+// hasPrefix reports whether elems starts with prefix.
+func hasPrefix(elems, prefix []string) bool {
+	if len(elems) < len(prefix) {
+		return false
+	}
+	for i := range prefix {
+		if elems[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortElements(sv SliceValue, value Value) error {
+	elems := sv.GetSlice()
+	less := func(i, j int) bool { return elems[i] < elems[j] }
+	if getter, ok := value.(Getter); ok {
+		v := reflect.ValueOf(getter.Get())
+		if v.Kind() == reflect.Slice && v.Len() == len(elems) {
+			if numericLess := numericElementLess(v); numericLess != nil {
+				less = numericLess
+			}
+		}
+	}
+
+	order := make([]int, len(elems))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool { return less(order[a], order[b]) })
+
+	sorted := make([]string, len(elems))
+	changed := false
+	for i, j := range order {
+		sorted[i] = elems[j]
+		changed = changed || i != j
+	}
+	if !changed {
+		return nil
+	}
+	return sv.Replace(sorted)
+}
+
+// numericElementLess compares the elements of the slice v numerically, or
+// returns nil if the elements are not numbers.
+func numericElementLess(v reflect.Value) func(i, j int) bool {
+	switch v.Type().Elem().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(i, j int) bool { return v.Index(i).Int() < v.Index(j).Int() }
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(i, j int) bool { return v.Index(i).Uint() < v.Index(j).Uint() }
+	case reflect.Float32, reflect.Float64:
+		return func(i, j int) bool { return v.Index(i).Float() < v.Index(j).Float() }
+	}
+	return nil
+}
@@ -0,0 +1,171 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestOptUniqueAndSorted(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    []int
+	}{
+		{
+			name:     "drop duplicates",
+			opts:     []zflag.Opt{zflag.OptUnique(zflag.UniqueDrop)},
+			input:    []string{"3", "1", "3", "2", "1"},
+			expected: []int{3, 1, 2},
+		},
+		{
+			name:        "reject duplicates",
+			opts:        []zflag.Opt{zflag.OptUnique(zflag.UniqueReject)},
+			input:       []string{"3", "1", "3"},
+			expectedErr: `invalid argument "3" for "--ids" flag: element 1: duplicate element`,
+		},
+		{
+			name:     "sorted numerically",
+			opts:     []zflag.Opt{zflag.OptSorted()},
+			input:    []string{"10", "9", "-1", "9"},
+			expected: []int{-1, 9, 9, 10},
+		},
+		{
+			name:     "sorted and unique",
+			opts:     []zflag.Opt{zflag.OptSorted(), zflag.OptUnique(zflag.UniqueDrop)},
+			input:    []string{"10", "9", "10", "2"},
+			expected: []int{2, 9, 10},
+		},
+		{
+			name:     "no options",
+			input:    []string{"3", "1", "3"},
+			expected: []int{3, 1, 3},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			ids := f.IntSlice("ids", nil, "usage", test.opts...)
+
+			err := f.Parse(repeatFlag("--ids", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				if !errors.Is(err, zflag.ErrDuplicateElement) {
+					t.Fatalf("expected an ErrDuplicateElement error, got %v", err)
+				}
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, *ids)
+		})
+	}
+}
+
+func TestOptUniqueAndSortedTypes(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	names := f.StringSlice("name", nil, "usage", zflag.OptSorted(), zflag.OptUnique(zflag.UniqueDrop))
+	timeouts := f.DurationSlice("timeout", nil, "usage", zflag.OptSorted())
+
+	var bound []string
+	assertNoErr(t, f.BindSetter("name", func(v interface{}) error {
+		bound = v.([]string)
+		return nil
+	}))
+
+	assertNoErr(t, f.Parse([]string{"--name=b", "--name=a", "--name=b", "--timeout=1m", "--timeout=5s"}))
+	assertDeepEqual(t, []string{"a", "b"}, *names)
+	assertDeepEqual(t, []string{"a", "b"}, bound)
+	assertDeepEqual(t, []time.Duration{5 * time.Second, time.Minute}, *timeouts)
+}
+
+func TestOptUniqueRejectCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectedErr string
+		expected    []string
+	}{
+		{
+			name:     "default replaced",
+			args:     []string{"--tags=x,y"},
+			expected: []string{"x", "y"},
+		},
+		{
+			name:        "duplicate of earlier value",
+			args:        []string{"--tags=a", "--tags=b,a"},
+			expectedErr: `invalid argument "b,a" for "--tags" flag: element 2: duplicate element`,
+			expected:    []string{"a"},
+		},
+		{
+			name:        "duplicate within value",
+			args:        []string{"--tags=a,b", "--tags=c,d,c"},
+			expectedErr: `invalid argument "c,d,c" for "--tags" flag: element 3: duplicate element`,
+			expected:    []string{"a", "b"},
+		},
+		{
+			name:        "duplicate replacing default",
+			args:        []string{"--tags=a,a"},
+			expectedErr: `invalid argument "a,a" for "--tags" flag: element 2: duplicate element`,
+			expected:    []string{"x"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			tags := f.StringSlice("tags", []string{"x"}, "usage", zflag.OptCSV(), zflag.OptUnique(zflag.UniqueReject), zflag.OptSorted())
+
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+			} else {
+				assertNoErr(t, err)
+			}
+			assertDeepEqual(t, test.expected, *tags)
+		})
+	}
+}
+
+func TestOptUniqueInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+
+	defer assertPanic(t)()
+	f.Int("id", 0, "usage", zflag.OptUnique(zflag.UniqueDrop))
+}
+
+func TestOptSortedInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+
+	defer assertPanic(t)()
+	f.Int("id", 0, "usage", zflag.OptSorted())
+}
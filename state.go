@@ -0,0 +1,267 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// flagSetState is a snapshot of a FlagSet, taken by PushState.
+type flagSetState struct {
+	flags         []flagState
+	actual        map[NormalizedName]*Flag
+	orderedActual []*Flag
+	args          []string
+	argsLenAtDash int
+	parsed        bool
+}
+
+// flagState is a snapshot of a single flag.
+type flagState struct {
+	flag    *Flag
+	changed bool
+	source  string
+	str     string
+	// value is a copy of what the Value points to, it restores the basic
+	// types as well as the internal state of the slice and map types.
+	value reflect.Value
+	// data is a copy of the slice, map or pointer the Value refers to.
+	data interface{}
+}
+
+// PushState saves the values, Changed and Source of all flags, as well as the
+// arguments of the FlagSet, so they can be restored with PopState. This allows
+// tests that parse the same FlagSet, e.g. CommandLine, to isolate their cases:
+//
+//	fs.PushState()
+//	defer fs.PopState()
+//
+// States are kept on a stack, so calls can be nested.
+func (fs *FlagSet) PushState() {
+	state := &flagSetState{
+		actual:        make(map[NormalizedName]*Flag, len(fs.actual)),
+		orderedActual: append([]*Flag(nil), fs.orderedActual...),
+		args:          append([]string(nil), fs.args...),
+		argsLenAtDash: fs.argsLenAtDash,
+		parsed:        fs.parsed,
+	}
+	for name, flag := range fs.actual {
+		state.actual[name] = flag
+	}
+
+	for _, flag := range fs.orderedFormal {
+		state.flags = append(state.flags, saveFlagState(flag))
+	}
+
+	fs.states = append(fs.states, state)
+}
+
+// PopState restores the state saved by the last call to PushState. It panics
+// if there is no saved state.
+func (fs *FlagSet) PopState() {
+	if len(fs.states) == 0 {
+		panic("zflag: PopState called without a matching PushState")
+	}
+
+	state := fs.states[len(fs.states)-1]
+	fs.states = fs.states[:len(fs.states)-1]
+
+	for _, flagState := range state.flags {
+		flagState.restore()
+	}
+
+	fs.actual = state.actual
+	fs.orderedActual = state.orderedActual
+	fs.sortedActual = nil
+	fs.args = state.args
+	fs.argsLenAtDash = state.argsLenAtDash
+	fs.parsed = state.parsed
+}
+
+// PushState saves the state of the command-line flags.
+// See FlagSet.PushState for more information.
+func PushState() {
+	CommandLine.PushState()
+}
+
+// PopState restores the state of the command-line flags saved by PushState.
+// See FlagSet.PopState for more information.
+func PopState() {
+	CommandLine.PopState()
+}
+
+// WithValues sets the flags to values for the duration of fn, restoring the
+// previous state afterwards, e.g. to retry an operation with safe settings.
+// Slice flags are replaced by the single value instead of appended to. The
+// state is restored and the error returned if setting a value fails.
+func (fs *FlagSet) WithValues(values map[string]string, fn func() error) error {
+	fs.PushState()
+	defer fs.PopState()
+
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if flag := fs.Lookup(name); flag != nil && flag.Changed {
+			if sv, ok := flag.Value.(SliceValue); ok {
+				_ = sv.Replace(nil)
+			}
+		}
+		if err := fs.Set(name, values[name]); err != nil {
+			return err
+		}
+	}
+
+	return fn()
+}
+
+// WithValues sets the command-line flags to values for the duration of fn.
+// See FlagSet.WithValues for more information.
+func WithValues(values map[string]string, fn func() error) error {
+	return CommandLine.WithValues(values, fn)
+}
+
+// clearValue empties the value of a slice or map flag, so that setting it
+// afterwards replaces its elements instead of adding to them. The returned
+// function puts the previous elements back, e.g. when setting fails.
+func clearValue(flag *Flag) (restore func()) {
+	switch v := flag.Value.(type) {
+	case SliceValue:
+		old := append([]string(nil), v.GetSlice()...)
+		_ = v.Replace(nil)
+		return func() { _ = v.Replace(old) }
+	case MapValue:
+		old := v.GetMap()
+		_ = v.ReplaceMap(nil)
+		return func() { _ = v.ReplaceMap(old) }
+	}
+	return func() {}
+}
+
+// undoSet restores the flag saved before a Set whose hooks failed, including
+// whether it was set at all.
+func (fs *FlagSet) undoSet(saved flagState) {
+	saved.restore()
+	if saved.changed {
+		return
+	}
+
+	delete(fs.actual, NormalizedName(saved.flag.Name))
+	for i, flag := range fs.orderedActual {
+		if flag == saved.flag {
+			fs.orderedActual = append(fs.orderedActual[:i], fs.orderedActual[i+1:]...)
+			break
+		}
+	}
+	fs.sortedActual = nil
+}
+
+func saveFlagState(flag *Flag) flagState {
+	state := flagState{
+		flag:    flag,
+		changed: flag.Changed,
+		source:  flag.Source,
+		str:     flag.Value.String(),
+	}
+
+	if rv := reflect.ValueOf(flag.Value); rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		state.value = reflect.New(rv.Elem().Type()).Elem()
+		state.value.Set(rv.Elem())
+	}
+
+	switch v := flag.Value.(type) {
+	case SliceValue:
+		state.data = append([]string(nil), v.GetSlice()...)
+	case Getter:
+		// a copy of maps, as they are modified in place
+		state.data = copyMap(v.Get())
+	}
+
+	return state
+}
+
+func (s flagState) restore() {
+	restored := true
+	switch v := s.flag.Value.(type) {
+	case SliceValue:
+		_ = v.Replace(s.data.([]string))
+	case *TimeValue:
+		*v.Time = s.data.(time.Time)
+	case *urlValue:
+		*v.value = s.data.(*url.URL)
+	default:
+		restored = restoreMap(v, s.data)
+	}
+
+	if s.value.IsValid() {
+		reflect.ValueOf(s.flag.Value).Elem().Set(s.value)
+	}
+	if !restored && s.flag.Value.String() != s.str {
+		// custom Values that keep their state elsewhere
+		_ = s.flag.Value.Set(s.str)
+	}
+
+	s.flag.Changed = s.changed
+	s.flag.Source = s.source
+}
+
+// restoreMap replaces the contents of the map held by v with the contents of
+// saved. It returns false if v does not hold a map.
+func restoreMap(v Value, saved interface{}) bool {
+	getter, ok := v.(Getter)
+	if !ok {
+		return false
+	}
+	m := reflect.ValueOf(getter.Get())
+	if m.Kind() != reflect.Map {
+		return false
+	}
+
+	for _, key := range m.MapKeys() {
+		m.SetMapIndex(key, reflect.Value{})
+	}
+	savedMap := reflect.ValueOf(saved)
+	for _, key := range savedMap.MapKeys() {
+		m.SetMapIndex(key, savedMap.MapIndex(key))
+	}
+	return true
+}
+
+// copyMap returns a shallow copy of v if it is a map, or v itself otherwise.
+func copyMap(v interface{}) interface{} {
+	m := reflect.ValueOf(v)
+	if m.Kind() != reflect.Map || m.IsNil() {
+		return v
+	}
+
+	cp := reflect.MakeMapWithSize(m.Type(), m.Len())
+	for _, key := range m.MapKeys() {
+		cp.SetMapIndex(key, m.MapIndex(key))
+	}
+	return cp.Interface()
+}
+
+// SetCommandLineForTest replaces CommandLine with fs for the duration of a
+// test, restoring the original CommandLine through t.Cleanup. If fs is nil, a
+// new FlagSet using ContinueOnError is used. Tests calling it must not run in
+// parallel with other tests using CommandLine.
+func SetCommandLineForTest(t interface{ Cleanup(func()) }, fs *FlagSet) *FlagSet {
+	if fs == nil {
+		fs = NewFlagSet(os.Args[0], ContinueOnError)
+	}
+
+	orig := CommandLine
+	CommandLine = fs
+	t.Cleanup(func() {
+		CommandLine = orig
+	})
+	return fs
+}
@@ -0,0 +1,118 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// StateMigration upgrades state saved by MarshalState with an older schema
+// version. It is passed the version the state was saved with and the values
+// of the flags by name, which it modifies in place, e.g. to rename a flag.
+type StateMigration func(version int, values map[string][]string) error
+
+type marshaledState struct {
+	Version int                 `json:"version"`
+	Flags   map[string][]string `json:"flags"`
+}
+
+// SetSchemaVersion sets the version of the flag definitions, which is
+// recorded by MarshalState. Increase it whenever flags are renamed or their
+// values change meaning, and handle the older versions with SetStateMigration.
+func (fs *FlagSet) SetSchemaVersion(version int) {
+	fs.schemaVersion = version
+}
+
+// SetSchemaVersion sets the version of the command-line flag definitions. See
+// FlagSet.SetSchemaVersion for more information.
+func SetSchemaVersion(version int) {
+	CommandLine.SetSchemaVersion(version)
+}
+
+// SchemaVersion returns the version of the flag definitions, as set with
+// SetSchemaVersion.
+func (fs *FlagSet) SchemaVersion() int {
+	return fs.schemaVersion
+}
+
+// SetStateMigration sets the function called by UnmarshalState when loading
+// state saved with an older schema version.
+func (fs *FlagSet) SetStateMigration(migrate StateMigration) {
+	fs.stateMigration = migrate
+}
+
+// SetStateMigration sets the function called when loading state of the
+// command-line flags saved with an older schema version. See
+// FlagSet.SetStateMigration for more information.
+func SetStateMigration(migrate StateMigration) {
+	CommandLine.SetStateMigration(migrate)
+}
+
+// MarshalState encodes the values of all changed flags as JSON, along with
+// the schema version, so they can be persisted and loaded again with
+// UnmarshalState, e.g. to save profiles or replay invocations.
+func (fs *FlagSet) MarshalState() ([]byte, error) {
+	state := marshaledState{
+		Version: fs.schemaVersion,
+		Flags:   make(map[string][]string, len(fs.actual)),
+	}
+	fs.Visit(func(flag *Flag) {
+		if values := flagValues(flag); values != nil {
+			state.Flags[flag.Name] = values
+		}
+	})
+	return json.Marshal(state)
+}
+
+// UnmarshalState sets the flags from state encoded by MarshalState. State
+// saved with an older schema version is first passed to the migration set with
+// SetStateMigration, state saved with a newer version returns an error.
+func (fs *FlagSet) UnmarshalState(data []byte) error {
+	var state marshaledState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return fmt.Errorf("failed to decode state: %w", err)
+	}
+
+	if state.Version > fs.schemaVersion {
+		return fmt.Errorf("state has schema version %d, which is newer than %d", state.Version, fs.schemaVersion)
+	}
+	if state.Version < fs.schemaVersion && fs.stateMigration != nil {
+		if state.Flags == nil {
+			state.Flags = map[string][]string{}
+		}
+		if err := fs.stateMigration(state.Version, state.Flags); err != nil {
+			return fmt.Errorf("failed to migrate state from schema version %d: %w", state.Version, err)
+		}
+	}
+
+	names := make([]string, 0, len(state.Flags))
+	for name := range state.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		values := state.Flags[name]
+		if flag := fs.Lookup(name); flag != nil && flag.Changed {
+			if sv, ok := flag.Value.(SliceValue); ok {
+				if len(values) == 1 && values[0] == "" {
+					// an empty value sets the slice to no elements
+					values = []string{}
+				}
+				if err := sv.Replace(values); err != nil {
+					return NewInvalidArgumentError(err, flag, fmt.Sprint(values))
+				}
+				continue
+			}
+		}
+		for _, value := range values {
+			if err := fs.Set(name, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func newStateFlagSet(version int) *zflag.FlagSet {
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetSchemaVersion(version)
+	f.String("name", "", "usage")
+	f.Int("port", 0, "usage")
+	f.StringSlice("tags", nil, "usage")
+	f.StringToString("labels", nil, "usage")
+	return f
+}
+
+func TestMarshalState(t *testing.T) {
+	t.Parallel()
+
+	f := newStateFlagSet(2)
+	assertEqual(t, 2, f.SchemaVersion())
+	err := f.Parse([]string{"--name", "with spaces", "--tags", "a", "--tags", "b", "--labels", "k=v"})
+	assertNoErr(t, err)
+
+	data, err := f.MarshalState()
+	assertNoErr(t, err)
+	assertEqual(t, `{"version":2,"flags":{"labels":["k=v"],"name":["with spaces"],"tags":["a","b"]}}`, string(data))
+
+	loaded := newStateFlagSet(2)
+	assertNoErr(t, loaded.UnmarshalState(data))
+	assertEqual(t, "with spaces", loaded.MustGetString("name"))
+	assertDeepEqual(t, []string{"a", "b"}, loaded.MustGetStringSlice("tags"))
+	assertDeepEqual(t, map[string]string{"k": "v"}, loaded.MustGetStringToString("labels"))
+	assertEqual(t, false, loaded.Changed("port"))
+	assertEqual(t, zflag.SourceSet, loaded.Lookup("name").Source)
+}
+
+func TestUnmarshalStateReplacesSlices(t *testing.T) {
+	t.Parallel()
+
+	f := newStateFlagSet(0)
+	assertNoErr(t, f.Parse([]string{"--tags", "x"}))
+	assertNoErr(t, f.UnmarshalState([]byte(`{"version":0,"flags":{"tags":["a","b"]}}`)))
+	assertDeepEqual(t, []string{"a", "b"}, f.MustGetStringSlice("tags"))
+}
+
+func TestUnmarshalStateMigration(t *testing.T) {
+	t.Parallel()
+
+	f := newStateFlagSet(2)
+	var migratedFrom int
+	f.SetStateMigration(func(version int, values map[string][]string) error {
+		migratedFrom = version
+		values["name"] = values["old-name"]
+		delete(values, "old-name")
+		return nil
+	})
+
+	assertNoErr(t, f.UnmarshalState([]byte(`{"version":1,"flags":{"old-name":["renamed"],"port":["8080"]}}`)))
+	assertEqual(t, 1, migratedFrom)
+	assertEqual(t, "renamed", f.MustGetString("name"))
+	assertEqual(t, 8080, f.MustGetInt("port"))
+}
+
+func TestUnmarshalStateErrors(t *testing.T) {
+	t.Parallel()
+
+	errMigration := errors.New("migration failed")
+	tests := []struct {
+		name        string
+		data        string
+		migration   zflag.StateMigration
+		expectedErr string
+	}{
+		{
+			name:        "invalid json",
+			data:        `{`,
+			expectedErr: "failed to decode state: unexpected end of JSON input",
+		},
+		{
+			name:        "newer version",
+			data:        `{"version":2,"flags":{}}`,
+			expectedErr: "state has schema version 2, which is newer than 1",
+		},
+		{
+			name: "failed migration",
+			data: `{"version":0,"flags":{}}`,
+			migration: func(int, map[string][]string) error {
+				return errMigration
+			},
+			expectedErr: "failed to migrate state from schema version 0: migration failed",
+		},
+		{
+			name:        "unknown flag",
+			data:        `{"version":1,"flags":{"old-name":["value"]}}`,
+			expectedErr: "unknown flag: --old-name",
+		},
+		{
+			name:        "invalid value",
+			data:        `{"version":1,"flags":{"port":["http"]}}`,
+			expectedErr: `invalid argument "http" for "--port" flag: strconv.ParseInt: parsing "http": invalid syntax`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := newStateFlagSet(1)
+			f.SetStateMigration(test.migration)
+			assertErrMsg(t, test.expectedErr, f.UnmarshalState([]byte(test.data)))
+		})
+	}
+}
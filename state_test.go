@@ -0,0 +1,171 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestPushPopState(t *testing.T) {
+	t.Parallel()
+
+	var (
+		s   string
+		n   int
+		ss  []string
+		m   map[string]string
+		tm  time.Time
+		u   *url.URL
+		cnt int
+	)
+	def := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&s, "string", "default", "usage")
+	f.IntVar(&n, "int", 1, "usage")
+	f.StringSliceVar(&ss, "slice", []string{"a"}, "usage")
+	f.StringToStringVar(&m, "map", map[string]string{"k": "v"}, "usage")
+	f.TimeVar(&tm, "time", def, []string{time.RFC3339}, "usage")
+	f.URLVar(&u, "url", nil, "usage")
+	f.CountVar(&cnt, "count", "usage", zflag.OptShorthand('c'))
+
+	assertNoErr(t, f.Parse([]string{"--string=first", "arg"}))
+
+	f.PushState()
+	assertNoErr(t, f.Parse([]string{
+		"--string=second", "--int=2", "--slice=b", "--slice=c", "--map=x=y",
+		"--time=2021-02-03T04:05:06Z", "--url=https://example.com", "other", "-ccc",
+	}))
+	assertEqual(t, "second", s)
+	assertDeepEqual(t, []string{"b", "c"}, ss)
+	assertEqual(t, 3, cnt)
+
+	f.PopState()
+	assertEqual(t, "first", s)
+	assertEqual(t, 1, n)
+	assertDeepEqual(t, []string{"a"}, ss)
+	assertDeepEqual(t, map[string]string{"k": "v"}, m)
+	assertEqual(t, def, tm)
+	assertEqual(t, (*url.URL)(nil), u)
+	assertEqual(t, 0, cnt)
+	assertDeepEqual(t, []string{"arg"}, f.Args())
+	assertEqual(t, 1, f.NFlag())
+	assertEqual(t, true, f.Changed("string"))
+	assertEqual(t, false, f.Changed("int"))
+	assertEqual(t, zflag.SourceArgs, f.Lookup("string").Source)
+	assertEqual(t, "", f.Lookup("int").Source)
+
+	// the slice and map flags must replace their values again once set
+	assertNoErr(t, f.Parse([]string{"--slice=d", "--map=a=b"}))
+	assertDeepEqual(t, []string{"d"}, ss)
+	assertDeepEqual(t, map[string]string{"a": "b"}, m)
+}
+
+func TestPushPopStateNested(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	s := f.String("string", "default", "usage")
+
+	f.PushState()
+	assertNoErr(t, f.Parse([]string{"--string=outer"}))
+	f.PushState()
+	assertNoErr(t, f.Parse([]string{"--string=inner"}))
+	assertEqual(t, "inner", *s)
+
+	f.PopState()
+	assertEqual(t, "outer", *s)
+	f.PopState()
+	assertEqual(t, "default", *s)
+	assertEqual(t, false, f.Parsed())
+}
+
+func TestPopStateWithoutPush(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.PopState()
+}
+
+func TestSetCommandLineForTest(t *testing.T) {
+	orig := zflag.CommandLine
+
+	t.Run("replaced", func(t *testing.T) {
+		fs := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		assertEqual(t, fs, zflag.SetCommandLineForTest(t, fs))
+		assertEqual(t, fs, zflag.CommandLine)
+
+		s := zflag.String("string", "default", "usage")
+		assertNoErr(t, zflag.CommandLine.Parse([]string{"--string=value"}))
+		assertEqual(t, "value", *s)
+	})
+	assertEqual(t, orig, zflag.CommandLine)
+	assertEqual(t, (*zflag.Flag)(nil), orig.Lookup("string"))
+
+	t.Run("nil", func(t *testing.T) {
+		fs := zflag.SetCommandLineForTest(t, nil)
+		assertEqual(t, fs, zflag.CommandLine)
+		assertEqual(t, true, fs != orig)
+	})
+	assertEqual(t, orig, zflag.CommandLine)
+}
+
+func TestWithValues(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	retries := f.Int("retries", 3, "usage")
+	hosts := f.StringSlice("hosts", nil, "usage")
+	safe := f.Bool("safe", false, "usage")
+	assertNoErr(t, f.Parse([]string{"--hosts", "a", "--hosts", "b"}))
+
+	called := false
+	err := f.WithValues(map[string]string{"retries": "0", "hosts": "c", "safe": "true"}, func() error {
+		called = true
+		assertEqual(t, 0, *retries)
+		assertDeepEqual(t, []string{"c"}, *hosts)
+		assertEqual(t, true, *safe)
+		assertEqual(t, true, f.Changed("safe"))
+		return nil
+	})
+	assertNoErr(t, err)
+	assertEqual(t, true, called)
+
+	assertEqual(t, 3, *retries)
+	assertDeepEqual(t, []string{"a", "b"}, *hosts)
+	assertEqual(t, false, *safe)
+	assertEqual(t, false, f.Changed("safe"))
+}
+
+func TestWithValuesErrors(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	retries := f.Int("retries", 3, "usage")
+
+	fn := func() error {
+		t.Fatal("fn must not be called")
+		return nil
+	}
+	err := f.WithValues(map[string]string{"retries": "many"}, fn)
+	assertErrMsg(t, `invalid argument "many" for "--retries" flag: strconv.ParseInt: parsing "many": invalid syntax`, err)
+	assertEqual(t, 3, *retries)
+
+	errFailed := errors.New("failed")
+	err = f.WithValues(map[string]string{"retries": "1"}, func() error { return errFailed })
+	assertEqual(t, errFailed, err)
+	assertEqual(t, 3, *retries)
+}
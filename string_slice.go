@@ -4,13 +4,19 @@
 package zflag
 
 import (
+	"bytes"
+	"encoding/csv"
+	"errors"
 	"fmt"
+	"io"
+	"strings"
 )
 
 // -- stringSlice Value
 type stringSliceValue struct {
 	value   *[]string
 	changed bool
+	csv     bool
 }
 
 var _ Value = (*stringSliceValue)(nil)
@@ -26,15 +32,76 @@ func newStringSliceValue(val []string, p *[]string) *stringSliceValue {
 }
 
 func (s *stringSliceValue) Set(val string) error {
+	elems := []string{val}
+	if s.csv {
+		var err error
+		if elems, err = readCSVRecord(val); err != nil {
+			return err
+		}
+	}
+
 	if !s.changed {
 		*s.value = []string{}
 	}
-	*s.value = append(*s.value, val)
+	*s.value = append(*s.value, elems...)
 	s.changed = true
 
 	return nil
 }
 
+// Delimiter returns the delimiter of the elements of a single value, which
+// is only set if the flag parses CSV, see OptCSV.
+func (s *stringSliceValue) Delimiter() string {
+	if s.csv {
+		return ","
+	}
+	return ""
+}
+
+// readCSVRecord splits val into the fields of a single RFC 4180 record.
+func readCSVRecord(val string) ([]string, error) {
+	r := csv.NewReader(strings.NewReader(val))
+	record, err := r.Read()
+	switch {
+	case errors.Is(err, io.EOF):
+		return []string{""}, nil
+	case err != nil:
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if _, err := r.Read(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid CSV: expected a single record")
+	}
+	return record, nil
+}
+
+// writeCSVField quotes val as a field of an RFC 4180 record, if needed.
+func writeCSVField(val string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{val})
+	w.Flush()
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// OptCSV makes string slice flags split each value into elements following
+// RFC 4180, so quoted elements may contain commas, e.g. 'a,"b,c",d' yields
+// three elements. By default, each value is a single element. String to
+// string map flags split each value into key=value pairs the same way, e.g.
+// 'a=1,"b=2,3"' sets the keys a and b.
+func OptCSV() Opt {
+	return func(f *Flag) error {
+		switch v := f.Value.(type) {
+		case *stringSliceValue:
+			v.csv = true
+			return nil
+		case *stringToStringValue:
+			v.csv = true
+			return nil
+		}
+		return fmt.Errorf("value of type %T cannot parse CSV", f.Value)
+	}
+}
+
 func (s *stringSliceValue) Get() interface{} {
 	return *s.value
 }
@@ -4,6 +4,8 @@
 package zflag_test
 
 import (
+	"encoding/csv"
+	"errors"
 	"io/ioutil"
 	"reflect"
 	"strings"
@@ -32,7 +34,7 @@ func TestStringSlice(t *testing.T) {
 			name:           "empty value passed",
 			input:          []string{""},
 			flagDefault:    []string{},
-			expectedValues: []string{""},
+			expectedValues: []string{},
 		},
 		{
 			name:           "single string",
@@ -136,3 +138,87 @@ func TestStringSlice(t *testing.T) {
 		})
 	}
 }
+
+func TestStringSliceCSV(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		input          []string
+		expectedErr    string
+		expectedValues []string
+	}{
+		{
+			name:           "quoted commas",
+			input:          []string{`a,"b,c",d`},
+			expectedValues: []string{"a", "b,c", "d"},
+		},
+		{
+			name:           "escaped quotes",
+			input:          []string{`"say ""hi""",x`},
+			expectedValues: []string{`say "hi"`, "x"},
+		},
+		{
+			name:           "repeated",
+			input:          []string{"a,b", "c"},
+			expectedValues: []string{"a", "b", "c"},
+		},
+		{
+			name:           "empty value",
+			input:          []string{""},
+			expectedValues: []string{},
+		},
+		{
+			name:        "multiple records",
+			input:       []string{"a\nb"},
+			expectedErr: `invalid argument "a\nb" for "--tags" flag: invalid CSV: expected a single record`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			tags := f.StringSlice("tags", nil, "usage", zflag.OptCSV())
+
+			err := f.Parse(repeatFlag("--tags", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, *tags)
+
+			roundTrip := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			roundTripTags := roundTrip.StringSlice("tags", nil, "usage", zflag.OptCSV())
+			assertNoErr(t, roundTrip.Parse(f.ToArgs()))
+			assertDeepEqual(t, *tags, *roundTripTags)
+		})
+	}
+}
+
+func TestStringSliceCSVParseError(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringSlice("tags", nil, "usage", zflag.OptCSV())
+
+	err := f.Parse([]string{`--tags=a,"b`})
+	if !errors.Is(err, csv.ErrQuote) {
+		t.Fatalf("expected a csv.ErrQuote error, got %v", err)
+	}
+}
+
+func TestOptCSVInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+
+	defer assertPanic(t)()
+	f.IntSlice("ints", nil, "usage", zflag.OptCSV())
+}
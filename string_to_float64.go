@@ -0,0 +1,139 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// -- stringToFloat64 Value
+type stringToFloat64Value struct {
+	value         *map[string]float64
+	changed       bool
+	valueOptional bool
+	uniqueKeys    bool
+}
+
+var _ Value = (*stringToFloat64Value)(nil)
+var _ Getter = (*stringToFloat64Value)(nil)
+var _ Typed = (*stringToFloat64Value)(nil)
+var _ MapValue = (*stringToFloat64Value)(nil)
+
+func newStringToFloat64Value(val map[string]float64, p *map[string]float64) *stringToFloat64Value {
+	ssv := new(stringToFloat64Value)
+	ssv.value = p
+	*ssv.value = val
+	return ssv
+}
+
+// Format: a=1,b=2
+func (s *stringToFloat64Value) Set(val string) error {
+	kv := strings.SplitN(val, "=", 2)
+	if len(kv) != 2 {
+		return fmt.Errorf("%s must be formatted as key=value", val)
+	}
+	key, val := kv[0], kv[1]
+
+	val = strings.TrimSpace(val)
+	v, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return NewKeyError(key, err)
+	}
+
+	if !s.changed {
+		*s.value = map[string]float64{}
+	} else if _, exists := (*s.value)[key]; exists && s.uniqueKeys {
+		return NewKeyError(key, errDuplicateKey)
+	}
+
+	(*s.value)[key] = v
+	s.changed = true
+
+	return nil
+}
+
+func (s *stringToFloat64Value) Get() interface{} {
+	return *s.value
+}
+
+func (s *stringToFloat64Value) Type() string {
+	return "stringToFloat64"
+}
+
+func (s *stringToFloat64Value) String() string {
+	records := make([]string, 0, len(*s.value)>>1)
+	for k, v := range *s.value {
+		records = append(records, k+"="+strconv.FormatFloat(v, 'g', -1, 64))
+	}
+
+	return fmt.Sprintf("%s", records)
+}
+
+// ReplaceMap replaces the contents of the map, parsing each of its values.
+func (s *stringToFloat64Value) ReplaceMap(val map[string]string) error {
+	out := make(map[string]float64, len(val))
+	for k, v := range val {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return NewKeyError(k, err)
+		}
+		out[k] = f
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *stringToFloat64Value) GetMap() map[string]string {
+	out := make(map[string]string, len(*s.value))
+	for k, v := range *s.value {
+		out[k] = strconv.FormatFloat(v, 'g', -1, 64)
+	}
+	return out
+}
+
+// GetStringToFloat64 return the map[string]float64 value of a flag with the given name
+func (fs *FlagSet) GetStringToFloat64(name string) (map[string]float64, error) {
+	val, err := fs.getFlagValue(name, "stringToFloat64")
+	if err != nil {
+		return map[string]float64{}, err
+	}
+	return val.(map[string]float64), nil
+}
+
+// MustGetStringToFloat64 is like GetStringToFloat64, but panics on error.
+func (fs *FlagSet) MustGetStringToFloat64(name string) map[string]float64 {
+	val, err := fs.GetStringToFloat64(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// StringToFloat64Var defines a map[string]float64 flag with specified name, default value, and usage string.
+// The argument p points to a map[string]float64 variable in which to store the values of multiple flags.
+func (fs *FlagSet) StringToFloat64Var(p *map[string]float64, name string, value map[string]float64, usage string, opts ...Opt) {
+	fs.Var(newStringToFloat64Value(value, p), name, usage, opts...)
+}
+
+// StringToFloat64Var defines a map[string]float64 flag with specified name, default value, and usage string.
+// The argument p points to a map[string]float64 variable in which to store the values of multiple flags.
+func StringToFloat64Var(p *map[string]float64, name string, value map[string]float64, usage string, opts ...Opt) {
+	CommandLine.StringToFloat64Var(p, name, value, usage, opts...)
+}
+
+// StringToFloat64 defines a map[string]float64 flag with specified name, default value, and usage string.
+// The return value is the address of a map[string]float64 variable that stores the values of multiple flags.
+func (fs *FlagSet) StringToFloat64(name string, value map[string]float64, usage string, opts ...Opt) *map[string]float64 {
+	var p map[string]float64
+	fs.StringToFloat64Var(&p, name, value, usage, opts...)
+	return &p
+}
+
+// StringToFloat64 defines a map[string]float64 flag with specified name, default value, and usage string.
+// The return value is the address of a map[string]float64 variable that stores the values of multiple flags.
+func StringToFloat64(name string, value map[string]float64, usage string, opts ...Opt) *map[string]float64 {
+	return CommandLine.StringToFloat64(name, value, usage, opts...)
+}
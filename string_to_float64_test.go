@@ -0,0 +1,201 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestStringToFloat64(t *testing.T) {
+	tests := []struct {
+		name              string
+		flagDefault       map[string]float64
+		input             []string
+		expectedErr       string
+		expectedValues    map[string]float64
+		expectedStrValues []string
+		visitor           func(f *zflag.Flag)
+	}{
+		{
+			name:              "no value passed",
+			input:             []string{},
+			flagDefault:       map[string]float64{},
+			expectedErr:       "",
+			expectedValues:    map[string]float64{},
+			expectedStrValues: []string{},
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			flagDefault: map[string]float64{},
+			expectedErr: `invalid argument "" for "--s2f64" flag:  must be formatted as key=value`,
+		},
+		{
+			name:        "invalid float64",
+			input:       []string{"blabla"},
+			flagDefault: map[string]float64{},
+			expectedErr: `invalid argument "blabla" for "--s2f64" flag: blabla must be formatted as key=value`,
+		},
+		{
+			name:        "no csv",
+			input:       []string{"test=1,5"},
+			flagDefault: map[string]float64{},
+			expectedErr: `invalid argument "test=1,5" for "--s2f64" flag: key "test": strconv.ParseFloat: parsing "1,5": invalid syntax`,
+		},
+		{
+			name:        "single key value pair per arg",
+			input:       []string{"test=1=1"},
+			flagDefault: map[string]float64{},
+			expectedErr: `invalid argument "test=1=1" for "--s2f64" flag: key "test": strconv.ParseFloat: parsing "1=1": invalid syntax`,
+		},
+		{
+			name:              "overrides multiple calls",
+			input:             []string{"test=1", "test=5"},
+			flagDefault:       map[string]float64{},
+			expectedValues:    map[string]float64{"test": 5},
+			expectedStrValues: []string{"test=5"},
+		},
+		{
+			name:              "empty defaults",
+			input:             []string{"test=1", "test2=5"},
+			flagDefault:       map[string]float64{},
+			expectedValues:    map[string]float64{"test": 1, "test2": 5},
+			expectedStrValues: []string{"test=1", "test2=5"},
+		},
+		{
+			name:              "overrides default values",
+			input:             []string{"test=1", "test2=5"},
+			flagDefault:       map[string]float64{"test2": 1, "test": 5},
+			expectedValues:    map[string]float64{"test": 1, "test2": 5},
+			expectedStrValues: []string{"test=1", "test2=5"},
+		},
+		{
+			name:              "returns default values",
+			input:             []string{},
+			flagDefault:       map[string]float64{"test2": 1, "test": 5},
+			expectedValues:    map[string]float64{"test2": 1, "test": 5},
+			expectedStrValues: []string{"test2=1", "test=5"},
+		},
+		{
+			name:              "fractional values",
+			input:             []string{"cpu=0.5", "mem=1e3"},
+			flagDefault:       map[string]float64{},
+			expectedValues:    map[string]float64{"cpu": 0.5, "mem": 1000},
+			expectedStrValues: []string{"cpu=0.5", "mem=1000"},
+		},
+		{
+			name:              "trims input",
+			input:             []string{"test=    1", "test2=5     ", "test3=     9     "},
+			flagDefault:       map[string]float64{},
+			expectedValues:    map[string]float64{"test": 1, "test2": 5, "test3": 9},
+			expectedStrValues: []string{"test=1", "test2=5", "test3=9"},
+		},
+	}
+
+	t.Parallel()
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var s2f64 map[string]float64
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.StringToFloat64Var(&s2f64, "s2f64", test.flagDefault, "usage")
+			err := f.Parse(repeatFlag("--s2f64", test.input...))
+			if test.expectedErr != "" {
+				assertErr(t, err)
+				assertEqualf(t, test.expectedErr, err.Error(), "expected error to equal %q, but was: %s", test.expectedErr, err)
+				return
+			}
+
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+
+			assertDeepEqual(t, test.expectedValues, s2f64)
+
+			s2f64GetS2F64, err := f.GetStringToFloat64("s2f64")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, s2f64GetS2F64)
+
+			s2f64Get, err := f.Get("s2f64")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, s2f64Get)
+
+			flag := f.Lookup("s2f64")
+			strVal := flag.Value.String()
+			if len(test.expectedStrValues) == 0 {
+				assertEqual(t, "[]", strVal)
+			} else {
+				assertEqual(t, '[', rune(strVal[0]))
+				assertEqual(t, ']', rune(strVal[len(strVal)-1]))
+
+				strVals := strings.Split(strVal[1:len(strVal)-1], " ")
+				sort.Strings(strVals)
+				sort.Strings(test.expectedStrValues)
+				assertDeepEqual(t, test.expectedStrValues, strVals)
+			}
+
+			defer assertNoPanic(t)()
+			mustStringToFloat64 := f.MustGetStringToFloat64("s2f64")
+			assertDeepEqual(t, test.expectedValues, mustStringToFloat64)
+		})
+	}
+}
+
+func TestStringToFloat64Errors(t *testing.T) {
+	t.Parallel()
+
+	var s string
+	var s2f64 map[string]float64
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StringVar(&s, "s", "", "usage")
+	f.StringToFloat64Var(&s2f64, "s2f64", map[string]float64{}, "usage")
+	err := f.Parse([]string{})
+	assertNoErr(t, err)
+
+	_, err = f.GetStringToFloat64("s")
+	assertErr(t, err)
+
+	defer assertPanic(t)()
+	_ = f.MustGetStringToFloat64("s")
+}
+
+func TestMapValueReplaceMap(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	s2s := f.StringToString("s2s", map[string]string{"a": "b"}, "usage")
+	s2i := f.StringToInt("s2i", map[string]int{"a": 1}, "usage")
+	s2i64 := f.StringToInt64("s2i64", map[string]int64{"a": 1}, "usage")
+	s2f64 := f.StringToFloat64("s2f64", map[string]float64{"a": 1}, "usage")
+
+	replace := map[string]string{"cpu": "2", "mem": "4"}
+	for _, name := range []string{"s2s", "s2i", "s2i64", "s2f64"} {
+		mv, ok := f.Lookup(name).Value.(zflag.MapValue)
+		if !ok {
+			t.Fatalf("expected %s to implement MapValue", name)
+		}
+		assertNoErr(t, mv.ReplaceMap(replace))
+		assertDeepEqual(t, replace, mv.GetMap())
+	}
+
+	assertDeepEqual(t, map[string]string{"cpu": "2", "mem": "4"}, *s2s)
+	assertDeepEqual(t, map[string]int{"cpu": 2, "mem": 4}, *s2i)
+	assertDeepEqual(t, map[string]int64{"cpu": 2, "mem": 4}, *s2i64)
+	assertDeepEqual(t, map[string]float64{"cpu": 2, "mem": 4}, *s2f64)
+
+	err := f.Lookup("s2f64").Value.(zflag.MapValue).ReplaceMap(map[string]string{"cpu": "many"})
+	assertErrMsg(t, `key "cpu": strconv.ParseFloat: parsing "many": invalid syntax`, err)
+	assertDeepEqual(t, map[string]float64{"cpu": 2, "mem": 4}, *s2f64)
+}
@@ -14,11 +14,13 @@ type stringToIntValue struct {
 	value         *map[string]int
 	changed       bool
 	valueOptional bool
+	uniqueKeys    bool
 }
 
 var _ Value = (*stringToIntValue)(nil)
 var _ Getter = (*stringToIntValue)(nil)
 var _ Typed = (*stringToIntValue)(nil)
+var _ MapValue = (*stringToIntValue)(nil)
 
 func newStringToIntValue(val map[string]int, p *map[string]int) *stringToIntValue {
 	ssv := new(stringToIntValue)
@@ -38,11 +40,13 @@ func (s *stringToIntValue) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.Atoi(val)
 	if err != nil {
-		return err
+		return NewKeyError(key, err)
 	}
 
 	if !s.changed {
 		*s.value = map[string]int{}
+	} else if _, exists := (*s.value)[key]; exists && s.uniqueKeys {
+		return NewKeyError(key, errDuplicateKey)
 	}
 
 	(*s.value)[key] = v
@@ -68,6 +72,28 @@ func (s *stringToIntValue) String() string {
 	return fmt.Sprintf("%s", records)
 }
 
+// ReplaceMap replaces the contents of the map, parsing each of its values.
+func (s *stringToIntValue) ReplaceMap(val map[string]string) error {
+	out := make(map[string]int, len(val))
+	for k, v := range val {
+		i, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return NewKeyError(k, err)
+		}
+		out[k] = i
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *stringToIntValue) GetMap() map[string]string {
+	out := make(map[string]string, len(*s.value))
+	for k, v := range *s.value {
+		out[k] = strconv.Itoa(v)
+	}
+	return out
+}
+
 // GetStringToInt return the map[string]int value of a flag with the given name
 func (fs *FlagSet) GetStringToInt(name string) (map[string]int, error) {
 	val, err := fs.getFlagValue(name, "stringToInt")
@@ -14,11 +14,13 @@ type stringToInt64Value struct {
 	value         *map[string]int64
 	changed       bool
 	valueOptional bool
+	uniqueKeys    bool
 }
 
 var _ Value = (*stringToInt64Value)(nil)
 var _ Getter = (*stringToInt64Value)(nil)
 var _ Typed = (*stringToInt64Value)(nil)
+var _ MapValue = (*stringToInt64Value)(nil)
 
 func newStringToInt64Value(val map[string]int64, p *map[string]int64) *stringToInt64Value {
 	ssv := new(stringToInt64Value)
@@ -38,11 +40,13 @@ func (s *stringToInt64Value) Set(val string) error {
 	val = strings.TrimSpace(val)
 	v, err := strconv.ParseInt(val, 10, 64)
 	if err != nil {
-		return err
+		return NewKeyError(key, err)
 	}
 
 	if !s.changed {
 		*s.value = map[string]int64{}
+	} else if _, exists := (*s.value)[key]; exists && s.uniqueKeys {
+		return NewKeyError(key, errDuplicateKey)
 	}
 
 	(*s.value)[key] = v
@@ -68,6 +72,28 @@ func (s *stringToInt64Value) String() string {
 	return fmt.Sprintf("%s", records)
 }
 
+// ReplaceMap replaces the contents of the map, parsing each of its values.
+func (s *stringToInt64Value) ReplaceMap(val map[string]string) error {
+	out := make(map[string]int64, len(val))
+	for k, v := range val {
+		i, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+		if err != nil {
+			return NewKeyError(k, err)
+		}
+		out[k] = i
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *stringToInt64Value) GetMap() map[string]string {
+	out := make(map[string]string, len(*s.value))
+	for k, v := range *s.value {
+		out[k] = strconv.FormatInt(v, 10)
+	}
+	return out
+}
+
 // GetStringToInt64 return the map[string]int64 value of a flag with the given name
 func (fs *FlagSet) GetStringToInt64(name string) (map[string]int64, error) {
 	val, err := fs.getFlagValue(name, "stringToInt64")
@@ -45,13 +45,13 @@ func TestStringToInt(t *testing.T) {
 			name:        "no csv",
 			input:       []string{"test=1,5"},
 			flagDefault: map[string]int{},
-			expectedErr: `invalid argument "test=1,5" for "--s2i" flag: strconv.Atoi: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "test=1,5" for "--s2i" flag: key "test": strconv.Atoi: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:        "single key value pair per arg",
 			input:       []string{"test=1=1"},
 			flagDefault: map[string]int{},
-			expectedErr: `invalid argument "test=1=1" for "--s2i" flag: strconv.Atoi: parsing "1=1": invalid syntax`,
+			expectedErr: `invalid argument "test=1=1" for "--s2i" flag: key "test": strconv.Atoi: parsing "1=1": invalid syntax`,
 		},
 		{
 			name:              "overrides multiple calls",
@@ -4,21 +4,27 @@
 package zflag
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 )
 
+var errDuplicateKey = errors.New("duplicate key")
+
 // -- stringToString Value
 type stringToStringValue struct {
 	value         *map[string]string
 	changed       bool
 	valueOptional bool
+	uniqueKeys    bool
+	csv           bool
 }
 
 var _ Value = (*stringToStringValue)(nil)
 var _ Getter = (*stringToStringValue)(nil)
 var _ Typed = (*stringToStringValue)(nil)
+var _ MapValue = (*stringToStringValue)(nil)
 
 func newStringToStringValue(val map[string]string, p *map[string]string) *stringToStringValue {
 	ssv := new(stringToStringValue)
@@ -28,22 +34,40 @@ func newStringToStringValue(val map[string]string, p *map[string]string) *string
 }
 
 func (s *stringToStringValue) Set(val string) error {
-	kv := strings.SplitN(val, "=", 2)
-	if !s.valueOptional && len(kv) != 2 {
-		return fmt.Errorf("%q must be formatted as key=value", val)
+	records := []string{val}
+	if s.csv {
+		var err error
+		if records, err = readCSVRecord(val); err != nil {
+			return err
+		}
 	}
 
-	key := kv[0]
-	val = ""
-	if len(kv) == 2 {
-		val = kv[1]
+	pairs := make([][2]string, 0, len(records))
+	seen := make(map[string]bool, len(records))
+	for _, record := range records {
+		kv := strings.SplitN(record, "=", 2)
+		if !s.valueOptional && len(kv) != 2 {
+			return fmt.Errorf("%q must be formatted as key=value", record)
+		}
+
+		key, value := kv[0], ""
+		if len(kv) == 2 {
+			value = kv[1]
+		}
+
+		if _, exists := (*s.value)[key]; s.uniqueKeys && (seen[key] || s.changed && exists) {
+			return NewKeyError(key, errDuplicateKey)
+		}
+		seen[key] = true
+		pairs = append(pairs, [2]string{key, value})
 	}
 
 	if !s.changed {
 		*s.value = map[string]string{}
 	}
-
-	(*s.value)[key] = val
+	for _, pair := range pairs {
+		(*s.value)[pair[0]] = pair[1]
+	}
 	s.changed = true
 
 	return nil
@@ -66,6 +90,24 @@ func (s *stringToStringValue) String() string {
 	return fmt.Sprintf("%s", records)
 }
 
+// ReplaceMap replaces the contents of the map, parsing each of its values.
+func (s *stringToStringValue) ReplaceMap(val map[string]string) error {
+	out := make(map[string]string, len(val))
+	for k, v := range val {
+		out[k] = v
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *stringToStringValue) GetMap() map[string]string {
+	out := make(map[string]string, len(*s.value))
+	for k, v := range *s.value {
+		out[k] = v
+	}
+	return out
+}
+
 // GetStringToString return the map[string]string value of a flag with the given name
 func (fs *FlagSet) GetStringToString(name string) (map[string]string, error) {
 	val, err := fs.getFlagValue(name, "stringToString")
@@ -122,8 +164,34 @@ func OptMapValueOptional() Opt {
 		case *stringToInt64Value:
 			v.valueOptional = true
 			return nil
+		case *stringToFloat64Value:
+			v.valueOptional = true
+			return nil
 		}
 
 		return fmt.Errorf("value of type %T cannot be optional", f.Value)
 	}
 }
+
+// OptMapUniqueKeys makes map flags return an error when a key is passed more
+// than once, instead of keeping the last value.
+func OptMapUniqueKeys() Opt {
+	return func(f *Flag) error {
+		switch v := f.Value.(type) {
+		case *stringToStringValue:
+			v.uniqueKeys = true
+			return nil
+		case *stringToIntValue:
+			v.uniqueKeys = true
+			return nil
+		case *stringToInt64Value:
+			v.uniqueKeys = true
+			return nil
+		case *stringToFloat64Value:
+			v.uniqueKeys = true
+			return nil
+		}
+
+		return fmt.Errorf("value of type %T cannot have unique keys", f.Value)
+	}
+}
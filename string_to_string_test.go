@@ -63,6 +63,21 @@ func TestStringToString(t *testing.T) {
 			expectedValues:    map[string]string{"test": "5"},
 			expectedStrValues: []string{`test="5"`},
 		},
+		{
+			name:        "unique keys",
+			input:       []string{"test=1", "test=5"},
+			flagDefault: map[string]string{},
+			flagOpts:    []zflag.Opt{zflag.OptMapUniqueKeys()},
+			expectedErr: `invalid argument "test=5" for "--s2s" flag: key "test": duplicate key`,
+		},
+		{
+			name:              "unique keys override default values",
+			input:             []string{"test=1"},
+			flagDefault:       map[string]string{"test": "5"},
+			flagOpts:          []zflag.Opt{zflag.OptMapUniqueKeys()},
+			expectedValues:    map[string]string{"test": "1"},
+			expectedStrValues: []string{`test="1"`},
+		},
 		{
 			name:              "empty defaults",
 			input:             []string{"test=1", "test2=5"},
@@ -91,6 +106,28 @@ func TestStringToString(t *testing.T) {
 			expectedValues:    map[string]string{"test1": "asd   ", "test2": "   value", "test3": "    asd   ", "test4": "multi\nline\narg\npassed\nin\n"},
 			expectedStrValues: nil, // this one is a bit hard to test as maps don't keep order.
 		},
+		{
+			name:              "csv",
+			input:             []string{`a=1,"b=2,3"`, "c=4"},
+			flagDefault:       map[string]string{},
+			flagOpts:          []zflag.Opt{zflag.OptCSV()},
+			expectedValues:    map[string]string{"a": "1", "b": "2,3", "c": "4"},
+			expectedStrValues: []string{`a="1"`, `b="2,3"`, `c="4"`},
+		},
+		{
+			name:        "csv invalid pair",
+			input:       []string{"a=1,b"},
+			flagDefault: map[string]string{},
+			flagOpts:    []zflag.Opt{zflag.OptCSV()},
+			expectedErr: `invalid argument "a=1,b" for "--s2s" flag: "b" must be formatted as key=value`,
+		},
+		{
+			name:        "csv unique keys",
+			input:       []string{"a=1,a=2"},
+			flagDefault: map[string]string{},
+			flagOpts:    []zflag.Opt{zflag.OptCSV(), zflag.OptMapUniqueKeys()},
+			expectedErr: `invalid argument "a=1,a=2" for "--s2s" flag: key "a": duplicate key`,
+		},
 		{
 			name:              "value optional",
 			input:             []string{"test1"},
@@ -174,3 +211,24 @@ func TestStringToStringErrors(t *testing.T) {
 	defer assertPanic(t)()
 	_ = f.MustGetStringToString("s")
 }
+
+func TestStringToStringCSVRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	newFS := func() *zflag.FlagSet {
+		f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+		f.SetOutput(ioutil.Discard)
+		f.StringToString("s2s", nil, "usage", zflag.OptCSV())
+		return f
+	}
+	assertNoErr(t, zflag.CheckRoundTrip(newFS, `--s2s=a=1,"b=2,3"`, `--s2s="c=""x"""`))
+}
+
+func TestOptMapUniqueKeysInvalidFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.String("s", "", "usage", zflag.OptMapUniqueKeys())
+}
@@ -0,0 +1,317 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// StructVar defines a flag for every exported field of the struct p points to,
+// storing the value of each flag in its field. The current field values are
+// used as the default values.
+//
+// Flag names are derived from the field names in kebab-case (ListenAddr
+// becomes listen-addr) and passed through the normalize func of the FlagSet.
+// Fields of nested structs are prefixed with the name of the struct field
+// (Server.Port becomes server-port), while the fields of embedded structs are
+// promoted without a prefix.
+//
+// The flags of nested and embedded structs are placed in a flag group named
+// after the struct field. A nested pointer-to-struct field that is nil is
+// treated as an optional section: it is only assigned a new struct once any
+// of its flags is set, and stays nil otherwise.
+//
+// The following struct tags are supported:
+//
+//	flag:"name"      overrides the name of the flag, or the prefix for a nested struct
+//	flag:"-"         skips the field
+//	usage:"text"     sets the usage of the flag
+//	shorthand:"n"    sets the shorthand of the flag
+//	group:"name"     sets the group of the flag, or of all flags of a nested struct
+//
+// Fields can be of any type that implements Value through a pointer receiver,
+// is registered with RegisterType, or is natively supported by zflag.
+// StructVar panics if p is not a pointer to a struct, or a field type is not
+// supported.
+func (fs *FlagSet) StructVar(p interface{}) {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("zflag: StructVar expects a non-nil pointer to a struct, got %T", p))
+	}
+
+	fs.structVar(v.Elem(), structSection{})
+}
+
+// StructVar defines a flag for every exported field of the struct p points to.
+// See FlagSet.StructVar for more information.
+func StructVar(p interface{}) {
+	CommandLine.StructVar(p)
+}
+
+// Extract populates the exported fields of the struct p points to from the
+// current values of the flags, the read-side counterpart of StructVar for
+// flags that were defined by other means. The flag of each field is found
+// using the naming rules and struct tags of StructVar, so the flags defined by
+// StructVar for a struct type can be extracted into another struct of that
+// type. A nil pointer-to-struct field is only assigned if any of its flags was
+// changed.
+//
+// Extract returns an error if p is not a pointer to a struct, if no flag
+// exists for a field, or if the value of a flag cannot be assigned to its
+// field.
+func (fs *FlagSet) Extract(p interface{}) error {
+	v := reflect.ValueOf(p)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("expected a non-nil pointer to a struct, got %T", p)
+	}
+
+	_, err := fs.extract(v.Elem(), "")
+	return err
+}
+
+// Extract populates the struct p points to from the command-line flags.
+// See FlagSet.Extract for more information.
+func Extract(p interface{}) error {
+	return CommandLine.Extract(p)
+}
+
+// extract populates the fields of v, reporting whether any of the flags was
+// changed.
+func (fs *FlagSet) extract(v reflect.Value, prefix string) (bool, error) {
+	var changed bool
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		isEmbeddedStruct := field.Anonymous && field.Type.Kind() == reflect.Struct
+		if field.PkgPath != "" && !isEmbeddedStruct {
+			continue
+		}
+
+		tag := field.Tag.Get("flag")
+		if tag == "-" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = kebabCase(field.Name)
+		}
+		name = string(fs.normalizeFlagName(prefix + name))
+
+		fieldValue := v.Field(i)
+		if flag := fs.Lookup(name); flag != nil {
+			if err := extractFlag(flag, fieldValue); err != nil {
+				return false, fmt.Errorf("field %s.%s: %w", t, field.Name, err)
+			}
+			changed = changed || flag.Changed
+			continue
+		}
+
+		nestedPrefix := name + "-"
+		if field.Anonymous && tag == "" {
+			nestedPrefix = prefix
+		}
+
+		switch {
+		case fieldValue.Kind() == reflect.Struct:
+			nestedChanged, err := fs.extract(fieldValue, nestedPrefix)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || nestedChanged
+		case fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.Struct:
+			section := fieldValue
+			if section.IsNil() {
+				section = reflect.New(fieldValue.Type().Elem())
+			}
+			nestedChanged, err := fs.extract(section.Elem(), nestedPrefix)
+			if err != nil {
+				return false, err
+			}
+			if nestedChanged && fieldValue.IsNil() {
+				fieldValue.Set(section)
+			}
+			changed = changed || nestedChanged
+		default:
+			return false, fmt.Errorf("field %s.%s: %w", t, field.Name, NewUnknownFlagError(name))
+		}
+	}
+	return changed, nil
+}
+
+// extractFlag assigns the value of flag to field.
+func extractFlag(flag *Flag, field reflect.Value) error {
+	if getter, ok := flag.Value.(Getter); ok {
+		flag.used = true
+		value := reflect.ValueOf(getter.Get())
+		switch {
+		case !value.IsValid():
+			field.Set(reflect.Zero(field.Type()))
+			return nil
+		case value.Type().AssignableTo(field.Type()):
+			field.Set(value)
+			return nil
+		case value.Kind() == field.Kind() && value.Type().ConvertibleTo(field.Type()):
+			field.Set(value.Convert(field.Type()))
+			return nil
+		case value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Type().AssignableTo(field.Type()):
+			field.Set(value.Elem())
+			return nil
+		}
+	}
+
+	if ptr := field.Addr(); ptr.Type().Implements(valueType) {
+		target := ptr.Interface().(Value)
+		if from, ok := flag.Value.(SliceValue); ok {
+			if to, ok := target.(SliceValue); ok {
+				return to.Replace(from.GetSlice())
+			}
+		}
+		return target.Set(flag.Value.String())
+	}
+
+	return fmt.Errorf("cannot assign flag --%s of type %T to %s", flag.Name, flag.Value, field.Type())
+}
+
+var valueType = reflect.TypeOf((*Value)(nil)).Elem()
+
+// structSection holds the state shared by the flags of a (nested) struct.
+type structSection struct {
+	prefix string
+	group  string
+	// enable is called whenever one of the flags of the section is set,
+	// it is nil unless the section is part of a pointer-to-struct field.
+	enable func()
+}
+
+func (fs *FlagSet) structVar(v reflect.Value, section structSection) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		isEmbeddedStruct := field.Anonymous && field.Type.Kind() == reflect.Struct
+		if field.PkgPath != "" && !isEmbeddedStruct {
+			continue
+		}
+
+		tag := field.Tag.Get("flag")
+		if tag == "-" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = kebabCase(field.Name)
+		}
+		name = string(fs.normalizeFlagName(section.prefix + name))
+
+		fieldValue := v.Field(i)
+		value, ok := fs.structFieldValue(fieldValue)
+		if ok {
+			fs.structFieldVar(value, name, field, section)
+			continue
+		}
+
+		nested := structSection{
+			prefix: name + "-",
+			group:  field.Name,
+			enable: section.enable,
+		}
+		if field.Anonymous && tag == "" {
+			nested.prefix = section.prefix
+		}
+		if group, ok := field.Tag.Lookup("group"); ok {
+			nested.group = group
+		}
+
+		switch {
+		case fieldValue.Kind() == reflect.Struct:
+			fs.structVar(fieldValue, nested)
+		case fieldValue.Kind() == reflect.Ptr && fieldValue.Type().Elem().Kind() == reflect.Struct:
+			if fieldValue.IsNil() {
+				// the section is only assigned to the field once one of its flags is set
+				target := fieldValue
+				sectionValue := reflect.New(fieldValue.Type().Elem())
+				parentEnable := section.enable
+				nested.enable = func() {
+					if parentEnable != nil {
+						parentEnable()
+					}
+					if target.IsNil() {
+						target.Set(sectionValue)
+					}
+				}
+				fieldValue = sectionValue
+			}
+			fs.structVar(fieldValue.Elem(), nested)
+		default:
+			panic(fmt.Sprintf("zflag: unsupported type %s of field %s.%s", field.Type, t, field.Name))
+		}
+	}
+}
+
+func (fs *FlagSet) structFieldVar(value Value, name string, field reflect.StructField, section structSection) {
+	opts := make([]Opt, 0, 2)
+	if shorthand := field.Tag.Get("shorthand"); shorthand != "" {
+		opts = append(opts, OptShorthandStr(shorthand))
+	}
+
+	group := section.group
+	if g, ok := field.Tag.Lookup("group"); ok {
+		group = g
+	}
+	if group != "" {
+		opts = append(opts, OptGroup(group))
+	}
+
+	flag := fs.Var(value, name, field.Tag.Get("usage"), opts...)
+	if section.enable != nil {
+		flag.setHooks = append(flag.setHooks, func() error {
+			section.enable()
+			return nil
+		})
+	}
+}
+
+func (fs *FlagSet) structFieldValue(field reflect.Value) (Value, bool) {
+	ptr := field.Addr()
+	if !ptr.CanInterface() {
+		// fields of unexported embedded structs can only be traversed
+		return nil, false
+	}
+
+	if ptr.Type().Implements(valueType) {
+		return ptr.Interface().(Value), true
+	}
+
+	value, err := fs.ValueFor(ptr.Interface())
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// kebabCase converts a Go identifier into kebab-case, keeping acronyms
+// together, e.g. "HTTPServerAddr" becomes "http-server-addr".
+func kebabCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	b.Grow(len(name) + 4)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteByte('-')
+			}
+		}
+		if r == '_' {
+			r = '-'
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,246 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+type structServer struct {
+	Port        int
+	ListenAddr  string `usage:"address to listen on"`
+	ReadTimeout time.Duration
+}
+
+type structCommon struct {
+	Verbose bool `shorthand:"v"`
+}
+
+type structOptions struct {
+	structCommon
+	Name           string `flag:"username" usage:"the name"`
+	HTTPServerAddr string
+	MaxRetries2    int
+	Tags           []string
+	Server         structServer
+	Backup         structServer `flag:"bak" group:"Backups"`
+	Start          time.Time
+	Custom         customValue
+	Ignored        string `flag:"-"`
+	unexported     string
+}
+
+func TestStructVar(t *testing.T) {
+	t.Parallel()
+
+	opts := structOptions{Name: "anon", Server: structServer{Port: 80}}
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SortFlags = false
+	f.StructVar(&opts)
+
+	names := make([]string, 0)
+	f.VisitAll(func(flag *zflag.Flag) {
+		names = append(names, flag.Name)
+	})
+	assertDeepEqual(t, []string{
+		"verbose", "username", "http-server-addr", "max-retries2", "tags",
+		"server-port", "server-listen-addr", "server-read-timeout",
+		"bak-port", "bak-listen-addr", "bak-read-timeout",
+		"start", "custom",
+	}, names)
+
+	assertEqual(t, "anon", f.Lookup("username").DefValue)
+	assertEqual(t, "80", f.Lookup("server-port").DefValue)
+	assertEqual(t, "the name", f.Lookup("username").Usage)
+	assertEqual(t, "address to listen on", f.Lookup("server-listen-addr").Usage)
+	assertEqual(t, 'v', f.Lookup("verbose").Shorthand)
+	assertDeepEqual(t, []string{"", "Backups", "Server", "structCommon"}, f.Groups())
+	assertEqual(t, "structCommon", f.Lookup("verbose").Group)
+	assertEqual(t, "", f.Lookup("username").Group)
+	assertEqual(t, "Server", f.Lookup("server-port").Group)
+	assertEqual(t, "Backups", f.Lookup("bak-port").Group)
+
+	err := f.Parse([]string{
+		"-v", "--username=zulu", "--tags=a", "--tags=b", "--server-port=8080",
+		"--bak-read-timeout=1s", "--custom=5",
+	})
+	assertNoErr(t, err)
+	assertEqual(t, true, opts.Verbose)
+	assertEqual(t, "zulu", opts.Name)
+	assertDeepEqual(t, []string{"a", "b"}, opts.Tags)
+	assertEqual(t, 8080, opts.Server.Port)
+	assertEqual(t, time.Second, opts.Backup.ReadTimeout)
+	assertEqual(t, customValue(5), opts.Custom)
+}
+
+func TestStructVarNormalizeFunc(t *testing.T) {
+	t.Parallel()
+
+	var opts struct {
+		Server struct {
+			ListenAddr string
+		}
+	}
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetNormalizeFunc(func(f *zflag.FlagSet, name string) zflag.NormalizedName {
+		return zflag.NormalizedName(strings.ReplaceAll(name, "-", "."))
+	})
+	f.StructVar(&opts)
+
+	assertNotNilf(t, f.Lookup("server.listen.addr"), "expected flag to be normalized")
+}
+
+func TestStructVarInvalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input interface{}
+	}{
+		{name: "not a pointer", input: structOptions{}},
+		{name: "not a struct", input: new(int)},
+		{name: "unsupported field", input: &struct{ C chan int }{}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			defer assertPanic(t)()
+			f.StructVar(test.input)
+		})
+	}
+}
+
+type structTLS struct {
+	Cert string
+	Key  string
+}
+
+type structSections struct {
+	TLS     *structTLS
+	Enabled *structTLS `flag:"enabled"`
+	Outer   *struct {
+		Inner *structTLS
+	}
+}
+
+func TestStructVarPointerSections(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectTLS   bool
+		expectOuter bool
+	}{
+		{name: "no flags set", args: []string{}},
+		{name: "section flag set", args: []string{"--tls-cert=a.pem"}, expectTLS: true},
+		{name: "nested section flag set", args: []string{"--outer-inner-key=b.key"}, expectOuter: true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			opts := structSections{Enabled: &structTLS{Cert: "default.pem"}}
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.StructVar(&opts)
+			assertNoErr(t, f.Parse(test.args))
+
+			assertEqual(t, test.expectTLS, opts.TLS != nil)
+			assertEqual(t, test.expectOuter, opts.Outer != nil)
+			assertEqual(t, "default.pem", opts.Enabled.Cert)
+			assertEqual(t, "TLS", f.Lookup("tls-cert").Group)
+			if test.expectTLS {
+				assertEqual(t, "a.pem", opts.TLS.Cert)
+			}
+			if test.expectOuter {
+				assertEqual(t, "b.key", opts.Outer.Inner.Key)
+			}
+		})
+	}
+}
+
+func TestExtract(t *testing.T) {
+	t.Parallel()
+
+	var defined structOptions
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.StructVar(&defined)
+	assertNoErr(t, f.Parse([]string{
+		"-v", "--username=zulu", "--tags=a", "--tags=b", "--server-port=8080",
+		"--bak-read-timeout=1s", "--custom=5",
+	}))
+
+	var opts structOptions
+	assertNoErr(t, f.Extract(&opts))
+	assertDeepEqual(t, defined, opts)
+	assertEqual(t, true, opts.Verbose)
+	assertEqual(t, "zulu", opts.Name)
+	assertDeepEqual(t, []string{"a", "b"}, opts.Tags)
+	assertEqual(t, 8080, opts.Server.Port)
+	assertEqual(t, time.Second, opts.Backup.ReadTimeout)
+	assertEqual(t, customValue(5), opts.Custom)
+}
+
+func TestExtractManualFlags(t *testing.T) {
+	t.Parallel()
+
+	type level int
+	type options struct {
+		Name    string
+		Level   level `flag:"verbosity"`
+		Section *structServer
+		Unset   *structServer `flag:"other"`
+	}
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("name", "", "usage")
+	f.Int("verbosity", 0, "usage")
+	f.Int("section-port", 0, "usage")
+	f.String("section-listen-addr", "", "usage")
+	f.Duration("section-read-timeout", 0, "usage")
+	f.Int("other-port", 0, "usage")
+	f.String("other-listen-addr", "", "usage")
+	f.Duration("other-read-timeout", 0, "usage")
+	assertNoErr(t, f.Parse([]string{"--name=zulu", "--verbosity=2", "--section-port=80"}))
+
+	var opts options
+	assertNoErr(t, f.Extract(&opts))
+	assertEqual(t, "zulu", opts.Name)
+	assertEqual(t, level(2), opts.Level)
+	assertEqual(t, 80, opts.Section.Port)
+	assertEqual(t, (*structServer)(nil), opts.Unset)
+	assertEqual(t, 0, len(f.UnusedFlags()))
+}
+
+func TestExtractErrors(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.String("name", "", "usage")
+
+	var notStruct string
+	assertErrMsg(t, "expected a non-nil pointer to a struct, got *string", f.Extract(&notStruct))
+
+	var missing struct {
+		Name  string
+		Other string
+	}
+	assertErrMsg(t, "field struct { Name string; Other string }.Other: unknown flag: --other", f.Extract(&missing))
+
+	var mismatch struct{ Name int }
+	assertErrMsg(t, "field struct { Name int }.Name: cannot assign flag --name of type *zflag.stringValue to int", f.Extract(&mismatch))
+}
@@ -0,0 +1,108 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown flag
+// and a suggested flag.
+const maxSuggestionDistance = 2
+
+func formatSuggestion(suggestion string) string {
+	if suggestion == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (did you mean %s?)", suggestion)
+}
+
+// suggestFlag returns the flag, with dashes, nearest to the unknown long
+// flag name, or an empty string if there is no flag close enough.
+func (fs *FlagSet) suggestFlag(name string) string {
+	if fs.DisableSuggestions {
+		return ""
+	}
+
+	if flag := fs.nearestFlag(name); flag != nil {
+		return "--" + flag.Name
+	}
+	return ""
+}
+
+// suggestShorthand returns the flag, with dashes, that was most likely meant
+// when typing the shorthand cluster containing the unknown shorthand char.
+// Clusters are first matched against long flags, e.g. -vrbose suggests
+// --verbose, otherwise the shorthand with the other case is suggested.
+func (fs *FlagSet) suggestShorthand(cluster string, char rune) string {
+	if fs.DisableSuggestions {
+		return ""
+	}
+
+	if utf8.RuneCountInString(cluster) > 1 {
+		if flag := fs.nearestFlag(cluster); flag != nil {
+			return "--" + flag.Name
+		}
+	}
+
+	for _, c := range []rune{unicode.ToLower(char), unicode.ToUpper(char)} {
+		if flag, ok := fs.shorthands[c]; ok && c != char && flag.ShorthandDeprecated == "" && !flag.Hidden {
+			return fmt.Sprintf("-%c", c)
+		}
+	}
+	return ""
+}
+
+// nearestFlag returns the visible long flag with the smallest edit distance
+// to name, as long as it is within maxSuggestionDistance.
+func (fs *FlagSet) nearestFlag(name string) *Flag {
+	normalName := string(fs.normalizeFlagName(name))
+	var nearest *Flag
+	nearestDistance := maxSuggestionDistance + 1
+	for _, flag := range sortFlags(fs.formal) {
+		if flag.Hidden || flag.ShorthandOnly {
+			continue
+		}
+		if d := levenshtein(normalName, flag.Name); d < nearestDistance && d < utf8.RuneCountInString(flag.Name) {
+			nearest = flag
+			nearestDistance = d
+		}
+	}
+	return nearest
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = minInt(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func minInt(values ...int) int {
+	m := values[0]
+	for _, v := range values[1:] {
+		if v < m {
+			m = v
+		}
+	}
+	return m
+}
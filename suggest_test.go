@@ -0,0 +1,87 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSuggestions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		args        []string
+		disable     bool
+		expectedErr string
+	}{
+		{
+			name:        "long typo",
+			args:        []string{"--verbos"},
+			expectedErr: "unknown flag: --verbos (did you mean --verbose?)",
+		},
+		{
+			name:        "long swapped letters",
+			args:        []string{"--ouptut=x"},
+			expectedErr: "unknown flag: --ouptut (did you mean --output?)",
+		},
+		{
+			name:        "long too far",
+			args:        []string{"--unrelated"},
+			expectedErr: "unknown flag: --unrelated",
+		},
+		{
+			name:        "hidden flags are not suggested",
+			args:        []string{"--secrets"},
+			expectedErr: "unknown flag: --secrets",
+		},
+		{
+			name:        "shorthand cluster typo",
+			args:        []string{"-vrbose"},
+			expectedErr: "unknown shorthand flag: 'r' in -rbose (did you mean --verbose?)",
+		},
+		{
+			name:        "shorthand case",
+			args:        []string{"-O"},
+			expectedErr: "unknown shorthand flag: 'O' in -O (did you mean -o?)",
+		},
+		{
+			name:        "shorthand without suggestion",
+			args:        []string{"-x"},
+			expectedErr: "unknown shorthand flag: 'x' in -x",
+		},
+		{
+			name:        "disabled",
+			args:        []string{"--verbos"},
+			disable:     true,
+			expectedErr: "unknown flag: --verbos",
+		},
+		{
+			name:        "disabled shorthand",
+			args:        []string{"-vrbose"},
+			disable:     true,
+			expectedErr: "unknown shorthand flag: 'r' in -rbose",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.DisableSuggestions = test.disable
+			f.Bool("verbose", false, "verbose", zflag.OptShorthand('v'))
+			f.String("output", "", "output", zflag.OptShorthand('o'))
+			f.String("secret", "", "secret", zflag.OptHidden())
+
+			err := f.Parse(test.args)
+			assertErrMsg(t, test.expectedErr, err)
+		})
+	}
+}
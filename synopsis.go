@@ -0,0 +1,130 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+)
+
+// SetArgsUsage declares the positional arguments of the FlagSet for the
+// synopsis, e.g. "SOURCE... DEST" or "[ARGS...]".
+func (fs *FlagSet) SetArgsUsage(usage string) {
+	fs.argsUsage = usage
+}
+
+// SetArgsUsage declares the positional arguments of the command-line flags.
+// See FlagSet.SetArgsUsage for more information.
+func SetArgsUsage(usage string) {
+	CommandLine.SetArgsUsage(usage)
+}
+
+// Synopsis returns a one-line usage synopsis generated from the visible
+// flags and the positional arguments declared with SetArgsUsage, for the top
+// of help output, e.g.
+//
+//	tool [-v] [--output FILE] --name NAME [ARGS...]
+//
+// Required flags are shown without brackets and repeatable flags are
+// followed by an ellipsis. Flags marked mutually exclusive by name, see
+// Group.MarkMutuallyExclusive, are shown as alternatives, e.g.
+// [--json | --yaml].
+func (fs *FlagSet) Synopsis() string {
+	parts := []string{fs.name}
+	alternatives := fs.synopsisAlternatives()
+	done := make(map[*Flag]bool)
+
+	fs.VisitAll(func(flag *Flag) {
+		if done[flag] || fs.isUsageHidden(flag) {
+			return
+		}
+
+		group, ok := alternatives[flag]
+		if !ok {
+			group = []*Flag{flag}
+		}
+		var forms []string
+		required := false
+		for _, f := range group {
+			if done[f] || fs.isUsageHidden(f) {
+				continue
+			}
+			done[f] = true
+			forms = append(forms, synopsisForm(f))
+			required = required || f.isRequired(fs)
+		}
+
+		part := strings.Join(forms, " | ")
+		if !required {
+			part = "[" + part + "]"
+		} else if len(forms) > 1 {
+			part = "(" + part + ")"
+		}
+		if isRepeatable(flag) && len(forms) == 1 {
+			part += "..."
+		}
+		parts = append(parts, part)
+	})
+
+	if fs.argsUsage != "" {
+		parts = append(parts, fs.argsUsage)
+	}
+	return strings.Join(parts, " ")
+}
+
+// synopsisAlternatives maps the flags of each set of mutually exclusive
+// flags declared by name to the whole set.
+func (fs *FlagSet) synopsisAlternatives() map[*Flag][]*Flag {
+	alternatives := make(map[*Flag][]*Flag)
+	for _, exclusive := range fs.exclusiveFlags {
+		var group []*Flag
+		for _, name := range exclusive.names {
+			if flag := fs.Lookup(name); flag != nil {
+				group = append(group, flag)
+			}
+		}
+		for _, flag := range group {
+			if _, ok := alternatives[flag]; !ok {
+				alternatives[flag] = group
+			}
+		}
+	}
+	return alternatives
+}
+
+// synopsisForm returns the shortest form of flag with its value, e.g.
+// -o FILE or --name[=NAME].
+func synopsisForm(flag *Flag) string {
+	form := "--" + flag.Name
+	if flag.Shorthand != 0 && flag.ShorthandDeprecated == "" {
+		form = "-" + string(flag.Shorthand)
+	}
+
+	if _, isCount := flag.Value.(*countValue); isCount {
+		return form
+	}
+
+	varname, _ := UnquoteUsage(flag)
+	varname = strings.ToUpper(strings.TrimSpace(varname))
+	_, isOptional := flag.Value.(OptionalValue)
+	switch {
+	case varname == "":
+	case isOptional || flag.NoArgDefault != "":
+		if strings.HasPrefix(form, "--") {
+			form += "[=" + varname + "]"
+		} else {
+			form += "[" + varname + "]"
+		}
+	default:
+		form += " " + varname
+	}
+	return form
+}
+
+// isRepeatable reports whether the flag accumulates the values of repeated
+// occurrences.
+func isRepeatable(flag *Flag) bool {
+	_, isSlice := flag.Value.(SliceValue)
+	_, isCount := flag.Value.(*countValue)
+	return isSlice || isCount
+}
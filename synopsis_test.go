@@ -0,0 +1,42 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSynopsis(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("tool", zflag.ContinueOnError)
+	f.Bool("verbose", false, "verbose output", zflag.OptShorthand('v'))
+	f.String("output", "", "write to `file`", zflag.OptShorthand('o'))
+	f.String("name", "", "name", zflag.OptRequired())
+	f.StringSlice("tag", nil, "tags")
+	f.Count("debug", "debug level", zflag.OptShorthand('d'))
+	f.String("mode", "auto", "mode", zflag.OptNoArgDefault("fast"))
+	f.String("hidden", "", "hidden", zflag.OptHidden())
+	f.Bool("json", false, "json output")
+	f.Bool("yaml", false, "yaml output")
+	f.Group("", "").MarkMutuallyExclusive("json", "yaml")
+	f.SetArgsUsage("[ARGS...]")
+
+	assertEqual(t, "tool [-d]... [--json | --yaml] [--mode[=STRING]] --name STRING [-o FILE] [--tag STRINGS]... [-v] [ARGS...]", f.Synopsis())
+}
+
+func TestSynopsisRequiredAlternatives(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("tool", zflag.ContinueOnError)
+	f.String("file", "", "file", zflag.OptRequired())
+	f.String("url", "", "url")
+	f.Group("", "").MarkMutuallyExclusive("file", "url")
+	assertEqual(t, "tool (--file STRING | --url STRING)", f.Synopsis())
+
+	f = zflag.NewFlagSet("tool", zflag.ContinueOnError)
+	assertEqual(t, "tool", f.Synopsis())
+}
@@ -0,0 +1,174 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"errors"
+	"strings"
+)
+
+// Toggle is an element of a toggle slice flag, enabling or disabling the
+// named item.
+type Toggle struct {
+	Name    string
+	Enabled bool
+}
+
+// String returns the name of the item, prefixed with ! if it is disabled.
+func (t Toggle) String() string {
+	if t.Enabled {
+		return t.Name
+	}
+	return "!" + t.Name
+}
+
+func parseToggle(val string) (Toggle, error) {
+	val = strings.TrimSpace(val)
+	t := Toggle{Name: strings.TrimPrefix(val, "!"), Enabled: !strings.HasPrefix(val, "!")}
+	if t.Name == "" {
+		return Toggle{}, errors.New("toggle name must not be empty")
+	}
+	return t, nil
+}
+
+// -- toggleSlice Value
+type toggleSliceValue struct {
+	value   *[]Toggle
+	changed bool
+}
+
+var _ Value = (*toggleSliceValue)(nil)
+var _ Getter = (*toggleSliceValue)(nil)
+var _ SliceValue = (*toggleSliceValue)(nil)
+var _ Typed = (*toggleSliceValue)(nil)
+var _ NegatableValue = (*toggleSliceValue)(nil)
+
+func newToggleSliceValue(val []Toggle, p *[]Toggle) *toggleSliceValue {
+	tsv := new(toggleSliceValue)
+	tsv.value = p
+	*tsv.value = val
+	return tsv
+}
+
+// Set appends the item, which is disabled if it is prefixed with !.
+func (s *toggleSliceValue) Set(val string) error {
+	t, err := parseToggle(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []Toggle{}
+	}
+	*s.value = append(*s.value, t)
+	s.changed = true
+
+	return nil
+}
+
+// Negate disables the item passed to --no-<name>.
+func (s *toggleSliceValue) Negate(val string) (string, error) {
+	t, err := parseToggle(val)
+	if err != nil {
+		return "", NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+	t.Enabled = !t.Enabled
+	return t.String(), nil
+}
+
+func (s *toggleSliceValue) Get() interface{} {
+	return *s.value
+}
+
+func (s *toggleSliceValue) Type() string {
+	return "toggleSlice"
+}
+
+func (s *toggleSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), ",") + "]"
+}
+
+func (s *toggleSliceValue) Append(val string) error {
+	t, err := parseToggle(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, t)
+	return nil
+}
+
+func (s *toggleSliceValue) Replace(val []string) error {
+	out := make([]Toggle, len(val))
+	for i, d := range val {
+		var err error
+		out[i], err = parseToggle(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *toggleSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, t := range *s.value {
+		out[i] = t.String()
+	}
+	return out
+}
+
+// GetToggleSlice returns the []Toggle value of a flag with the given name.
+func (fs *FlagSet) GetToggleSlice(name string) ([]Toggle, error) {
+	val, err := fs.getFlagValue(name, "toggleSlice")
+	if err != nil {
+		return []Toggle{}, err
+	}
+	return val.([]Toggle), nil
+}
+
+// MustGetToggleSlice is like GetToggleSlice, but panics on error.
+func (fs *FlagSet) MustGetToggleSlice(name string) []Toggle {
+	val, err := fs.GetToggleSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// ToggleSliceVar defines a []Toggle flag with specified name, default value,
+// and usage string. The argument p points to a []Toggle variable in which to
+// store the value of the flag. Each occurrence of --<name> item enables the
+// item and each occurrence of --no-<name> item disables it, in the order in
+// which they were passed. The flag always has AddNegative set.
+func (fs *FlagSet) ToggleSliceVar(p *[]Toggle, name string, value []Toggle, usage string, opts ...Opt) {
+	fs.Var(newToggleSliceValue(value, p), name, usage, append([]Opt{OptAddNegative()}, opts...)...)
+}
+
+// ToggleSliceVar defines a []Toggle flag with specified name, default value,
+// and usage string. The argument p points to a []Toggle variable in which to
+// store the value of the flag.
+func ToggleSliceVar(p *[]Toggle, name string, value []Toggle, usage string, opts ...Opt) {
+	CommandLine.ToggleSliceVar(p, name, value, usage, opts...)
+}
+
+// ToggleSlice defines a []Toggle flag with specified name, default value, and
+// usage string. The return value is the address of a []Toggle variable that
+// stores the value of the flag.
+func (fs *FlagSet) ToggleSlice(name string, value []Toggle, usage string, opts ...Opt) *[]Toggle {
+	var p []Toggle
+	fs.ToggleSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// ToggleSlice defines a []Toggle flag with specified name, default value, and
+// usage string. The return value is the address of a []Toggle variable that
+// stores the value of the flag.
+func ToggleSlice(name string, value []Toggle, usage string, opts ...Opt) *[]Toggle {
+	return CommandLine.ToggleSlice(name, value, usage, opts...)
+}
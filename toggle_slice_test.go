@@ -0,0 +1,121 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestToggleSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name              string
+		flagDefault       []zflag.Toggle
+		args              []string
+		expectedErr       string
+		expectedValues    []zflag.Toggle
+		expectedStrValues string
+	}{
+		{
+			name:              "no value passed",
+			args:              []string{},
+			expectedStrValues: "[]",
+		},
+		{
+			name:              "enable and disable",
+			args:              []string{"--feature", "x", "--no-feature", "y", "--feature=!z", "--no-feature=!w"},
+			expectedValues:    []zflag.Toggle{{Name: "x", Enabled: true}, {Name: "y"}, {Name: "z"}, {Name: "w", Enabled: true}},
+			expectedStrValues: "[x,!y,!z,w]",
+		},
+		{
+			name:              "replaces default",
+			flagDefault:       []zflag.Toggle{{Name: "a", Enabled: true}},
+			args:              []string{"--no-feature", "b"},
+			expectedValues:    []zflag.Toggle{{Name: "b"}},
+			expectedStrValues: "[!b]",
+		},
+		{
+			name:              "keeps default",
+			flagDefault:       []zflag.Toggle{{Name: "a", Enabled: true}},
+			args:              []string{},
+			expectedValues:    []zflag.Toggle{{Name: "a", Enabled: true}},
+			expectedStrValues: "[a]",
+		},
+		{
+			name:        "empty name",
+			args:        []string{"--feature= "},
+			expectedErr: `invalid argument " " for "--feature" flag: element 1: toggle name must not be empty`,
+		},
+		{
+			name:        "empty negated name",
+			args:        []string{"--no-feature=!"},
+			expectedErr: `invalid argument "!" for "--feature" flag: element 1: toggle name must not be empty`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var toggles []zflag.Toggle
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.ToggleSliceVar(&toggles, "feature", test.flagDefault, "usage")
+			err := f.Parse(test.args)
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			assertDeepEqual(t, test.expectedValues, toggles)
+			got, err := f.GetToggleSlice("feature")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expectedValues, got)
+			assertDeepEqual(t, test.expectedValues, f.MustGetToggleSlice("feature"))
+			assertEqual(t, test.expectedStrValues, f.Lookup("feature").Value.String())
+		})
+	}
+}
+
+func TestToggleSliceSliceValue(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	toggles := f.ToggleSlice("feature", nil, "usage")
+	sliced := f.Lookup("feature").Value.(zflag.SliceValue)
+
+	assertNoErr(t, sliced.Replace([]string{"a", "!b"}))
+	assertNoErr(t, sliced.Append("c"))
+	assertErrMsg(t, "element 4: toggle name must not be empty", sliced.Append("!"))
+	assertErrMsg(t, "element 2: toggle name must not be empty", sliced.Replace([]string{"a", " "}))
+	assertDeepEqual(t, []string{"a", "!b", "c"}, sliced.GetSlice())
+	assertDeepEqual(t, []zflag.Toggle{{Name: "a", Enabled: true}, {Name: "b"}, {Name: "c", Enabled: true}}, *toggles)
+
+	_, err := f.GetBool("feature")
+	assertErr(t, err)
+}
+
+func TestToggleSliceUsage(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(&buf)
+	f.ToggleSlice("feature", nil, "features to toggle")
+	f.PrintDefaults()
+
+	assertEqual(t, "      --[no-]feature strings   features to toggle\n", buf.String())
+
+	tokens := f.Classify([]string{"--no-feature", "x"})
+	assertEqual(t, 2, len(tokens))
+	assertEqual(t, zflag.TokenValue, tokens[1].Kind)
+}
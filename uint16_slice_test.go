@@ -28,22 +28,22 @@ func TestUint16Slice(t *testing.T) {
 			expectedValues: []uint16{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []uint16{},
-			expectedErr: `invalid argument "" for "--ui16s" flag: strconv.ParseUint: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []uint16{},
+			expectedValues: []uint16{},
 		},
 		{
 			name:        "invalid uint16",
 			input:       []string{"blabla"},
 			flagDefault: []uint16{},
-			expectedErr: `invalid argument "blabla" for "--ui16s" flag: strconv.ParseUint: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--ui16s" flag: element 1: strconv.ParseUint: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []uint16{},
-			expectedErr: `invalid argument "1,5" for "--ui16s" flag: strconv.ParseUint: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--ui16s" flag: element 1: strconv.ParseUint: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
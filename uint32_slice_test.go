@@ -28,22 +28,22 @@ func TestUint32Slice(t *testing.T) {
 			expectedValues: []uint32{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []uint32{},
-			expectedErr: `invalid argument "" for "--ui32s" flag: strconv.ParseUint: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []uint32{},
+			expectedValues: []uint32{},
 		},
 		{
 			name:        "invalid uint32",
 			input:       []string{"blabla"},
 			flagDefault: []uint32{},
-			expectedErr: `invalid argument "blabla" for "--ui32s" flag: strconv.ParseUint: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--ui32s" flag: element 1: strconv.ParseUint: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []uint32{},
-			expectedErr: `invalid argument "1,5" for "--ui32s" flag: strconv.ParseUint: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--ui32s" flag: element 1: strconv.ParseUint: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
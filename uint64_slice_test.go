@@ -28,22 +28,22 @@ func TestUint64Slice(t *testing.T) {
 			expectedValues: []uint64{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []uint64{},
-			expectedErr: `invalid argument "" for "--ui64s" flag: strconv.ParseUint: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []uint64{},
+			expectedValues: []uint64{},
 		},
 		{
 			name:        "invalid uint64",
 			input:       []string{"blabla"},
 			flagDefault: []uint64{},
-			expectedErr: `invalid argument "blabla" for "--ui64s" flag: strconv.ParseUint: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--ui64s" flag: element 1: strconv.ParseUint: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []uint64{},
-			expectedErr: `invalid argument "1,5" for "--ui64s" flag: strconv.ParseUint: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--ui64s" flag: element 1: strconv.ParseUint: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
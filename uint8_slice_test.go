@@ -28,22 +28,22 @@ func TestUint8Slice(t *testing.T) {
 			expectedValues: []uint8{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []uint8{},
-			expectedErr: `invalid argument "" for "--ui8s" flag: strconv.ParseUint: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []uint8{},
+			expectedValues: []uint8{},
 		},
 		{
 			name:        "invalid uint8",
 			input:       []string{"blabla"},
 			flagDefault: []uint8{},
-			expectedErr: `invalid argument "blabla" for "--ui8s" flag: strconv.ParseUint: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--ui8s" flag: element 1: strconv.ParseUint: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []uint8{},
-			expectedErr: `invalid argument "1,5" for "--ui8s" flag: strconv.ParseUint: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--ui8s" flag: element 1: strconv.ParseUint: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
@@ -28,22 +28,22 @@ func TestUintSlice(t *testing.T) {
 			expectedValues: []uint{},
 		},
 		{
-			name:        "empty value passed",
-			input:       []string{""},
-			flagDefault: []uint{},
-			expectedErr: `invalid argument "" for "--uis" flag: strconv.ParseUint: parsing "": invalid syntax`,
+			name:           "empty value passed",
+			input:          []string{""},
+			flagDefault:    []uint{},
+			expectedValues: []uint{},
 		},
 		{
 			name:        "invalid uint",
 			input:       []string{"blabla"},
 			flagDefault: []uint{},
-			expectedErr: `invalid argument "blabla" for "--uis" flag: strconv.ParseUint: parsing "blabla": invalid syntax`,
+			expectedErr: `invalid argument "blabla" for "--uis" flag: element 1: strconv.ParseUint: parsing "blabla": invalid syntax`,
 		},
 		{
 			name:        "no csv",
 			input:       []string{"1,5"},
 			flagDefault: []uint{},
-			expectedErr: `invalid argument "1,5" for "--uis" flag: strconv.ParseUint: parsing "1,5": invalid syntax`,
+			expectedErr: `invalid argument "1,5" for "--uis" flag: element 1: strconv.ParseUint: parsing "1,5": invalid syntax`,
 		},
 		{
 			name:           "empty defaults",
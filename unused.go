@@ -0,0 +1,68 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MarkUsed records that the value of the named flag is used, for flags that
+// are only read through the pointer they are bound to, which cannot be
+// tracked. See UnusedFlags.
+func (fs *FlagSet) MarkUsed(names ...string) {
+	for _, name := range names {
+		flag := fs.Lookup(name)
+		if flag == nil {
+			panic(NewUnknownFlagError(name))
+		}
+		flag.used = true
+	}
+}
+
+// MarkUsed records that the value of the named command-line flag is used.
+// See FlagSet.MarkUsed for more information.
+func MarkUsed(names ...string) {
+	CommandLine.MarkUsed(names...)
+}
+
+// UnusedFlags returns the flags, in lexicographical order, whose value was
+// never read with Get, one of the typed getters or Changed, and that were not
+// marked with MarkUsed. It is meant to be called in development builds or
+// tests once the program has read its configuration, to find dead flags.
+func (fs *FlagSet) UnusedFlags() []*Flag {
+	var unused []*Flag
+	for _, flag := range sortFlags(fs.formal) {
+		if !flag.used {
+			unused = append(unused, flag)
+		}
+	}
+	return unused
+}
+
+// UnusedFlags returns the command-line flags whose value was never read.
+// See FlagSet.UnusedFlags for more information.
+func UnusedFlags() []*Flag {
+	return CommandLine.UnusedFlags()
+}
+
+// CheckUnusedFlags returns an error listing the unused flags, or nil if every
+// flag was used. See UnusedFlags.
+func (fs *FlagSet) CheckUnusedFlags() error {
+	unused := fs.UnusedFlags()
+	if len(unused) == 0 {
+		return nil
+	}
+	names := make([]string, len(unused))
+	for i, flag := range unused {
+		names[i] = "--" + flag.Name
+	}
+	return fmt.Errorf("flags defined but never used: %s", strings.Join(names, ", "))
+}
+
+// CheckUnusedFlags returns an error listing the unused command-line flags.
+// See FlagSet.CheckUnusedFlags for more information.
+func CheckUnusedFlags() error {
+	return CommandLine.CheckUnusedFlags()
+}
@@ -0,0 +1,49 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestUnusedFlags(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.String("read", "", "usage")
+	f.Bool("checked", false, "usage")
+	f.Int("bound", 0, "usage")
+	f.Int("dead", 0, "usage")
+	f.Duration("forgotten", 0, "usage")
+	assertNoErr(t, f.Parse([]string{"--read=a", "--dead=1"}))
+
+	_ = f.MustGetString("read")
+	_ = f.Changed("checked")
+	f.MarkUsed("bound")
+
+	unused := f.UnusedFlags()
+	assertEqual(t, 2, len(unused))
+	assertEqual(t, "dead", unused[0].Name)
+	assertEqual(t, "forgotten", unused[1].Name)
+	assertErrMsg(t, "flags defined but never used: --dead, --forgotten", f.CheckUnusedFlags())
+
+	_, err := f.Get("dead")
+	assertNoErr(t, err)
+	f.MarkUsed("forgotten")
+	assertEqual(t, 0, len(f.UnusedFlags()))
+	assertNoErr(t, f.CheckUnusedFlags())
+}
+
+func TestMarkUsedUnknownFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	f.MarkUsed("missing")
+}
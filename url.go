@@ -0,0 +1,155 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// urlConstraints are the optional checks of URL flags, set with
+// OptURLRequireScheme and OptURLSchemes.
+type urlConstraints struct {
+	requireScheme bool
+	schemes       []string
+}
+
+func (c *urlConstraints) parse(val string) (*url.URL, error) {
+	val = strings.TrimSpace(val)
+	if val == "" {
+		return nil, fmt.Errorf("failed to parse URL: %q", val)
+	}
+	u, err := url.Parse(val)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse URL: %q", val)
+	}
+
+	if (c.requireScheme || len(c.schemes) > 0) && u.Scheme == "" {
+		return nil, fmt.Errorf("URL %q has no scheme", val)
+	}
+	if len(c.schemes) > 0 && !containsString(c.schemes, strings.ToLower(u.Scheme)) {
+		return nil, fmt.Errorf("URL scheme %q is not allowed, expected one of: %s", u.Scheme, strings.Join(c.schemes, ", "))
+	}
+	return u, nil
+}
+
+func urlConstraintsOf(f *Flag) (*urlConstraints, error) {
+	switch v := f.Value.(type) {
+	case *urlValue:
+		return &v.constraints, nil
+	case *urlSliceValue:
+		return &v.constraints, nil
+	}
+	return nil, fmt.Errorf("flag %s is not a URL flag", f.Name)
+}
+
+// OptURLRequireScheme require URL flags to have a scheme
+func OptURLRequireScheme() Opt {
+	return func(f *Flag) error {
+		c, err := urlConstraintsOf(f)
+		if err != nil {
+			return err
+		}
+		c.requireScheme = true
+		return nil
+	}
+}
+
+// OptURLSchemes restrict URL flags to the given schemes
+func OptURLSchemes(schemes ...string) Opt {
+	return func(f *Flag) error {
+		c, err := urlConstraintsOf(f)
+		if err != nil {
+			return err
+		}
+		for _, scheme := range schemes {
+			c.schemes = append(c.schemes, strings.ToLower(scheme))
+		}
+		return nil
+	}
+}
+
+// -- *url.URL value
+type urlValue struct {
+	value       **url.URL
+	constraints urlConstraints
+}
+
+var _ Value = (*urlValue)(nil)
+var _ Getter = (*urlValue)(nil)
+var _ Typed = (*urlValue)(nil)
+
+func newURLValue(val *url.URL, p **url.URL) *urlValue {
+	*p = val
+	return &urlValue{value: p}
+}
+
+func (u *urlValue) String() string {
+	if *u.value == nil {
+		return ""
+	}
+	return (*u.value).String()
+}
+
+func (u *urlValue) Set(val string) error {
+	parsed, err := u.constraints.parse(val)
+	if err != nil {
+		return err
+	}
+	*u.value = parsed
+	return nil
+}
+
+func (u *urlValue) Get() interface{} {
+	return *u.value
+}
+
+func (u *urlValue) Type() string {
+	return "url"
+}
+
+// GetURL return the *url.URL value of a flag with the given name
+func (fs *FlagSet) GetURL(name string) (*url.URL, error) {
+	val, err := fs.getFlagValue(name, "url")
+	if err != nil {
+		return nil, err
+	}
+	return val.(*url.URL), nil
+}
+
+// MustGetURL is like GetURL, but panics on error.
+func (fs *FlagSet) MustGetURL(name string) *url.URL {
+	val, err := fs.GetURL(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// URLVar defines a *url.URL flag with specified name, default value, and usage string.
+// The argument p points to a *url.URL variable in which to store the value of the flag.
+func (fs *FlagSet) URLVar(p **url.URL, name string, value *url.URL, usage string, opts ...Opt) {
+	fs.Var(newURLValue(value, p), name, usage, opts...)
+}
+
+// URLVar defines a *url.URL flag with specified name, default value, and usage string.
+// The argument p points to a *url.URL variable in which to store the value of the flag.
+func URLVar(p **url.URL, name string, value *url.URL, usage string, opts ...Opt) {
+	CommandLine.URLVar(p, name, value, usage, opts...)
+}
+
+// URL defines a *url.URL flag with specified name, default value, and usage string.
+// The return value is the address of a *url.URL variable that stores the value of the flag.
+func (fs *FlagSet) URL(name string, value *url.URL, usage string, opts ...Opt) **url.URL {
+	var p *url.URL
+	fs.URLVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// URL defines a *url.URL flag with specified name, default value, and usage string.
+// The return value is the address of a *url.URL variable that stores the value of the flag.
+func URL(name string, value *url.URL, usage string, opts ...Opt) **url.URL {
+	return CommandLine.URL(name, value, usage, opts...)
+}
@@ -0,0 +1,138 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"net/url"
+	"strings"
+)
+
+// -- urlSlice Value
+type urlSliceValue struct {
+	value       *[]*url.URL
+	changed     bool
+	constraints urlConstraints
+}
+
+var _ Value = (*urlSliceValue)(nil)
+var _ Getter = (*urlSliceValue)(nil)
+var _ SliceValue = (*urlSliceValue)(nil)
+var _ Typed = (*urlSliceValue)(nil)
+
+func newURLSliceValue(val []*url.URL, p *[]*url.URL) *urlSliceValue {
+	usv := new(urlSliceValue)
+	usv.value = p
+	*usv.value = val
+	return usv
+}
+
+// Set converts, and assigns, the URL argument string representation as the []*url.URL value of this flag.
+// If Set is called on a flag that already has a []*url.URL assigned, the newly converted values will be appended.
+func (s *urlSliceValue) Set(val string) error {
+	u, err := s.constraints.parse(val)
+	if err != nil {
+		return NewElementError(elementPosition(s.changed, len(*s.value)), err)
+	}
+
+	if !s.changed {
+		*s.value = []*url.URL{}
+	}
+	*s.value = append(*s.value, u)
+
+	s.changed = true
+
+	return nil
+}
+
+func (s *urlSliceValue) Get() interface{} {
+	return *s.value
+}
+
+// Type returns a string that uniquely represents this flag's type.
+func (s *urlSliceValue) Type() string {
+	return "urlSlice"
+}
+
+// String defines a "native" format for this *url.URL slice flag value.
+func (s *urlSliceValue) String() string {
+	if s.value == nil {
+		return "[]"
+	}
+
+	return "[" + strings.Join(s.GetSlice(), " ") + "]"
+}
+
+func (s *urlSliceValue) Append(val string) error {
+	u, err := s.constraints.parse(val)
+	if err != nil {
+		return NewElementError(len(*s.value)+1, err)
+	}
+	*s.value = append(*s.value, u)
+	return nil
+}
+
+func (s *urlSliceValue) Replace(val []string) error {
+	out := make([]*url.URL, len(val))
+	for i, d := range val {
+		u, err := s.constraints.parse(d)
+		if err != nil {
+			return NewElementError(i+1, err)
+		}
+		out[i] = u
+	}
+	*s.value = out
+	return nil
+}
+
+func (s *urlSliceValue) GetSlice() []string {
+	out := make([]string, len(*s.value))
+	for i, d := range *s.value {
+		out[i] = d.String()
+	}
+	return out
+}
+
+// GetURLSlice returns the []*url.URL value of a flag with the given name
+func (fs *FlagSet) GetURLSlice(name string) ([]*url.URL, error) {
+	val, err := fs.getFlagValue(name, "urlSlice")
+	if err != nil {
+		return []*url.URL{}, err
+	}
+	return val.([]*url.URL), nil
+}
+
+// MustGetURLSlice is like GetURLSlice, but panics on error.
+func (fs *FlagSet) MustGetURLSlice(name string) []*url.URL {
+	val, err := fs.GetURLSlice(name)
+	if err != nil {
+		panic(err)
+	}
+	return val
+}
+
+// URLSliceVar defines a []*url.URL flag with specified name, default value, and usage string.
+// The argument p points to a []*url.URL variable in which to store the value of the flag.
+func (fs *FlagSet) URLSliceVar(p *[]*url.URL, name string, value []*url.URL, usage string, opts ...Opt) {
+	fs.Var(newURLSliceValue(value, p), name, usage, opts...)
+}
+
+// URLSliceVar defines a []*url.URL flag with specified name, default value, and usage string.
+// The argument p points to a []*url.URL variable in which to store the value of the flag.
+func URLSliceVar(p *[]*url.URL, name string, value []*url.URL, usage string, opts ...Opt) {
+	CommandLine.URLSliceVar(p, name, value, usage, opts...)
+}
+
+// URLSlice defines a []*url.URL flag with specified name, default value, and usage string.
+// The return value is the address of a []*url.URL variable that stores the value of the flag.
+func (fs *FlagSet) URLSlice(name string, value []*url.URL, usage string, opts ...Opt) *[]*url.URL {
+	var p []*url.URL
+	fs.URLSliceVar(&p, name, value, usage, opts...)
+	return &p
+}
+
+// URLSlice defines a []*url.URL flag with specified name, default value, and usage string.
+// The return value is the address of a []*url.URL variable that stores the value of the flag.
+func URLSlice(name string, value []*url.URL, usage string, opts ...Opt) *[]*url.URL {
+	return CommandLine.URLSlice(name, value, usage, opts...)
+}
@@ -0,0 +1,91 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestURLSlice(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault []*url.URL
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    []*url.URL
+		visitor     func(f *zflag.Flag)
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: nil,
+		},
+		{
+			name:     "multiple values",
+			input:    []string{"https://a.example.com", "https://b.example.com"},
+			expected: []*url.URL{mustParseURL("https://a.example.com"), mustParseURL("https://b.example.com")},
+		},
+		{
+			name:        "overrides default values",
+			input:       []string{"https://b.example.com"},
+			flagDefault: []*url.URL{mustParseURL("https://a.example.com")},
+			expected:    []*url.URL{mustParseURL("https://b.example.com")},
+		},
+		{
+			name:        "disallowed scheme",
+			opts:        []zflag.Opt{zflag.OptURLSchemes("https")},
+			input:       []string{"https://a.example.com", "http://b.example.com"},
+			expectedErr: `invalid argument "http://b.example.com" for "--urls" flag: element 2: URL scheme "http" is not allowed, expected one of: https`,
+		},
+		{
+			name:  "as slice values",
+			input: []string{"https://a.example.com"},
+			visitor: func(f *zflag.Flag) {
+				sv := f.Value.(zflag.SliceValue)
+				_ = sv.Replace([]string{"https://b.example.com"})
+				_ = sv.Append("https://c.example.com")
+			},
+			expected: []*url.URL{mustParseURL("https://b.example.com"), mustParseURL("https://c.example.com")},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var urls []*url.URL
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.URLSliceVar(&urls, "urls", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--urls", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+
+			if test.visitor != nil {
+				f.VisitAll(test.visitor)
+			}
+			assertDeepEqual(t, test.expected, urls)
+
+			got, err := f.GetURLSlice("urls")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetURLSlice("urls"))
+
+			sv := f.Lookup("urls").Value.(zflag.SliceValue)
+			assertErrMsg(t, `failed to parse URL: ""`, errors.Unwrap(sv.Append("")))
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"net/url"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func mustParseURL(s string) *url.URL {
+	u, err := url.Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return u
+}
+
+func TestURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		flagDefault *url.URL
+		opts        []zflag.Opt
+		input       []string
+		expectedErr string
+		expected    *url.URL
+	}{
+		{
+			name:     "no value passed",
+			input:    []string{},
+			expected: nil,
+		},
+		{
+			name:        "with default value",
+			input:       []string{},
+			flagDefault: mustParseURL("https://example.com"),
+			expected:    mustParseURL("https://example.com"),
+		},
+		{
+			name:     "url",
+			input:    []string{"https://user@example.com:8443/path?q=1"},
+			expected: mustParseURL("https://user@example.com:8443/path?q=1"),
+		},
+		{
+			name:     "trims input",
+			input:    []string{"  /relative/path  "},
+			expected: mustParseURL("/relative/path"),
+		},
+		{
+			name:        "empty value passed",
+			input:       []string{""},
+			expectedErr: `invalid argument "" for "--url" flag: failed to parse URL: ""`,
+		},
+		{
+			name:        "invalid url",
+			input:       []string{"http://[::1"},
+			expectedErr: `invalid argument "http://[::1" for "--url" flag: failed to parse URL: "http://[::1"`,
+		},
+		{
+			name:        "require scheme",
+			opts:        []zflag.Opt{zflag.OptURLRequireScheme()},
+			input:       []string{"example.com"},
+			expectedErr: `invalid argument "example.com" for "--url" flag: URL "example.com" has no scheme`,
+		},
+		{
+			name:     "allowed scheme",
+			opts:     []zflag.Opt{zflag.OptURLSchemes("http", "HTTPS")},
+			input:    []string{"HTTPS://example.com"},
+			expected: mustParseURL("HTTPS://example.com"),
+		},
+		{
+			name:        "disallowed scheme",
+			opts:        []zflag.Opt{zflag.OptURLSchemes("http", "https")},
+			input:       []string{"ftp://example.com"},
+			expectedErr: `invalid argument "ftp://example.com" for "--url" flag: URL scheme "ftp" is not allowed, expected one of: http, https`,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var u *url.URL
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(ioutil.Discard)
+			f.URLVar(&u, "url", test.flagDefault, "usage", test.opts...)
+			err := f.Parse(repeatFlag("--url", test.input...))
+			if test.expectedErr != "" {
+				assertErrMsg(t, test.expectedErr, err)
+				return
+			}
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, u)
+
+			got, err := f.GetURL("url")
+			assertNoErr(t, err)
+			assertDeepEqual(t, test.expected, got)
+			assertDeepEqual(t, test.expected, f.MustGetURL("url"))
+		})
+	}
+}
+
+func TestURLOptOnOtherFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	defer assertPanic(t)()
+	f.String("name", "", "usage", zflag.OptURLSchemes("https"))
+}
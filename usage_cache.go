@@ -0,0 +1,69 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"strings"
+)
+
+// usageCacheKey identifies a rendering of the flag usages.
+type usageCacheKey struct {
+	group          string
+	cols           int
+	sortFlags      bool
+	showDeprecated bool
+	visibility     string // visibility holds the results of the OptVisibleWhen functions.
+}
+
+// newUsageCacheKey returns the cache key for the usages of group, evaluating
+// the visibility of flags that are conditionally visible.
+func (fs *FlagSet) newUsageCacheKey(group string, cols int) usageCacheKey {
+	var visibility strings.Builder
+	for _, flag := range fs.orderedFormal {
+		if flag.visibleWhen == nil {
+			continue
+		}
+		if flag.visibleWhen(fs) {
+			visibility.WriteByte('1')
+		} else {
+			visibility.WriteByte('0')
+		}
+	}
+
+	return usageCacheKey{
+		group:          group,
+		cols:           cols,
+		sortFlags:      fs.SortFlags,
+		showDeprecated: fs.ShowDeprecated,
+		visibility:     visibility.String(),
+	}
+}
+
+// InvalidateUsageCache clears the usages cached while CacheUsage is set.
+// Adding, removing and renaming flags clears the cache automatically, but
+// it must be called after modifying the fields of a defined Flag, or after
+// changing FlagUsageFormatter or NegativePrefix.
+func (fs *FlagSet) InvalidateUsageCache() {
+	fs.usageCache = nil
+}
+
+// cachedFlagUsages returns the usages of group from the cache, rendering
+// them with render if they are not cached or caching is disabled.
+func (fs *FlagSet) cachedFlagUsages(group string, cols int, render func() string) string {
+	if !fs.CacheUsage {
+		return render()
+	}
+
+	key := fs.newUsageCacheKey(group, cols)
+	if usages, ok := fs.usageCache[key]; ok {
+		return usages
+	}
+
+	usages := render()
+	if fs.usageCache == nil {
+		fs.usageCache = make(map[usageCacheKey]string)
+	}
+	fs.usageCache[key] = usages
+	return usages
+}
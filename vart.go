@@ -0,0 +1,20 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag
+
+// VarT defines a flag of type T with specified name, default value, and usage
+// string. The argument p points to a variable of type T in which to store the
+// value of the flag. T must be natively supported by zflag or registered
+// with RegisterType, otherwise VarT panics.
+func VarT[T any](fs *FlagSet, p *T, name string, value T, usage string, opts ...Opt) *Flag {
+	*p = value
+	v, err := fs.ValueFor(p)
+	if err != nil {
+		panic(err)
+	}
+	return fs.Var(v, name, usage, opts...)
+}
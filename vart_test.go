@@ -0,0 +1,45 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+//go:build go1.18
+// +build go1.18
+
+package zflag_test
+
+import (
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestVarT(t *testing.T) {
+	t.Parallel()
+
+	var (
+		p point
+		d time.Duration
+	)
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	zflag.VarT(f, &p, "point", point{1, 1}, "usage")
+	zflag.VarT(f, &d, "duration", time.Second, "usage")
+
+	assertEqual(t, point{1, 1}, p)
+	assertEqual(t, time.Second, d)
+
+	assertNoErr(t, f.Parse([]string{"--point=5,6", "--duration=1m"}))
+	assertEqual(t, point{5, 6}, p)
+	assertEqual(t, time.Minute, f.MustGetDuration("duration"))
+}
+
+func TestVarTUnsupported(t *testing.T) {
+	t.Parallel()
+
+	var c chan int
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+
+	defer assertPanic(t)()
+	zflag.VarT(f, &c, "chan", nil, "usage")
+}
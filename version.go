@@ -0,0 +1,30 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag
+
+import (
+	"fmt"
+)
+
+// SetVersion enables the built-in handling of --version and -V, which print
+// version to the output of the FlagSet, after which parsing stops and
+// ErrVersion is returned. Flags named version, or with the shorthand V, take
+// precedence over the built-in handling.
+func (fs *FlagSet) SetVersion(version string) {
+	fs.SetVersionFunc(func() {
+		fmt.Fprintln(fs.Output(), version)
+	})
+}
+
+// SetVersionFunc is like SetVersion, but calls fn instead of printing a
+// version string. Passing nil disables the built-in handling of --version.
+func (fs *FlagSet) SetVersionFunc(fn func()) {
+	fs.versionFunc = fn
+}
+
+// SetVersion enables the built-in handling of --version and -V for the
+// command-line flags. See FlagSet.SetVersion for more information.
+func SetVersion(version string) {
+	CommandLine.SetVersion(version)
+}
@@ -0,0 +1,79 @@
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zflag_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"testing"
+
+	"github.com/zulucmd/zflag/v2"
+)
+
+func TestSetVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{name: "long", args: []string{"--version"}, expected: "1.2.3\n"},
+		{name: "shorthand", args: []string{"-V"}, expected: "1.2.3\n"},
+		{name: "shorthand cluster", args: []string{"-bV"}, expected: "1.2.3\n"},
+		{name: "after positional", args: []string{"arg", "--version"}, expected: "1.2.3\n"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+			f.SetOutput(&buf)
+			f.Bool("bool", false, "bool", zflag.OptShorthand('b'))
+			f.SetVersion("1.2.3")
+
+			err := f.Parse(test.args)
+			assertEqual(t, zflag.ErrVersion, err)
+			assertEqual(t, test.expected, buf.String())
+		})
+	}
+}
+
+func TestSetVersionFunc(t *testing.T) {
+	t.Parallel()
+
+	called := 0
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetVersionFunc(func() { called++ })
+
+	err := f.Parse([]string{"--version"})
+	assertEqual(t, zflag.ErrVersion, err)
+	assertEqual(t, 1, called)
+
+	f.SetVersionFunc(nil)
+	err = f.Parse([]string{"--version"})
+	assertErrMsg(t, "unknown flag: --version", err)
+	assertEqual(t, 1, called)
+}
+
+func TestSetVersionDefinedFlag(t *testing.T) {
+	t.Parallel()
+
+	f := zflag.NewFlagSet("test", zflag.ContinueOnError)
+	f.SetOutput(ioutil.Discard)
+	f.SetVersion("1.2.3")
+	version := f.Bool("version", false, "print version", zflag.OptShorthand('V'))
+
+	err := f.Parse([]string{"-V"})
+	assertNoErr(t, err)
+	assertEqual(t, true, *version)
+
+	err = f.Parse([]string{"--version"})
+	assertNoErr(t, err)
+	assertEqual(t, true, *version)
+}